@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+type BulkCancelHandler struct{}
+
+func (h *BulkCancelHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, logic.BulkCancelCustomIdPrefix)
+	})
+}
+
+func (h *BulkCancelHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+		PermissionLevel: permission.Admin,
+		Timeout:         time.Second * 3,
+	}
+}
+
+func (h *BulkCancelHandler) Execute(ctx *context.ButtonContext) {
+	id := strings.TrimPrefix(ctx.InteractionData.CustomId, logic.BulkCancelCustomIdPrefix)
+	if err := redis.DeleteBulkOperation(ctx, id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.EditWith(customisation.Red, i18n.TitleBulk, i18n.MessageBulkCancelled)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// Closing a ticket makes many requests, so only a few tickets are processed at once
+	bulkConcurrency      = 5
+	bulkProgressInterval = time.Second * 3
+)
+
+type BulkConfirmHandler struct{}
+
+func (h *BulkConfirmHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, logic.BulkConfirmCustomIdPrefix)
+	})
+}
+
+func (h *BulkConfirmHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+		PermissionLevel: permission.Admin,
+		// Interaction tokens are valid for 15 minutes, after which the progress message can no longer be edited
+		Timeout: time.Minute * 14,
+	}
+}
+
+func (h *BulkConfirmHandler) Execute(ctx *context.ButtonContext) {
+	id := strings.TrimPrefix(ctx.InteractionData.CustomId, logic.BulkConfirmCustomIdPrefix)
+
+	op, ok, err := logic.TakeBulkOperation(ctx, id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// The preview is ephemeral, so only the admin who requested it can confirm it
+	if !ok || op.GuildId != ctx.GuildId() || op.UserId != ctx.UserId() {
+		ctx.EditWith(customisation.Red, i18n.TitleBulk, i18n.MessageBulkExpired)
+		return
+	}
+
+	runner, ok, err := logic.NewBulkRunner(ctx, op)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		if op.Action == logic.BulkActionMove {
+			ctx.EditWith(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		} else {
+			ctx.EditWith(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		}
+
+		return
+	}
+
+	var succeeded, skipped, failed atomic.Int32
+	total := len(op.TicketIds)
+
+	editProgress := func(colour customisation.Colour, msg i18n.MessageId) {
+		s, sk, f := succeeded.Load(), skipped.Load(), failed.Load()
+		ctx.EditWith(colour, i18n.TitleBulk, msg, int(s+sk+f), total, s, sk, f)
+	}
+
+	editProgress(customisation.Orange, i18n.MessageBulkProgress)
+
+	finished := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(bulkProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-finished:
+				return
+			case <-ticker.C:
+				editProgress(customisation.Orange, i18n.MessageBulkProgress)
+			}
+		}
+	}()
+
+	var group errgroup.Group
+	group.SetLimit(bulkConcurrency)
+
+	for _, ticketId := range op.TicketIds {
+		group.Go(func() error {
+			ok, err := runBulkTicket(ctx, runner, op, ticketId)
+			if err != nil {
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+				failed.Add(1)
+			} else if ok {
+				succeeded.Add(1)
+			} else {
+				skipped.Add(1)
+			}
+
+			// Errors are counted rather than returned, so that the remaining tickets are still processed
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	// Wait for any in-flight progress edit, so that it cannot overwrite the final result
+	close(finished)
+	<-stopped
+
+	editProgress(customisation.Green, i18n.MessageBulkComplete)
+}
+
+// runBulkTicket returns false if the ticket was skipped
+func runBulkTicket(ctx *context.ButtonContext, runner *logic.BulkRunner, op logic.BulkOperation, ticketId int) (bool, error) {
+	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, op.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	// The ticket may have been closed since the preview was generated
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		return false, nil
+	}
+
+	cc := context.NewAutoCloseContext(ctx, ctx.Worker(), op.GuildId, *ticket.ChannelId, op.UserId, ctx.PremiumTier())
+	return runner.Run(ctx, cc, ticket)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/blacklist"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/permissionwrapper"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/config"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+type ModmailOpenHandler struct{}
+
+func (h *ModmailOpenHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, logic.ModmailSelectCustomIdPrefix)
+		},
+	}
+}
+
+func (h *ModmailOpenHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.DMsAllowed),
+		Timeout: constants.TimeoutOpenTicket,
+	}
+}
+
+func (h *ModmailOpenHandler) Execute(ctx *context.SelectMenuContext) {
+	if len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	messageId, err := strconv.ParseUint(strings.TrimPrefix(ctx.InteractionData.CustomId, logic.ModmailSelectCustomIdPrefix), 10, 64)
+	if err != nil {
+		return
+	}
+
+	guildId, panelId, ok := logic.ParseModmailSelection(ctx.InteractionData.Values[0])
+	if !ok {
+		return
+	}
+
+	// The options may be out of date, so check that the user can still open a ticket in the guild
+	settings, err := dbclient.Local.ModmailSettings.Get(ctx, guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !settings.Enabled || blacklist.IsGuildBlacklisted(guildId) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+		return
+	}
+
+	member, err := ctx.Worker().GetGuildMember(guildId, ctx.UserId())
+	if err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+		return
+	}
+
+	var panel *database.Panel
+	if panelId != 0 {
+		tmp, err := dbclient.Client.Panel.GetById(ctx, panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if tmp.PanelId == 0 || tmp.GuildId != guildId || tmp.FormId != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+			return
+		}
+
+		panel = &tmp
+	}
+
+	// Premium is looked up for the selected guild, rather than the pseudo premium given to DMs
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if premiumTier == premium.None && config.Conf.PremiumOnly {
+		return
+	}
+
+	// Discord does not send the bot's permissions in the guild with DM interactions
+	appPermissions, err := permissionwrapper.GetEffectivePermissions(ctx.Worker(), guildId, ctx.Worker().BotId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Threads are created in the panel's channel
+	var channelId uint64
+	if panel != nil {
+		channelId = panel.ChannelId
+	}
+
+	cc := context.NewModmailContext(ctx, guildId, channelId, member, premiumTier, appPermissions)
+
+	blacklisted, err := cc.IsBlacklisted(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if blacklisted {
+		ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+		return
+	}
+
+	if panel == nil {
+		guildSettings, err := cc.Settings()
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if guildSettings.UseThreads {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+			return
+		}
+	}
+
+	logic.OpenModmailTicket(ctx.Context, cc, panel, ctx.ChannelId(), messageId)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+// PrioritySelectHandler lets the opener choose the priority of their ticket, if the panel it was opened from allows it.
+// Staff can use the select menu too, and once they have set the priority the opener can no longer change it.
+type PrioritySelectHandler struct{}
+
+func (h *PrioritySelectHandler) Matcher() matcher.Matcher {
+	return matcher.NewSimpleMatcher(logic.PrioritySelectCustomId)
+}
+
+func (h *PrioritySelectHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+func (h *PrioritySelectHandler) Execute(ctx *context.SelectMenuContext) {
+	if len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	priority, ok := localdb.ParsePriority(ctx.InteractionData.Values[0])
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePriorityInvalid)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.UserId == 0 || !ticket.Open {
+		ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	permissionLevel, err := ctx.UserPermissionLevel(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		if ticket.UserId != ctx.UserId() || ticket.PanelId == nil {
+			ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePrioritySelectNoPermission)
+			return
+		}
+
+		settings, err := dbclient.Local.PanelPriorities.Get(ctx, *ticket.PanelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !settings.UserSelectable {
+			ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePrioritySelectNoPermission)
+			return
+		}
+
+		// Don't let the opener override a priority chosen by staff
+		setBy, err := dbclient.Local.TicketPriorities.GetSetBy(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if setBy != nil && *setBy != ticket.UserId {
+			ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePrioritySelectLocked)
+			return
+		}
+	}
+
+	renamed, err := logic.SetPriority(ctx, ctx, ticket, priority)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if renamed {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePrioritySet, priority)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePrioritySetRenameRatelimited, priority)
+	}
+}
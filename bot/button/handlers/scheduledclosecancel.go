@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+type ScheduledCloseCancelHandler struct{}
+
+func (h *ScheduledCloseCancelHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: logic.ScheduledCloseCancelCustomId,
+	}
+}
+
+func (h *ScheduledCloseCancelHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 3,
+	}
+}
+
+func (h *ScheduledCloseCancelHandler) Execute(ctx *context.ButtonContext) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	// The opener may cancel, as replying would cancel the close anyway
+	if ctx.UserId() != ticket.UserId && !utils.CanClose(ctx, ctx, ticket) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+		return
+	}
+
+	_, ok, err := dbclient.Local.ScheduledCloses.Delete(ctx, ctx.GuildId(), ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageScheduledCloseNotScheduled)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds: utils.Embeds(utils.BuildEmbed(ctx, customisation.Red, i18n.TitleScheduledClose, i18n.MessageScheduledCloseCancelled, nil, ctx.UserId())),
+	})
+}
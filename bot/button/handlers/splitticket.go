@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	cmdregistry "github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+type SplitTicketHandler struct{}
+
+func (h *SplitTicketHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, logic.SplitTicketCustomIdPrefix)
+	})
+}
+
+func (h *SplitTicketHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: constants.TimeoutOpenTicket,
+	}
+}
+
+func (h *SplitTicketHandler) Execute(ctx *context.SelectMenuContext) {
+	if len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	messageId, openerId, ok := logic.ParseSplitCustomId(ctx.InteractionData.CustomId, logic.SplitTicketCustomIdPrefix)
+	if !ok {
+		return
+	}
+
+	panelId, err := strconv.Atoi(ctx.InteractionData.Values[0])
+	if err != nil {
+		return
+	}
+
+	source, ok := getSplitSource(ctx)
+	if !ok {
+		return
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		return
+	}
+
+	splitTicketFor(ctx, source, &panel, messageId, openerId)
+}
+
+// getSplitSource returns false if the user is not staff, or if the ticket has been closed since the split menus were
+// sent, in which case a response has already been sent
+func getSplitSource(ctx cmdregistry.InteractionContext) (database.Ticket, bool) {
+	permissionLevel, err := ctx.UserPermissionLevel(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return database.Ticket{}, false
+	}
+
+	source, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if source.Id == 0 || source.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return database.Ticket{}, false
+	}
+
+	return source, true
+}
+
+// splitTicketFor splits the ticket, opening the new ticket on behalf of the chosen opener
+func splitTicketFor(ctx cmdregistry.InteractionContext, source database.Ticket, panel *database.Panel, messageId, openerId uint64) {
+	opener, err := ctx.Worker().GetGuildMember(ctx.GuildId(), openerId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := logic.SplitTicket(ctx, context.NewOnBehalfContext(ctx, opener), source, panel, messageId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+}
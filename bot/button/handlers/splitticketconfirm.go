@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/logic"
+)
+
+// SplitTicketConfirmHandler splits the ticket in guilds without any panels to choose from
+type SplitTicketConfirmHandler struct{}
+
+func (h *SplitTicketConfirmHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, logic.SplitConfirmCustomIdPrefix)
+	})
+}
+
+func (h *SplitTicketConfirmHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: constants.TimeoutOpenTicket,
+	}
+}
+
+func (h *SplitTicketConfirmHandler) Execute(ctx *context.ButtonContext) {
+	messageId, openerId, ok := logic.ParseSplitCustomId(ctx.InteractionData.CustomId, logic.SplitConfirmCustomIdPrefix)
+	if !ok {
+		return
+	}
+
+	source, ok := getSplitSource(ctx)
+	if !ok {
+		return
+	}
+
+	splitTicketFor(ctx, source, nil, messageId, openerId)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/logic"
+)
+
+// SplitTicketOpenerHandler updates the split menus with the user chosen to open the new ticket
+type SplitTicketOpenerHandler struct{}
+
+func (h *SplitTicketOpenerHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, logic.SplitOpenerCustomIdPrefix)
+	})
+}
+
+func (h *SplitTicketOpenerHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+func (h *SplitTicketOpenerHandler) Execute(ctx *context.SelectMenuContext) {
+	if len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	messageId, err := strconv.ParseUint(strings.TrimPrefix(ctx.InteractionData.CustomId, logic.SplitOpenerCustomIdPrefix), 10, 64)
+	if err != nil {
+		return
+	}
+
+	openerId, err := strconv.ParseUint(ctx.InteractionData.Values[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	source, ok := getSplitSource(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := logic.BuildSplitResponse(ctx, ctx, source, messageId, openerId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(res)
+}
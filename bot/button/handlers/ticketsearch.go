@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+type TicketSearchHandler struct{}
+
+func (h *TicketSearchHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return ticketSearchPattern.MatchString(customId)
+	})
+}
+
+func (h *TicketSearchHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+		PermissionLevel: permission.Support,
+		Timeout:         time.Second * 5,
+	}
+}
+
+var ticketSearchPattern = regexp.MustCompile(`^` + logic.TicketSearchCustomIdPrefix + `([0-9a-f-]+)_(\d+)$`)
+
+func (h *TicketSearchHandler) Execute(ctx *context.ButtonContext) {
+	groups := ticketSearchPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 3 {
+		return
+	}
+
+	searchId := groups[1]
+	page, err := strconv.Atoi(groups[2])
+	if err != nil {
+		return
+	}
+
+	search, ok, err := logic.GetTicketSearch(ctx, searchId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.EditWith(customisation.Red, i18n.TitleSearch, i18n.MessageSearchExpired)
+		return
+	}
+
+	res, err := logic.BuildTicketSearchMessage(ctx, ctx, searchId, search, page)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(res)
+}
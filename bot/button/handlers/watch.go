@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"time"
+)
+
+// WatchHandler toggles whether the staff member is watching the ticket
+type WatchHandler struct{}
+
+func (h *WatchHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: "watch",
+	}
+}
+
+func (h *WatchHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 3,
+	}
+}
+
+func (h *WatchHandler) Execute(ctx *context.ButtonContext) {
+	permissionLevel, err := ctx.UserPermissionLevel(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageWatchNoPermission)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify this is a ticket channel
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	removed, err := dbclient.Local.TicketWatchers.Remove(ctx, ticket.GuildId, ticket.Id, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if removed {
+		ctx.Reply(customisation.Green, i18n.TitleWatch, i18n.MessageUnwatchSuccess, ticket.Id)
+		return
+	}
+
+	if _, err := dbclient.Local.TicketWatchers.Add(ctx, ticket.GuildId, ticket.Id, ctx.UserId()); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleWatch, i18n.MessageWatchSuccess, ticket.Id)
+}
@@ -14,6 +14,7 @@ import (
 	cmdregistry "github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/TicketsBot/worker/i18n"
@@ -114,7 +115,7 @@ func HandleInteraction(ctx context.Context, manager *ComponentInteractionManager
 
 		shouldExecute, canEdit := doPropertiesChecks(checkCtx, data.GuildId.Value, cc, handler.Properties())
 		if shouldExecute {
-			go func() {
+			shutdown.Go(func() {
 				defer close(responseCh)
 
 				cc := cc.(*cmdcontext.ButtonContext)
@@ -124,7 +125,7 @@ func HandleInteraction(ctx context.Context, manager *ComponentInteractionManager
 				defer cancel()
 
 				handler.Execute(cc)
-			}()
+			})
 		}
 
 		return canEdit
@@ -136,7 +137,7 @@ func HandleInteraction(ctx context.Context, manager *ComponentInteractionManager
 
 		shouldExecute, canEdit := doPropertiesChecks(checkCtx, data.GuildId.Value, cc, handler.Properties())
 		if shouldExecute {
-			go func() {
+			shutdown.Go(func() {
 				defer close(responseCh)
 
 				cc := cc.(*cmdcontext.SelectMenuContext)
@@ -146,7 +147,7 @@ func HandleInteraction(ctx context.Context, manager *ComponentInteractionManager
 				defer cancel()
 
 				handler.Execute(cc)
-			}()
+			})
 		}
 
 		return canEdit
@@ -51,6 +51,8 @@ func (m *ComponentInteractionManager) RegisterCommands() {
 	m.buttonRegistry = append(m.buttonRegistry,
 		new(handlers.AddAdminHandler),
 		new(handlers.AddSupportHandler),
+		new(handlers.BulkCancelHandler),
+		new(handlers.BulkConfirmHandler),
 		new(handlers.CloseHandler),
 		new(handlers.CloseWithReasonModalHandler),
 		new(handlers.ClaimHandler),
@@ -64,14 +66,22 @@ func (m *ComponentInteractionManager) RegisterCommands() {
 		new(handlers.PremiumKeyButtonHandler),
 		new(handlers.RateHandler),
 		new(handlers.RedeemVoteCreditsHandler),
+		new(handlers.ScheduledCloseCancelHandler),
+		new(handlers.SplitTicketConfirmHandler),
+		new(handlers.TicketSearchHandler),
 		new(handlers.ViewStaffHandler),
 		new(handlers.ViewSurveyHandler),
+		new(handlers.WatchHandler),
 	)
 
 	m.selectRegistry = append(m.selectRegistry,
 		new(handlers.LanguageSelectorHandler),
+		new(handlers.ModmailOpenHandler),
 		new(handlers.MultiPanelHandler),
 		new(handlers.PremiumKeyOpenHandler),
+		new(handlers.PrioritySelectHandler),
+		new(handlers.SplitTicketHandler),
+		new(handlers.SplitTicketOpenerHandler),
 	)
 
 	m.modalRegistry = append(m.modalRegistry,
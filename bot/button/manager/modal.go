@@ -8,6 +8,7 @@ import (
 	"github.com/TicketsBot/worker/bot/button"
 	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/config"
 	"github.com/rxdn/gdl/objects/interaction"
 	"time"
@@ -50,10 +51,10 @@ func HandleModalInteraction(ctx context.Context, manager *ComponentInteractionMa
 	cc := cmdcontext.NewModalContext(ctx, worker, data, premiumTier, responseCh)
 	shouldExecute, canEdit := doPropertiesChecks(lookupCtx, data.GuildId.Value, cc, handler.Properties())
 	if shouldExecute {
-		go func() {
+		shutdown.Go(func() {
 			defer cancel()
 			handler.Execute(cc)
-		}()
+		})
 	} else {
 		cancel()
 	}
@@ -0,0 +1,27 @@
+package cache
+
+import "context"
+
+// GetMemberGuilds returns the guilds that the user is known to be a member of. gdl does not index members by user,
+// so the members table is queried directly. Members that have not been cached are not found.
+func GetMemberGuilds(ctx context.Context, userId uint64) ([]uint64, error) {
+	query := `SELECT "guild_id" FROM members WHERE "user_id" = $1;`
+
+	rows, err := Client.Query(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guildIds []uint64
+	for rows.Next() {
+		var guildId uint64
+		if err := rows.Scan(&guildId); err != nil {
+			return nil, err
+		}
+
+		guildIds = append(guildIds, guildId)
+	}
+
+	return guildIds, rows.Err()
+}
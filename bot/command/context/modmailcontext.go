@@ -0,0 +1,143 @@
+package context
+
+import (
+	"context"
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/member"
+	"github.com/rxdn/gdl/objects/user"
+)
+
+// ModmailContext acts in a guild on behalf of a user who interacted with the bot in their DMs. Replies are sent in
+// response to the DM interaction.
+type ModmailContext struct {
+	context.Context
+	*Replyable
+	*StateCache
+	dm                 *SelectMenuContext
+	guildId, channelId uint64
+	member             member.Member
+	premium            premium.PremiumTier
+	appPermissions     uint64
+}
+
+var _ registry.InteractionContext = (*ModmailContext)(nil)
+
+// NewModmailContext takes the ID of the channel that tickets should be opened from, which is only required when the
+// guild uses threads, and the permissions the bot has in the guild, which Discord does not provide for DMs.
+func NewModmailContext(
+	dm *SelectMenuContext,
+	guildId, channelId uint64,
+	member member.Member,
+	premium premium.PremiumTier,
+	appPermissions uint64,
+) *ModmailContext {
+	c := ModmailContext{
+		Context:        dm.Context,
+		dm:             dm,
+		guildId:        guildId,
+		channelId:      channelId,
+		member:         member,
+		premium:        premium,
+		appPermissions: appPermissions,
+	}
+
+	c.Replyable = NewReplyable(&c)
+	c.StateCache = NewStateCache(&c)
+	return &c
+}
+
+func (c *ModmailContext) Worker() *worker.Context {
+	return c.dm.Worker()
+}
+
+func (c *ModmailContext) GuildId() uint64 {
+	return c.guildId
+}
+
+func (c *ModmailContext) ChannelId() uint64 {
+	return c.channelId
+}
+
+func (c *ModmailContext) UserId() uint64 {
+	return c.dm.UserId()
+}
+
+func (c *ModmailContext) UserPermissionLevel(ctx context.Context) (permcache.PermissionLevel, error) {
+	return permcache.GetPermissionLevel(ctx, utils.ToRetriever(c.Worker()), c.member, c.guildId)
+}
+
+func (c *ModmailContext) PremiumTier() premium.PremiumTier {
+	return c.premium
+}
+
+func (c *ModmailContext) IsInteraction() bool {
+	return true
+}
+
+func (c *ModmailContext) Source() registry.Source {
+	return registry.SourceDiscord
+}
+
+func (c *ModmailContext) ToErrorContext() errorcontext.WorkerErrorContext {
+	return errorcontext.WorkerErrorContext{
+		Guild:   c.guildId,
+		User:    c.UserId(),
+		Channel: c.dm.ChannelId(),
+	}
+}
+
+func (c *ModmailContext) ReplyWith(response command.MessageResponse) (message.Message, error) {
+	return c.dm.ReplyWith(response)
+}
+
+func (c *ModmailContext) Channel() (channel.PartialChannel, error) {
+	ch, err := c.Worker().GetChannel(c.channelId)
+	if err != nil {
+		return channel.PartialChannel{}, err
+	}
+
+	return ch.ToPartialChannel(), nil
+}
+
+func (c *ModmailContext) Guild() (guild.Guild, error) {
+	return c.Worker().GetGuild(c.guildId)
+}
+
+func (c *ModmailContext) Member() (member.Member, error) {
+	return c.member, nil
+}
+
+func (c *ModmailContext) User() (user.User, error) {
+	return c.dm.User()
+}
+
+func (c *ModmailContext) IsBlacklisted(ctx context.Context) (bool, error) {
+	permLevel, err := c.UserPermissionLevel(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return utils.IsBlacklisted(ctx, c.guildId, c.UserId(), c.member, permLevel)
+}
+
+/// InteractionContext functions
+
+// InteractionMetadata returns the DM interaction, as if it had been made in the guild
+func (c *ModmailContext) InteractionMetadata() interaction.InteractionMetadata {
+	metadata := c.dm.InteractionMetadata()
+	metadata.GuildId = objects.NewNullableSnowflake(c.guildId)
+	metadata.Member = &c.member
+	metadata.AppPermissions = c.appPermissions
+	return metadata
+}
@@ -0,0 +1,120 @@
+package context
+
+import (
+	"context"
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/member"
+	"github.com/rxdn/gdl/objects/user"
+)
+
+// OnBehalfContext acts on behalf of a member chosen by a staff member, such as when opening a ticket for them. Replies
+// are sent in response to the staff member's interaction.
+type OnBehalfContext struct {
+	context.Context
+	*Replyable
+	*StateCache
+	staff  registry.InteractionContext
+	member member.Member
+}
+
+var _ registry.InteractionContext = (*OnBehalfContext)(nil)
+
+func NewOnBehalfContext(staff registry.InteractionContext, member member.Member) *OnBehalfContext {
+	c := OnBehalfContext{
+		Context: staff,
+		staff:   staff,
+		member:  member,
+	}
+
+	c.Replyable = NewReplyable(&c)
+	c.StateCache = NewStateCache(&c)
+	return &c
+}
+
+func (c *OnBehalfContext) Worker() *worker.Context {
+	return c.staff.Worker()
+}
+
+func (c *OnBehalfContext) GuildId() uint64 {
+	return c.staff.GuildId()
+}
+
+func (c *OnBehalfContext) ChannelId() uint64 {
+	return c.staff.ChannelId()
+}
+
+func (c *OnBehalfContext) UserId() uint64 {
+	return c.member.User.Id
+}
+
+func (c *OnBehalfContext) UserPermissionLevel(ctx context.Context) (permcache.PermissionLevel, error) {
+	return permcache.GetPermissionLevel(ctx, utils.ToRetriever(c.Worker()), c.member, c.GuildId())
+}
+
+func (c *OnBehalfContext) PremiumTier() premium.PremiumTier {
+	return c.staff.PremiumTier()
+}
+
+func (c *OnBehalfContext) IsInteraction() bool {
+	return true
+}
+
+func (c *OnBehalfContext) Source() registry.Source {
+	return c.staff.Source()
+}
+
+func (c *OnBehalfContext) ToErrorContext() errorcontext.WorkerErrorContext {
+	return errorcontext.WorkerErrorContext{
+		Guild:   c.GuildId(),
+		User:    c.UserId(),
+		Channel: c.ChannelId(),
+	}
+}
+
+func (c *OnBehalfContext) ReplyWith(response command.MessageResponse) (message.Message, error) {
+	return c.staff.ReplyWith(response)
+}
+
+func (c *OnBehalfContext) Channel() (channel.PartialChannel, error) {
+	return c.staff.Channel()
+}
+
+func (c *OnBehalfContext) Guild() (guild.Guild, error) {
+	return c.staff.Guild()
+}
+
+func (c *OnBehalfContext) Member() (member.Member, error) {
+	return c.member, nil
+}
+
+func (c *OnBehalfContext) User() (user.User, error) {
+	return c.member.User, nil
+}
+
+func (c *OnBehalfContext) IsBlacklisted(ctx context.Context) (bool, error) {
+	permLevel, err := c.UserPermissionLevel(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return utils.IsBlacklisted(ctx, c.GuildId(), c.UserId(), c.member, permLevel)
+}
+
+/// InteractionContext functions
+
+// InteractionMetadata returns the staff member's interaction, as if it had been made by the member
+func (c *OnBehalfContext) InteractionMetadata() interaction.InteractionMetadata {
+	metadata := c.staff.InteractionMetadata()
+	metadata.Member = &c.member
+	return metadata
+}
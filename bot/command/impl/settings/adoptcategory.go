@@ -0,0 +1,36 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type AdoptCategoryCommand struct {
+}
+
+func (AdoptCategoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "adopt-category",
+		Description:     i18n.HelpAdoptCategory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			AdoptCategoryAddCommand{},
+			AdoptCategoryRemoveCommand{},
+			AdoptCategoryListCommand{},
+		},
+		DefaultEphemeral: true,
+	}
+}
+
+func (c AdoptCategoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AdoptCategoryCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
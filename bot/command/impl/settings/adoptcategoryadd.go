@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+const adoptableCategoryLimit = 25
+
+type AdoptCategoryAddCommand struct {
+}
+
+func (AdoptCategoryAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpAdoptCategoryAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("category", "Category whose channels can be closed as tickets", interaction.OptionTypeChannel, i18n.MessageAdoptCategoryNotCategory),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c AdoptCategoryAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AdoptCategoryAddCommand) Execute(ctx registry.CommandContext, categoryId uint64) {
+	ch, err := ctx.Worker().GetChannel(categoryId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ch.Type != channel.ChannelTypeGuildCategory || ch.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptCategoryNotCategory)
+		return
+	}
+
+	count, err := dbclient.Local.AdoptableCategories.GetCount(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if count >= adoptableCategoryLimit {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptCategoryLimit, adoptableCategoryLimit)
+		return
+	}
+
+	if err := dbclient.Local.AdoptableCategories.Add(ctx, ctx.GuildId(), categoryId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAdoptCategoryAdded, categoryId)
+}
@@ -0,0 +1,54 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"time"
+)
+
+type AdoptCategoryListCommand struct {
+}
+
+func (AdoptCategoryListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "list",
+		Description:      i18n.HelpAdoptCategoryList,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Admin,
+		Category:         command.Settings,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 3,
+	}
+}
+
+func (c AdoptCategoryListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AdoptCategoryListCommand) Execute(ctx registry.CommandContext) {
+	categories, err := dbclient.Local.AdoptableCategories.GetAll(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(categories) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAdoptCategoryListEmpty)
+		return
+	}
+
+	var joined string
+	for _, categoryId := range categories {
+		joined += fmt.Sprintf("• <#%d>\n", categoryId)
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAdoptCategoryList, joined)
+}
@@ -0,0 +1,49 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+type AdoptCategoryRemoveCommand struct {
+}
+
+func (AdoptCategoryRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpAdoptCategoryRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("category", "Category to stop treating as tickets", interaction.OptionTypeChannel, i18n.MessageAdoptCategoryNotAdoptable),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c AdoptCategoryRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AdoptCategoryRemoveCommand) Execute(ctx registry.CommandContext, categoryId uint64) {
+	removed, err := dbclient.Local.AdoptableCategories.Remove(ctx, ctx.GuildId(), categoryId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !removed {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptCategoryNotAdoptable, categoryId)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAdoptCategoryRemoved, categoryId)
+}
@@ -0,0 +1,116 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+// assignStrategyOff disables auto assignment for the panel
+const assignStrategyOff = "off"
+
+type AutoAssignCommand struct {
+}
+
+func (AutoAssignCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "auto-assign",
+		Description:     i18n.HelpAutoAssignSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to configure auto assignment for", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredAutocompleteableArgument("strategy", "How to choose the staff member to assign tickets to", interaction.OptionTypeString, i18n.MessageAutoAssignInvalidStrategy, assignStrategyAutoCompleteHandler),
+			command.NewOptionalArgument("on-call-only", "Whether to only assign tickets to staff who are on call", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c AutoAssignCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AutoAssignCommand) Execute(ctx registry.CommandContext, panelId int, rawStrategy string, onCallOnly *bool) {
+	panel, err := dbclient.Client.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		return
+	}
+
+	if rawStrategy == assignStrategyOff {
+		if err := dbclient.Local.AutoAssign.Delete(ctx, panel.PanelId); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAutoAssignDisabled, panel.Title)
+		return
+	}
+
+	strategy, ok := localdb.ParseAssignStrategy(rawStrategy)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAutoAssignInvalidStrategy)
+		return
+	}
+
+	settings, ok, err := dbclient.Local.AutoAssign.Get(ctx, panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		settings = localdb.AutoAssignSettings{
+			PanelId: panel.PanelId,
+			GuildId: ctx.GuildId(),
+		}
+	}
+
+	settings.Strategy = strategy
+	if onCallOnly != nil {
+		settings.OnCallOnly = *onCallOnly
+	}
+
+	if err := dbclient.Local.AutoAssign.Set(ctx, settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageAutoAssignUpdated, panel.Title, strategy, settings.OnCallOnly)
+}
+
+func assignStrategyAutoCompleteHandler(_ interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	strategies := []string{assignStrategyOff}
+	for _, strategy := range localdb.AssignStrategies {
+		strategies = append(strategies, string(strategy))
+	}
+
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, strategy := range strategies {
+		if strings.Contains(strategy, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  strategy,
+				Value: strategy,
+			})
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,113 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const (
+	defaultBusinessDayStart = "09:00"
+	defaultBusinessDayEnd   = "17:00"
+)
+
+type BusinessHoursCommand struct {
+}
+
+func (BusinessHoursCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "business-hours",
+		Description:     i18n.HelpBusinessHours,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("timezone", "The timezone of your business hours, e.g. Europe/London", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTimezone),
+			command.NewRequiredArgument("days", "The business days, e.g. mon-fri, or none to remove your business hours", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidDays),
+			command.NewOptionalArgument("start", "The start of each business day, in HH:MM format", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTime),
+			command.NewOptionalArgument("end", "The end of each business day, in HH:MM format", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTime),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c BusinessHoursCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursCommand) Execute(ctx registry.CommandContext, timezone, rawDays string, rawStart, rawEnd *string) {
+	if strings.EqualFold(strings.TrimSpace(rawDays), "none") {
+		if err := dbclient.Local.BusinessHours.Delete(ctx, ctx.GuildId()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageBusinessHoursRemoved)
+		return
+	}
+
+	hours := sla.BusinessHours{
+		Timezone: strings.TrimSpace(timezone),
+	}
+
+	if _, err := hours.Location(); err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTimezone)
+		return
+	}
+
+	days, err := sla.ParseDays(rawDays)
+	if err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidDays)
+		return
+	}
+
+	start, end := defaultBusinessDayStart, defaultBusinessDayEnd
+	if rawStart != nil {
+		start = *rawStart
+	}
+
+	if rawEnd != nil {
+		end = *rawEnd
+	}
+
+	window, ok := parseBusinessWindow(start, end)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTime)
+		return
+	}
+
+	for _, day := range days {
+		hours.Days[day] = &window
+	}
+
+	if err := dbclient.Local.BusinessHours.Set(ctx, ctx.GuildId(), hours); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageBusinessHoursUpdated,
+		strings.ToLower(rawDays), sla.FormatClock(window.Start), sla.FormatClock(window.End), hours.Timezone)
+}
+
+func parseBusinessWindow(rawStart, rawEnd string) (sla.Window, bool) {
+	start, err := sla.ParseClock(rawStart)
+	if err != nil {
+		return sla.Window{}, false
+	}
+
+	end, err := sla.ParseClock(rawEnd)
+	if err != nil || end <= start {
+		return sla.Window{}, false
+	}
+
+	return sla.Window{Start: start, End: end}, true
+}
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type HistoryCommand struct {
+}
+
+func (HistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "history",
+		Description:     i18n.HelpHistorySettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("post-on-open", "Whether to post a summary of a returning user's previous tickets when they open a ticket", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c HistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (HistoryCommand) Execute(ctx registry.CommandContext, postOnOpen bool) {
+	settings := localdb.HistorySettings{
+		PostOnOpen: postOnOpen,
+	}
+
+	if err := dbclient.Local.HistorySettings.Set(ctx, ctx.GuildId(), settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if postOnOpen {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageHistorySettingsEnabled)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageHistorySettingsDisabled)
+	}
+}
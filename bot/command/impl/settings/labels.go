@@ -0,0 +1,36 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type LabelsCommand struct {
+}
+
+func (LabelsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "labels",
+		Description:     i18n.HelpLabelsSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			LabelsCreateCommand{},
+			LabelsDeleteCommand{},
+			LabelsListCommand{},
+		},
+		DefaultEphemeral: true,
+	}
+}
+
+func (c LabelsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelsCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
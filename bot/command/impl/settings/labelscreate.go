@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const (
+	labelNameMaxLength  = 32
+	labelEmojiMaxLength = 64
+)
+
+type LabelsCreateCommand struct {
+}
+
+func (LabelsCreateCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "create",
+		Description:     i18n.HelpLabelsCreate,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("name", "The name of the label", interaction.OptionTypeString, i18n.MessageLabelInvalidName),
+			command.NewOptionalArgument("colour", "The colour of the label, as a hex code such as #5865F2", interaction.OptionTypeString, i18n.MessageLabelInvalidColour),
+			command.NewOptionalArgument("emoji", "An emoji to show next to the label", interaction.OptionTypeString, i18n.MessageLabelInvalidEmoji),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c LabelsCreateCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelsCreateCommand) Execute(ctx registry.CommandContext, name string, colour, emoji *string) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > labelNameMaxLength {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelInvalidName, labelNameMaxLength)
+		return
+	}
+
+	label := localdb.Label{
+		GuildId: ctx.GuildId(),
+		Name:    name,
+		Colour:  ctx.GetColour(customisation.Green),
+	}
+
+	if colour != nil {
+		parsed, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(*colour), "#"), 16, 24)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelInvalidColour)
+			return
+		}
+
+		label.Colour = int(parsed)
+	}
+
+	if emoji != nil {
+		trimmed := strings.TrimSpace(*emoji)
+		if len(trimmed) == 0 || len(trimmed) > labelEmojiMaxLength {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelInvalidEmoji)
+			return
+		}
+
+		label.Emoji = &trimmed
+	}
+
+	labels, err := dbclient.Local.Labels.GetByGuild(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(labels) >= logic.GuildLabelLimit {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelLimit, logic.GuildLabelLimit)
+		return
+	}
+
+	_, ok, err := dbclient.Local.Labels.Create(ctx, label)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelExists)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageLabelCreated, logic.FormatLabel(label))
+}
@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type LabelsDeleteCommand struct {
+}
+
+func (LabelsDeleteCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "delete",
+		Description:     i18n.HelpLabelsDelete,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("label", "The label to delete, removing it from all tickets", interaction.OptionTypeInteger, i18n.MessageLabelInvalid, tickets.LabelCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c LabelsDeleteCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelsDeleteCommand) Execute(ctx registry.CommandContext, labelId int) {
+	label, ok, err := dbclient.Local.Labels.Get(ctx, ctx.GuildId(), labelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelInvalid)
+		return
+	}
+
+	if err := dbclient.Local.Labels.Delete(ctx, ctx.GuildId(), label.Id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageLabelDeleted, logic.FormatLabel(label))
+}
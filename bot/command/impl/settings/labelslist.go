@@ -0,0 +1,56 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type LabelsListCommand struct {
+}
+
+func (LabelsListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "list",
+		Description:      i18n.HelpLabelsList,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Admin,
+		Category:         command.Settings,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 3,
+	}
+}
+
+func (c LabelsListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelsListCommand) Execute(ctx registry.CommandContext) {
+	labels, err := dbclient.Local.Labels.GetByGuild(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(labels) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageLabelListEmpty)
+		return
+	}
+
+	var joined string
+	for _, label := range labels {
+		joined += fmt.Sprintf("• %s (`#%06X`)\n", logic.FormatLabel(label), label.Colour)
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageLabelList, joined)
+}
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ModmailCommand struct {
+}
+
+func (ModmailCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "modmail",
+		Description:     i18n.HelpModmailSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("enabled", "Whether members can open tickets by sending the bot a DM", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c ModmailCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ModmailCommand) Execute(ctx registry.CommandContext, enabled bool) {
+	settings := localdb.ModmailSettings{
+		Enabled: enabled,
+	}
+
+	if err := dbclient.Local.ModmailSettings.Set(ctx, ctx.GuildId(), settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if enabled {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageModmailSettingsEnabled)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageModmailSettingsDisabled)
+	}
+}
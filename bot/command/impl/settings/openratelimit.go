@@ -0,0 +1,141 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const maxOpenRateLimitWindow = time.Hour * 24
+
+var openRateLimitScopes = []localdb.RateLimitScope{
+	localdb.RateLimitScopeGuild,
+	localdb.RateLimitScopeUser,
+	localdb.RateLimitScopePanel,
+}
+
+type OpenRateLimitCommand struct {
+}
+
+func (c OpenRateLimitCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "open-ratelimit",
+		Description:     i18n.HelpOpenRateLimit,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("scope", "Whether the limit applies to the whole server, each user or each panel", interaction.OptionTypeString, i18n.MessageOpenRateLimitInvalidScope, c.AutoCompleteHandler),
+			command.NewOptionalArgument("limit", "How many tickets may be opened in the window, 0 to disable. Leave empty to reset", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("window", "The length of the window in seconds", interaction.OptionTypeInteger, i18n.MessageOpenRateLimitInvalidWindow),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c OpenRateLimitCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OpenRateLimitCommand) Execute(ctx registry.CommandContext, rawScope string, limit *int, windowSeconds *int) {
+	scope := localdb.RateLimitScope(rawScope)
+	defaultLimit, ok := defaultOpenRateLimit(scope)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenRateLimitInvalidScope)
+		return
+	}
+
+	// No limit resets the scope to the default
+	if limit == nil {
+		if err := dbclient.Local.OpenRateLimits.Delete(ctx, ctx.GuildId(), scope); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageOpenRateLimitReset, scope)
+		return
+	}
+
+	window := defaultLimit.Window
+	if windowSeconds != nil {
+		window = time.Duration(*windowSeconds) * time.Second
+	} else if window == 0 {
+		window = time.Minute
+	}
+
+	minWindow := time.Second
+	if scope == localdb.RateLimitScopeGuild {
+		minWindow = logic.MinGuildOpenWindow
+	}
+
+	if window < minWindow || window > maxOpenRateLimitWindow {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenRateLimitInvalidWindow, int(minWindow.Seconds()), int(maxOpenRateLimitWindow.Seconds()))
+		return
+	}
+
+	if *limit < 0 || (scope == localdb.RateLimitScopeGuild && *limit == 0) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidArgument)
+		return
+	}
+
+	if scope == localdb.RateLimitScopeGuild {
+		if maxLimit := logic.MaxGuildOpenLimit(ctx.PremiumTier()); *limit > maxLimit {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenRateLimitGuildTooHigh, maxLimit)
+			return
+		}
+	}
+
+	setting := localdb.OpenRateLimit{
+		Limit:  *limit,
+		Window: window,
+	}
+
+	if err := dbclient.Local.OpenRateLimits.Set(ctx, ctx.GuildId(), scope, setting); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if setting.Limit == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageOpenRateLimitDisabled, scope)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageOpenRateLimitUpdated, scope, setting.Limit, int(window.Seconds()))
+	}
+}
+
+func defaultOpenRateLimit(scope localdb.RateLimitScope) (redis.RateLimit, bool) {
+	switch scope {
+	case localdb.RateLimitScopeGuild:
+		return redis.DefaultOpenRateLimits.Guild, true
+	case localdb.RateLimitScopeUser:
+		return redis.DefaultOpenRateLimits.User, true
+	case localdb.RateLimitScopePanel:
+		return redis.DefaultOpenRateLimits.Panel, true
+	default:
+		return redis.RateLimit{}, false
+	}
+}
+
+func (OpenRateLimitCommand) AutoCompleteHandler(_ interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, scope := range openRateLimitScopes {
+		if strings.Contains(string(scope), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(scope),
+				Value: string(scope),
+			})
+		}
+	}
+
+	return choices
+}
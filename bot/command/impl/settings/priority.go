@@ -0,0 +1,80 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const maxEscalationMinutes = 60 * 24
+
+type PriorityCommand struct {
+}
+
+func (PriorityCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "priority",
+		Description:     i18n.HelpPrioritySettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("escalation-role", "The role to ping when an urgent ticket has no staff response", interaction.OptionTypeRole, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("escalation-minutes", "How long an urgent ticket may go without a staff response", interaction.OptionTypeInteger, i18n.MessagePrioritySettingsInvalidMinutes),
+			command.NewOptionalArgument("channel-prefix", "Whether to prefix ticket channel names with their priority", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("disable-escalation", "Stop pinging a role for urgent tickets", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c PriorityCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (PriorityCommand) Execute(ctx registry.CommandContext, roleId *uint64, minutes *int, channelPrefix *bool, disableEscalation *bool) {
+	settings, err := dbclient.Local.PrioritySettings.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if roleId != nil {
+		settings.EscalationRoleId = roleId
+	}
+
+	if disableEscalation != nil && *disableEscalation {
+		settings.EscalationRoleId = nil
+	}
+
+	if minutes != nil {
+		if *minutes < 1 || *minutes > maxEscalationMinutes {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePrioritySettingsInvalidMinutes, maxEscalationMinutes)
+			return
+		}
+
+		settings.EscalationMinutes = *minutes
+	}
+
+	if channelPrefix != nil {
+		settings.ChannelPrefix = *channelPrefix
+	}
+
+	if err := dbclient.Local.PrioritySettings.Set(ctx, ctx.GuildId(), settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if settings.EscalationRoleId == nil {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessagePrioritySettingsEscalationOff, settings.ChannelPrefix)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessagePrioritySettingsUpdated, *settings.EscalationRoleId, settings.EscalationMinutes, settings.ChannelPrefix)
+	}
+}
@@ -0,0 +1,43 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type SettingsCommand struct {
+}
+
+func (SettingsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "settings",
+		Description:     i18n.HelpSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			AdoptCategoryCommand{},
+			TranscriptCommand{},
+			OpenRateLimitCommand{},
+			PriorityCommand{},
+			SlaCommand{},
+			BusinessHoursCommand{},
+			AutoAssignCommand{},
+			HistoryCommand{},
+			LabelsCommand{},
+			ModmailCommand{},
+		},
+		DefaultEphemeral: true,
+	}
+}
+
+func (c SettingsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (SettingsCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
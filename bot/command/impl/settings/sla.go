@@ -0,0 +1,144 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const maxSlaTargetMinutes = 60 * 24 * 30
+
+type SlaCommand struct {
+}
+
+func (SlaCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "sla",
+		Description:     i18n.HelpSlaSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to configure the SLA policy of", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("first-response", "Minutes until the first staff response is due, 0 to disable", interaction.OptionTypeInteger, i18n.MessageSlaSettingsInvalidTarget),
+			command.NewOptionalArgument("next-response", "Minutes until staff must respond to each later message, 0 to disable", interaction.OptionTypeInteger, i18n.MessageSlaSettingsInvalidTarget),
+			command.NewOptionalArgument("resolution", "Minutes until the ticket must be closed, 0 to disable", interaction.OptionTypeInteger, i18n.MessageSlaSettingsInvalidTarget),
+			command.NewOptionalArgument("warning-percent", "How far through a target to post a warning", interaction.OptionTypeInteger, i18n.MessageSlaSettingsInvalidWarning),
+			command.NewOptionalArgument("staff-channel", "The channel to post warnings and breaches in", interaction.OptionTypeChannel, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("business-hours", "Whether the clocks only run during business hours", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c SlaCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (SlaCommand) Execute(ctx registry.CommandContext, panelId int, firstResponse, nextResponse, resolution, warningPercent *int, staffChannelId *uint64, businessHours *bool) {
+	panel, err := dbclient.Client.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		return
+	}
+
+	policy, ok, err := dbclient.Local.SlaPolicies.Get(ctx, panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		policy = localdb.SlaPolicy{
+			PanelId:        panel.PanelId,
+			GuildId:        ctx.GuildId(),
+			WarningPercent: sla.DefaultWarningPercent,
+		}
+	}
+
+	for _, target := range []struct {
+		value *int
+		dest  **time.Duration
+	}{
+		{firstResponse, &policy.FirstResponse},
+		{nextResponse, &policy.NextResponse},
+		{resolution, &policy.Resolution},
+	} {
+		if target.value == nil {
+			continue
+		}
+
+		if *target.value < 0 || *target.value > maxSlaTargetMinutes {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSlaSettingsInvalidTarget, maxSlaTargetMinutes)
+			return
+		}
+
+		if *target.value == 0 {
+			*target.dest = nil
+		} else {
+			d := time.Duration(*target.value) * time.Minute
+			*target.dest = &d
+		}
+	}
+
+	if warningPercent != nil {
+		if *warningPercent < 1 || *warningPercent > 99 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSlaSettingsInvalidWarning)
+			return
+		}
+
+		policy.WarningPercent = *warningPercent
+	}
+
+	if staffChannelId != nil {
+		policy.StaffChannelId = staffChannelId
+	}
+
+	if businessHours != nil {
+		policy.BusinessHours = *businessHours
+	}
+
+	// A policy without any targets does not track anything
+	if policy.FirstResponse == nil && policy.NextResponse == nil && policy.Resolution == nil {
+		if err := dbclient.Local.SlaPolicies.Delete(ctx, panel.PanelId); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageSlaSettingsRemoved, panel.Title)
+		return
+	}
+
+	if err := dbclient.Local.SlaPolicies.Set(ctx, policy); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageSlaSettingsUpdated, panel.Title,
+		formatSlaTarget(policy.FirstResponse), formatSlaTarget(policy.NextResponse), formatSlaTarget(policy.Resolution),
+		policy.WarningPercent, policy.BusinessHours)
+}
+
+func formatSlaTarget(target *time.Duration) string {
+	if target == nil {
+		return "-"
+	}
+
+	return utils.FormatDuration(*target)
+}
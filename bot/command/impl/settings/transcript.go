@@ -0,0 +1,87 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/transcript"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TranscriptCommand struct {
+}
+
+func (c TranscriptCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "transcript",
+		Description:     i18n.HelpTranscriptSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("format", "The format transcripts are rendered in", interaction.OptionTypeString, i18n.MessageTranscriptSettingsInvalidFormat, c.AutoCompleteHandler),
+			command.NewOptionalArgument("attach", "Whether to attach the transcript to the close message", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c TranscriptCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TranscriptCommand) Execute(ctx registry.CommandContext, format string, attach *bool) {
+	exporter, ok := transcript.GetExporter(transcript.Format(format))
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTranscriptSettingsInvalidFormat)
+		return
+	}
+
+	current, err := dbclient.Local.TranscriptSettings.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	settings := localdb.TranscriptSettings{
+		Format:        string(exporter.Format()),
+		AttachToClose: current.AttachToClose,
+	}
+
+	if attach != nil {
+		settings.AttachToClose = *attach
+	}
+
+	if err := dbclient.Local.TranscriptSettings.Set(ctx, ctx.GuildId(), settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if settings.AttachToClose {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageTranscriptSettingsUpdatedAttached, settings.Format)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleSettings, i18n.MessageTranscriptSettingsUpdated, settings.Format)
+	}
+}
+
+func (TranscriptCommand) AutoCompleteHandler(_ interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, format := range transcript.Formats() {
+		if strings.Contains(string(format), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(format),
+				Value: string(format),
+			})
+		}
+	}
+
+	return choices
+}
@@ -5,9 +5,12 @@ import (
 	"github.com/TicketsBot/analytics-client"
 	"github.com/TicketsBot/common/permission"
 	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/getsentry/sentry-go"
@@ -25,11 +28,14 @@ type StatsServerCommand struct {
 
 func (StatsServerCommand) Properties() registry.Properties {
 	return registry.Properties{
-		Name:             "server",
-		Description:      i18n.HelpStatsServer,
-		Type:             interaction.ApplicationCommandTypeChatInput,
-		PermissionLevel:  permission.Support,
-		Category:         command.Statistics,
+		Name:            "server",
+		Description:     i18n.HelpStatsServer,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Statistics,
+		Arguments: command.Arguments(
+			command.NewOptionalAutocompleteableArgument("label", "Only include tickets with this label", interaction.OptionTypeInteger, i18n.MessageLabelInvalid, tickets.LabelCommand{}.AutoCompleteHandler),
+		),
 		PremiumOnly:      true,
 		DefaultEphemeral: true,
 		Timeout:          time.Second * 10,
@@ -40,11 +46,17 @@ func (c StatsServerCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (StatsServerCommand) Execute(ctx registry.CommandContext) {
+func (StatsServerCommand) Execute(ctx registry.CommandContext, labelId *int) {
 	span := sentry.StartTransaction(ctx, "/stats server")
 	span.SetTag("guild", strconv.FormatUint(ctx.GuildId(), 10))
 	defer span.Finish()
 
+	// The analytics service does not know about labels, so label statistics are calculated separately
+	if labelId != nil {
+		replyWithLabelStats(ctx, *labelId)
+		return
+	}
+
 	group, _ := errgroup.WithContext(ctx)
 
 	var totalTickets, openTickets uint64
@@ -111,6 +123,26 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		return
 	})
 
+	// SLA breach rates
+	var weeklySla, monthlySla, totalSla localdb.SlaBreachStats
+	for _, window := range []struct {
+		stats *localdb.SlaBreachStats
+		since time.Time
+	}{
+		{&weeklySla, time.Now().Add(-time.Hour * 24 * 7)},
+		{&monthlySla, time.Now().Add(-time.Hour * 24 * 28)},
+		{&totalSla, time.Time{}},
+	} {
+		window := window
+		group.Go(func() (err error) {
+			span := sentry.StartSpan(span.Context(), "GetSlaBreachStats")
+			defer span.Finish()
+
+			*window.stats, err = dbclient.Local.TicketSla.GetBreachStats(ctx, ctx.GuildId(), nil, window.since)
+			return
+		})
+	}
+
 	// tickets per day
 	var ticketVolumeTable string
 	group.Go(func() error {
@@ -157,12 +189,60 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		AddField("Average Ticket Duration (Total)", formatNullableTime(ticketDuration.AllTime), true).
 		AddField("Average Ticket Duration (Monthly)", formatNullableTime(ticketDuration.Monthly), true).
 		AddField("Average Ticket Duration (Weekly)", formatNullableTime(ticketDuration.Weekly), true).
+		AddField("SLA Breach Rate (Total)", formatBreachRate(totalSla), true).
+		AddField("SLA Breach Rate (Monthly)", formatBreachRate(monthlySla), true).
+		AddField("SLA Breach Rate (Weekly)", formatBreachRate(weeklySla), true).
 		AddField("Ticket Volume", fmt.Sprintf("```\n%s\n```", ticketVolumeTable), false)
 
 	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
 	span.Finish()
 }
 
+func replyWithLabelStats(ctx registry.CommandContext, labelId int) {
+	label, ok, err := dbclient.Local.Labels.Get(ctx, ctx.GuildId(), labelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelInvalid)
+		return
+	}
+
+	stats, err := dbclient.Local.TicketLabels.GetStats(ctx, ctx.GuildId(), label.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	feedbackRating := "No data"
+	if stats.AverageRating != nil {
+		feedbackRating = fmt.Sprintf("%.1f / 5 ⭐", *stats.AverageRating)
+	}
+
+	msgEmbed := embed.NewEmbed().
+		SetTitle(fmt.Sprintf("Statistics: %s", logic.FormatLabel(label))).
+		SetColor(label.Colour).
+		AddField("Total Tickets", strconv.Itoa(stats.Total), true).
+		AddField("Open Tickets", strconv.Itoa(stats.Open), true).
+		AddBlankField(true).
+		AddField("Feedback Rating", feedbackRating, true).
+		AddField("Feedback Count", strconv.Itoa(stats.Ratings), true).
+		AddBlankField(true).
+		AddField("Average Ticket Duration (Total)", formatNullableTime(stats.AverageDuration), true)
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
+}
+
 func formatNullableTime(duration *time.Duration) string {
 	return utils.FormatNullableTime(duration)
 }
+
+func formatBreachRate(stats localdb.SlaBreachStats) string {
+	if stats.Tickets == 0 {
+		return "No data"
+	}
+
+	return fmt.Sprintf("%.1f%% (%d / %d)", float64(stats.Breached)/float64(stats.Tickets)*100, stats.Breached, stats.Tickets)
+}
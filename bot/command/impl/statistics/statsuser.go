@@ -7,6 +7,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/getsentry/sentry-go"
@@ -260,6 +261,26 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			return
 		})
 
+		// SLA breach rates of claimed tickets
+		var weeklySla, monthlySla, totalSla localdb.SlaBreachStats
+		for _, window := range []struct {
+			stats *localdb.SlaBreachStats
+			since time.Time
+		}{
+			{&weeklySla, time.Now().Add(-time.Hour * 24 * 7)},
+			{&monthlySla, time.Now().Add(-time.Hour * 24 * 28)},
+			{&totalSla, time.Time{}},
+		} {
+			window := window
+			group.Go(func() (err error) {
+				span := sentry.StartSpan(span.Context(), "GetSlaBreachStats")
+				defer span.Finish()
+
+				*window.stats, err = dbclient.Local.TicketSla.GetBreachStats(ctx, ctx.GuildId(), &userId, window.since)
+				return
+			})
+		}
+
 		if err := group.Wait(); err != nil {
 			ctx.HandleError(err)
 			return
@@ -289,7 +310,10 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			AddField("Tickets Answered (Total)", fmt.Sprintf("%d / %d", totalAnsweredTickets, totalTotalTickets), true).
 			AddField("Claimed Tickets (Weekly)", strconv.Itoa(weeklyClaimedTickets), true).
 			AddField("Claimed Tickets (Monthly)", strconv.Itoa(monthlyClaimedTickets), true).
-			AddField("Claimed Tickets (Total)", strconv.Itoa(totalClaimedTickets), true)
+			AddField("Claimed Tickets (Total)", strconv.Itoa(totalClaimedTickets), true).
+			AddField("SLA Breach Rate (Weekly)", formatBreachRate(weeklySla), true).
+			AddField("SLA Breach Rate (Monthly)", formatBreachRate(monthlySla), true).
+			AddField("SLA Breach Rate (Total)", formatBreachRate(totalSla), true)
 
 		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
 		span.Finish()
@@ -1,6 +1,8 @@
 package tickets
 
 import (
+	"time"
+
 	permcache "github.com/TicketsBot/common/permission"
 	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/registry"
@@ -8,6 +10,7 @@ import (
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/interaction"
 	"github.com/rxdn/gdl/rest/request"
@@ -25,6 +28,7 @@ func (AddCommand) Properties() registry.Properties {
 		Category:        command.Tickets,
 		Arguments: command.Arguments(
 			command.NewRequiredArgument("user", "User to add to the ticket", interaction.OptionTypeUser, i18n.MessageAddNoMembers),
+			command.NewOptionalArgument("duration", "Remove the user again after this long, e.g. 2h or 3d", interaction.OptionTypeString, i18n.MessageAddInvalidDuration),
 		),
 		Timeout: constants.TimeoutOpenTicket,
 	}
@@ -34,7 +38,17 @@ func (c AddCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (AddCommand) Execute(ctx registry.CommandContext, userId uint64) {
+func (AddCommand) Execute(ctx registry.CommandContext, userId uint64, durationRaw *string) {
+	var duration time.Duration
+	if durationRaw != nil {
+		var err error
+		duration, err = utils.ParseDuration(*durationRaw)
+		if err != nil || duration < logic.MinTemporaryAccessDuration || duration > logic.MaxTemporaryAccessDuration {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAddInvalidDuration)
+			return
+		}
+	}
+
 	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
@@ -59,43 +73,38 @@ func (AddCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
-	// Add user to ticket in DB
-	if err := dbclient.Client.TicketMembers.Add(ctx, ctx.GuildId(), ticket.Id, userId); err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	if ticket.IsThread {
-		if err := ctx.Worker().AddThreadMember(*ticket.ChannelId, userId); err != nil {
-			if err, ok := err.(request.RestError); ok && err.ApiError.Message == "Missing Access" {
-				ch, err := ctx.Channel()
-				if err != nil {
-					ctx.HandleError(err)
-					return
-				}
-
-				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenCantSeeParentChannel, userId, ch.ParentId.Value)
-			} else {
+	if err := logic.AddTicketMember(ctx, ctx.Worker(), ticket, userId); err != nil {
+		if err, ok := err.(request.RestError); ok && ticket.IsThread && err.ApiError.Message == "Missing Access" {
+			ch, err := ctx.Channel()
+			if err != nil {
 				ctx.HandleError(err)
+				return
 			}
 
-			return
-		}
-	} else {
-		// Build permissions
-		additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ctx.GuildId())
-		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenCantSeeParentChannel, userId, ch.ParentId.Value)
+		} else {
 			ctx.HandleError(err)
-			return
 		}
 
-		// ticket.ChannelId cannot be nil, as we get by channel id
-		data := logic.BuildUserOverwrite(userId, additionalPermissions)
-		if err := ctx.Worker().EditChannelPermissions(*ticket.ChannelId, data); err != nil {
+		return
+	}
+
+	// Adding a user without a duration makes any temporary access they had permanent
+	if durationRaw == nil {
+		if err := dbclient.Local.TemporaryAccess.Delete(ctx, ctx.GuildId(), ticket.Id, userId); err != nil {
 			ctx.HandleError(err)
 			return
 		}
+
+		ctx.ReplyPermanent(customisation.Green, i18n.TitleAdd, i18n.MessageAddSuccess, userId, *ticket.ChannelId)
+		return
+	}
+
+	expiresAt, err := logic.GrantTemporaryAccess(ctx, ctx, ticket, userId, duration)
+	if err != nil {
+		ctx.HandleError(err)
+		return
 	}
 
-	ctx.ReplyPermanent(customisation.Green, i18n.TitleAdd, i18n.MessageAddSuccess, userId, *ticket.ChannelId)
+	ctx.ReplyPermanent(customisation.Green, i18n.TitleAdd, i18n.MessageAddSuccessTemporary, userId, *ticket.ChannelId, expiresAt.Unix())
 }
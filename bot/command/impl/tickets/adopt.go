@@ -0,0 +1,99 @@
+package tickets
+
+import (
+	"errors"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/permission"
+)
+
+type AdoptCommand struct {
+}
+
+func (AdoptCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "adopt",
+		Description:     i18n.HelpAdopt,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("user", "The user who opened the ticket", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("panel", "Ticket panel the ticket belongs to", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("channel", "The channel or thread to adopt, defaults to the current channel", interaction.OptionTypeChannel, i18n.MessageInvalidArgument),
+		),
+		Timeout: constants.TimeoutOpenTicket,
+	}
+}
+
+func (c AdoptCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AdoptCommand) Execute(ctx *cmdcontext.SlashCommandContext, openerId uint64, panelId *int, channelId *uint64) {
+	targetId := ctx.ChannelId()
+	if channelId != nil {
+		targetId = *channelId
+	}
+
+	ch, err := ctx.Worker().GetChannel(targetId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ch.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptInvalidChannel)
+		return
+	}
+
+	opener, err := ctx.Worker().GetGuildMember(ctx.GuildId(), openerId)
+	if err != nil || opener.User.Bot {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidUser)
+		return
+	}
+
+	var panel *database.Panel
+	if panelId != nil {
+		p, err := dbclient.Client.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		// Ensure the panel belongs to the same guild
+		if p.PanelId == 0 || p.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+			return
+		}
+
+		panel = &p
+	}
+
+	canManageWebhooks := permission.HasPermissionRaw(ctx.InteractionMetadata().AppPermissions, permission.ManageWebhooks)
+	ticket, err := logic.AdoptTicket(ctx, ctx, ch, openerId, panel, canManageWebhooks)
+	if err != nil {
+		if errors.Is(err, logic.ErrAlreadyTicket) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptAlreadyTicket)
+		} else if errors.Is(err, logic.ErrChannelNotAdoptable) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAdoptInvalidChannel)
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleAdopted, i18n.MessageAdoptSuccess, ch.Id, ticket.Id, openerId)
+}
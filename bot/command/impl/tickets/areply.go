@@ -0,0 +1,38 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type AReplyCommand struct {
+}
+
+func (AReplyCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "areply",
+		Description:     i18n.HelpAReply,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("message", "Message to send to the ticket opener's DMs, without your name", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("attachment", "File to send with the message", interaction.OptionTypeAttachment, i18n.MessageInvalidArgument),
+		),
+		Timeout: time.Second * 30,
+	}
+}
+
+func (c AReplyCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AReplyCommand) Execute(ctx registry.CommandContext, content string, attachment *channel.Attachment) {
+	replyToModmail(ctx, content, attachment, true)
+}
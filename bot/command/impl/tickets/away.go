@@ -0,0 +1,35 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type AwayCommand struct {
+}
+
+func (AwayCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "away",
+		Description:     i18n.HelpAway,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Children: []registry.Command{
+			AwaySetCommand{},
+			AwayClearCommand{},
+		},
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c AwayCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AwayCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
@@ -0,0 +1,47 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type AwayClearCommand struct {
+}
+
+func (AwayClearCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "clear",
+		Description:      i18n.HelpAwayClear,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 3,
+	}
+}
+
+func (c AwayClearCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AwayClearCommand) Execute(ctx registry.CommandContext) {
+	ok, err := dbclient.Local.StaffAway.Delete(ctx, ctx.GuildId(), ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAwayNotAway)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleAway, i18n.MessageAwayCleared)
+}
@@ -0,0 +1,99 @@
+package tickets
+
+import (
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const (
+	maxAwayDuration = time.Hour * 24 * 365
+	maxAwayNote     = 255
+)
+
+type AwaySetCommand struct {
+}
+
+func (AwaySetCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "set",
+		Description:     i18n.HelpAwaySet,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("until", "The date you return, in YYYY-MM-DD format (UTC), or how long you are away for, e.g. 2w", interaction.OptionTypeString, i18n.MessageAwayInvalidUntil),
+			command.NewOptionalArgument("note", "A note shown to users who mention you, e.g. who to contact instead", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c AwaySetCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AwaySetCommand) Execute(ctx registry.CommandContext, rawUntil string, note *string) {
+	until, ok := parseAwayUntil(rawUntil)
+	if !ok || !until.After(time.Now()) || until.After(time.Now().Add(maxAwayDuration)) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAwayInvalidUntil)
+		return
+	}
+
+	if note != nil {
+		note = utils.Ptr(utils.StringMax(strings.TrimSpace(*note), maxAwayNote))
+	}
+
+	status := localdb.AwayStatus{
+		GuildId: ctx.GuildId(),
+		UserId:  ctx.UserId(),
+		Until:   until,
+		Note:    note,
+	}
+
+	if err := dbclient.Local.StaffAway.Set(ctx, status); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Staff who are away should not be pinged through the on call roles
+	if err := dbclient.Client.OnCall.Remove(ctx, ctx.GuildId(), ctx.UserId()); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := logic.RemoveOnCallRoles(ctx, ctx, ctx.UserId()); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleAway, i18n.MessageAwaySet, until.Unix())
+}
+
+// parseAwayUntil accepts either a date, in which case the staff member is away until the end of that day, or a
+// duration from now
+func parseAwayUntil(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+
+	if date, err := time.Parse(time.DateOnly, raw); err == nil {
+		return date.AddDate(0, 0, 1), true
+	}
+
+	duration, err := utils.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(duration), true
+}
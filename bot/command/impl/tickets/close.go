@@ -7,6 +7,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
@@ -27,6 +28,7 @@ func (c CloseCommand) Properties() registry.Properties {
 		Category:        command.Tickets,
 		Arguments: command.Arguments(
 			command.NewOptionalAutocompleteableArgument("reason", "The reason the ticket was closed", interaction.OptionTypeString, "infallible", c.AutoCompleteHandler), // should never fail
+			command.NewOptionalArgument("in", "Close the ticket after a delay instead, e.g. 30m or 2h", interaction.OptionTypeString, i18n.MessageScheduledCloseInvalidDelay),
 		),
 		Timeout: constants.TimeoutCloseTicket,
 	}
@@ -36,8 +38,48 @@ func (c CloseCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (CloseCommand) Execute(ctx registry.CommandContext, reason *string) {
-	logic.CloseTicket(ctx, ctx, reason, false)
+func (CloseCommand) Execute(ctx registry.CommandContext, reason *string, delay *string) {
+	if delay == nil {
+		logic.CloseTicket(ctx, ctx, reason, false)
+		return
+	}
+
+	duration, err := utils.ParseDuration(*delay)
+	if err != nil || duration < logic.MinScheduledCloseDelay || duration > logic.MaxScheduledCloseDelay {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageScheduledCloseInvalidDelay)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	if !utils.CanClose(ctx, ctx, ticket) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+		return
+	}
+
+	if reason != nil && len(*reason) > 255 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonTooLong)
+		return
+	}
+
+	closeAt, err := logic.ScheduleClose(ctx, ctx, ticket, reason, duration)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(
+		utils.BuildEmbed(ctx, customisation.Green, i18n.TitleScheduledClose, i18n.MessageScheduledCloseScheduled, nil, closeAt.Unix()),
+	))
 }
 
 func (CloseCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
@@ -0,0 +1,55 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type HistoryCommand struct {
+}
+
+func (HistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "history",
+		Description:     i18n.HelpHistory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("user", "The user to view the ticket history of", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c HistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (HistoryCommand) Execute(ctx registry.CommandContext, userId uint64) {
+	replyWithHistory(ctx, userId)
+}
+
+func replyWithHistory(ctx registry.CommandContext, userId uint64) {
+	e, err := logic.BuildUserHistoryEmbed(ctx, ctx, userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if _, err := ctx.ReplyWith(command.MessageResponse{
+		Embeds: utils.Slice(e),
+		Flags:  message.SumFlags(message.FlagEphemeral),
+	}); err != nil {
+		ctx.HandleError(err)
+	}
+}
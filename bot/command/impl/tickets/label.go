@@ -0,0 +1,85 @@
+package tickets
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type LabelCommand struct {
+}
+
+func (LabelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "label",
+		Description:     i18n.HelpLabel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Children: []registry.Command{
+			LabelAddCommand{},
+			LabelRemoveCommand{},
+		},
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c LabelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
+
+// AutoCompleteHandler suggests the guild's labels, by ID
+func (LabelCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	labels, err := dbclient.Local.Labels.GetByGuild(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, label := range labels {
+		if !strings.Contains(strings.ToLower(label.Name), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  labelChoiceName(label),
+			Value: label.Id,
+		})
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
+
+// Choice names cannot contain custom emojis, so only unicode emojis are shown
+func labelChoiceName(label localdb.Label) string {
+	if label.Emoji == nil || strings.HasPrefix(*label.Emoji, "<") {
+		return label.Name
+	}
+
+	return *label.Emoji + " " + label.Name
+}
@@ -0,0 +1,78 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type LabelRemoveCommand struct {
+}
+
+func (LabelRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpLabelRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("label", "The label to remove from the ticket", interaction.OptionTypeInteger, i18n.MessageLabelInvalid, LabelCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c LabelRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (LabelRemoveCommand) Execute(ctx registry.CommandContext, labelId int) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Check this is a ticket channel
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.TitleLabel, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	label, ok, err := dbclient.Local.Labels.Get(ctx, ctx.GuildId(), labelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.TitleLabel, i18n.MessageLabelInvalid)
+		return
+	}
+
+	removed, err := dbclient.Local.TicketLabels.Remove(ctx, ctx.GuildId(), ticket.Id, label.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if removed {
+		if err := logic.PublishTicketLabels(ctx, ctx.GuildId(), ticket.Id); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleLabel, i18n.MessageLabelRemoved, logic.FormatLabel(label))
+	} else {
+		ctx.Reply(customisation.Red, i18n.TitleLabel, i18n.MessageLabelNotAdded, logic.FormatLabel(label))
+	}
+}
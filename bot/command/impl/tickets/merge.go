@@ -0,0 +1,132 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type MergeCommand struct {
+}
+
+func (c MergeCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "merge",
+		Description:     i18n.HelpMerge,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Everyone,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("target", "Ticket to merge this ticket into", interaction.OptionTypeInteger, i18n.MessageMergeTargetInvalid, c.AutoCompleteHandler),
+		),
+		Timeout: constants.TimeoutOpenTicket,
+	}
+}
+
+func (c MergeCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (MergeCommand) Execute(ctx registry.CommandContext, targetId int) {
+	source, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify this is a ticket channel
+	if source.Id == 0 || source.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	if source.Id == targetId {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMergeSameTicket)
+		return
+	}
+
+	target, err := dbclient.Client.Tickets.Get(ctx, targetId, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if target.Id == 0 || !target.Open || target.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMergeTargetInvalid, targetId)
+		return
+	}
+
+	// The user must be allowed to manage both tickets, as the members of the source ticket gain access to the target
+	for _, ticket := range []database.Ticket{source, target} {
+		hasPermission, err := logic.HasPermissionForTicket(ctx, ctx.Worker(), ticket, ctx.UserId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !hasPermission {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMergeNoPermission, ticket.Id)
+			return
+		}
+	}
+
+	if err := logic.MergeTickets(ctx, ctx, source, target); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+}
+
+// AutoCompleteHandler suggests the other open tickets in the guild, newest first
+func (MergeCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	tickets, err := dbclient.Client.Tickets.GetGuildOpenTickets(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	value = strings.TrimPrefix(value, "#")
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, 25)
+	for _, ticket := range tickets {
+		if len(choices) == 25 {
+			break
+		}
+
+		if ticket.ChannelId == nil || *ticket.ChannelId == data.ChannelId {
+			continue
+		}
+
+		if !strings.HasPrefix(strconv.Itoa(ticket.Id), value) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("#%d", ticket.Id),
+			Value: ticket.Id,
+		})
+	}
+
+	return choices
+}
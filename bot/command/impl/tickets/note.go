@@ -0,0 +1,169 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type NoteCommand struct {
+}
+
+func (NoteCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "note",
+		Description:     i18n.HelpNote,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Children: []registry.Command{
+			NoteAddCommand{},
+			NoteListCommand{},
+			NoteEditCommand{},
+			NoteDeleteCommand{},
+		},
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c NoteCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NoteCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
+
+// AutoCompleteHandler suggests the notes on the ticket that the command is being run in. Autocomplete requests are not
+// subject to the command's permission level, so it is checked here to avoid leaking notes to the ticket opener.
+func (NoteCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 || data.Member == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	// Only the cache is needed to look up the guild owner. If the guild is not cached, the lookup fails and no notes
+	// are suggested.
+	retriever := utils.ToRetriever(&worker.Context{Cache: cache.Client})
+	permLevel, err := permcache.GetPermissionLevel(ctx, retriever, *data.Member, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	if permLevel < permcache.Support {
+		return nil
+	}
+
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, data.ChannelId, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	if ticket.Id == 0 {
+		return nil
+	}
+
+	notes, err := dbclient.Local.TicketNotes.GetByTicket(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, note := range notes {
+		if value != "" && !strings.Contains(strings.ToLower(note.Content), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  utils.StringMax(fmt.Sprintf("#%d: %s", note.Id, note.Content), 97, "..."),
+			Value: note.Id,
+		})
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
+
+// getNoteTicket returns false if the command was not run in a ticket that the user can access, in which case a
+// response has already been sent
+func getNoteTicket(ctx registry.CommandContext) (database.Ticket, bool) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if ticket.Id == 0 || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return database.Ticket{}, false
+	}
+
+	// HasPermissionForTicket returns true if the user opened the ticket, but the command's properties enforces
+	// requiring the user to be a staff member
+	hasPermission, err := logic.HasPermissionForTicket(ctx, ctx.Worker(), ticket, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if !hasPermission {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return database.Ticket{}, false
+	}
+
+	return ticket, true
+}
+
+// getModifiableNote returns false if the note does not belong to the ticket, or if the user is neither its author nor
+// an admin, in which case a response has already been sent
+func getModifiableNote(ctx registry.CommandContext, ticket database.Ticket, noteId int) (localdb.TicketNote, bool) {
+	note, ok, err := dbclient.Local.TicketNotes.Get(ctx, ctx.GuildId(), noteId)
+	if err != nil {
+		ctx.HandleError(err)
+		return localdb.TicketNote{}, false
+	}
+
+	if !ok || note.TicketId != ticket.Id {
+		ctx.Reply(customisation.Red, i18n.TitleNotes, i18n.MessageNoteInvalid)
+		return localdb.TicketNote{}, false
+	}
+
+	if note.AuthorId != ctx.UserId() {
+		permissionLevel, err := ctx.UserPermissionLevel(ctx)
+		if err != nil {
+			ctx.HandleError(err)
+			return localdb.TicketNote{}, false
+		}
+
+		if permissionLevel < permcache.Admin {
+			ctx.Reply(customisation.Red, i18n.TitleNotes, i18n.MessageNoteNotAuthor)
+			return localdb.TicketNote{}, false
+		}
+	}
+
+	return note, true
+}
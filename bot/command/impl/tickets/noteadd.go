@@ -0,0 +1,72 @@
+package tickets
+
+import (
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+// noteLimit keeps /note list within a single embed
+const noteLimit = 25
+
+type NoteAddCommand struct {
+}
+
+func (NoteAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpNoteAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("content", "The note, which is only visible to staff", interaction.OptionTypeString, i18n.MessageNoteInvalidContent),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c NoteAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NoteAddCommand) Execute(ctx registry.CommandContext, content string) {
+	content = strings.TrimSpace(content)
+	if len(content) == 0 || len(content) > logic.MaxNoteLength {
+		ctx.Reply(customisation.Red, i18n.TitleNotes, i18n.MessageNoteInvalidContent, logic.MaxNoteLength)
+		return
+	}
+
+	ticket, ok := getNoteTicket(ctx)
+	if !ok {
+		return
+	}
+
+	count, err := dbclient.Local.TicketNotes.GetCount(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if count >= noteLimit {
+		ctx.Reply(customisation.Red, i18n.TitleNotes, i18n.MessageNoteLimit, noteLimit)
+		return
+	}
+
+	id, err := dbclient.Local.TicketNotes.Create(ctx, ticket.GuildId, ticket.Id, ctx.UserId(), content)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleNotes, i18n.MessageNoteAdded, id)
+}
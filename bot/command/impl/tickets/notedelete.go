@@ -0,0 +1,54 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type NoteDeleteCommand struct {
+}
+
+func (NoteDeleteCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "delete",
+		Description:     i18n.HelpNoteDelete,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "The note to delete", interaction.OptionTypeInteger, i18n.MessageNoteInvalid, NoteCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c NoteDeleteCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NoteDeleteCommand) Execute(ctx registry.CommandContext, noteId int) {
+	ticket, ok := getNoteTicket(ctx)
+	if !ok {
+		return
+	}
+
+	note, ok := getModifiableNote(ctx, ticket, noteId)
+	if !ok {
+		return
+	}
+
+	if err := dbclient.Local.TicketNotes.Delete(ctx, ctx.GuildId(), note.Id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleNotes, i18n.MessageNoteDeleted, note.Id)
+}
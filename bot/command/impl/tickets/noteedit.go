@@ -0,0 +1,63 @@
+package tickets
+
+import (
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type NoteEditCommand struct {
+}
+
+func (NoteEditCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "edit",
+		Description:     i18n.HelpNoteEdit,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "The note to edit", interaction.OptionTypeInteger, i18n.MessageNoteInvalid, NoteCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("content", "The new content of the note", interaction.OptionTypeString, i18n.MessageNoteInvalidContent),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c NoteEditCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NoteEditCommand) Execute(ctx registry.CommandContext, noteId int, content string) {
+	content = strings.TrimSpace(content)
+	if len(content) == 0 || len(content) > logic.MaxNoteLength {
+		ctx.Reply(customisation.Red, i18n.TitleNotes, i18n.MessageNoteInvalidContent, logic.MaxNoteLength)
+		return
+	}
+
+	ticket, ok := getNoteTicket(ctx)
+	if !ok {
+		return
+	}
+
+	note, ok := getModifiableNote(ctx, ticket, noteId)
+	if !ok {
+		return
+	}
+
+	if err := dbclient.Local.TicketNotes.Edit(ctx, ctx.GuildId(), note.Id, ctx.UserId(), content); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleNotes, i18n.MessageNoteEdited, note.Id)
+}
@@ -0,0 +1,71 @@
+package tickets
+
+import (
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type NoteListCommand struct {
+}
+
+func (NoteListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "list",
+		Description:      i18n.HelpNoteList,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c NoteListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NoteListCommand) Execute(ctx registry.CommandContext) {
+	ticket, ok := getNoteTicket(ctx)
+	if !ok {
+		return
+	}
+
+	notes, err := dbclient.Local.TicketNotes.GetByTicket(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(notes) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleNotes, i18n.MessageNoteListEmpty)
+		return
+	}
+
+	entries := make([]string, len(notes))
+	for i, note := range notes {
+		if note.EditedAt == nil {
+			entries[i] = ctx.GetMessage(i18n.MessageNoteListEntry, note.Id, note.AuthorId, note.CreatedAt.Unix(), note.Content)
+		} else {
+			entries[i] = ctx.GetMessage(i18n.MessageNoteListEntryEdited, note.Id, note.AuthorId, note.CreatedAt.Unix(), note.EditedAt.Unix(), note.Content)
+		}
+	}
+
+	e := embed.NewEmbed().
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTitle(ctx.GetMessage(i18n.TitleNotes)).
+		SetDescription(utils.StringMax(strings.Join(entries, "\n\n"), 4093, "..."))
+
+	if _, err := ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(e)); err != nil {
+		ctx.HandleError(err)
+	}
+}
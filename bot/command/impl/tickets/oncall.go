@@ -1,19 +1,17 @@
 package tickets
 
 import (
-	"errors"
+	"fmt"
+	"strings"
+
 	permcache "github.com/TicketsBot/common/permission"
-	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/registry"
-	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/sla"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/interaction"
-	"github.com/rxdn/gdl/objects/member"
-	"github.com/rxdn/gdl/rest/request"
-	"time"
 )
 
 type OnCallCommand struct {
@@ -21,13 +19,17 @@ type OnCallCommand struct {
 
 func (OnCallCommand) Properties() registry.Properties {
 	return registry.Properties{
-		Name:             "on-call",
-		Description:      i18n.HelpOnCall,
-		Type:             interaction.ApplicationCommandTypeChatInput,
-		PermissionLevel:  permcache.Support,
+		Name:            "on-call",
+		Description:     i18n.HelpOnCall,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Children: []registry.Command{
+			OnCallToggleCommand{},
+			OnCallWhoCommand{},
+			OnCallScheduleCommand{},
+		},
 		Category:         command.Tickets,
 		DefaultEphemeral: true,
-		Timeout:          time.Second * 8,
 	}
 }
 
@@ -35,141 +37,40 @@ func (c OnCallCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (OnCallCommand) Execute(ctx registry.CommandContext) {
-	settings, err := ctx.Settings()
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	if !settings.UseThreads {
-		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallChannelMode)
-		return
-	}
-
-	member, err := ctx.Member()
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Reflects *new* state
-	onCall, err := dbclient.Client.OnCall.Toggle(ctx, ctx.GuildId(), ctx.UserId())
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, ctx.GuildId(), ctx.UserId(), member)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	teams, err := dbclient.Client.SupportTeam.GetMulti(ctx, ctx.GuildId(), teamIds)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
+func (OnCallCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
 
-	metadata, err := dbclient.Client.GuildMetadata.Get(ctx, ctx.GuildId())
-	if err != nil {
-		ctx.HandleError(err)
-		return
+// formatShiftTimes formats when the shift runs, e.g. Mon, Tue 09:00-17:00 (Europe/London)
+func formatShiftTimes(shift localdb.OnCallShift) string {
+	days := make([]string, len(shift.Days))
+	for i, day := range shift.Days {
+		days[i] = day.String()[:3]
 	}
 
-	if onCall { // *new* value
-		if defaultTeam {
-			if err := assignOnCallRole(ctx, member, metadata.OnCallRole, nil, 0); err != nil {
-				ctx.HandleError(err)
-				return
-			}
-		}
-
-		for i, teamId := range teamIds {
-			if i >= 5 { // Don't get caught up adding roles forever
-				break
-			}
-
-			team, ok := teams[teamId]
-			if !ok {
-				continue
-			}
-
-			if err := assignOnCallRole(ctx, member, team.OnCallRole, &team, 0); err != nil {
-				ctx.HandleError(err)
-				return
-			}
-		}
-
-		// TODO: Add assigning roles progress message
-		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageOnCallSuccess)
-	} else {
-		if defaultTeam && metadata.OnCallRole != nil {
-			if err := ctx.Worker().RemoveGuildMemberRole(ctx.GuildId(), ctx.UserId(), *metadata.OnCallRole); err != nil {
-				ctx.HandleError(err)
-				return
-			}
-		}
-
-		for i, teamId := range teamIds {
-			if i >= 5 { // Don't get caught up adding roles forever
-				break
-			}
-
-			team, ok := teams[teamId]
-			if !ok {
-				continue
-			}
-
-			if team.OnCallRole == nil {
-				continue
-			}
-
-			if err := ctx.Worker().RemoveGuildMemberRole(ctx.GuildId(), ctx.UserId(), *team.OnCallRole); err != nil {
-				ctx.HandleError(err)
-				return
-			}
-		}
-
-		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageOnCallRemoveSuccess)
-	}
+	return fmt.Sprintf("%s %s-%s (%s)", strings.Join(days, ", "), sla.FormatClock(shift.Start), sla.FormatClock(shift.End), shift.Timezone)
 }
 
-// Attempt counter to prevent infinite loop
-func assignOnCallRole(ctx registry.CommandContext, member member.Member, roleId *uint64, team *database.SupportTeam, attempt int) error {
-	if attempt >= 2 {
-		return errors.New("reached retry limit")
+// shiftTeamName returns the name of the team that the shift is for
+func shiftTeamName(ctx registry.CommandContext, shift localdb.OnCallShift, teamNames map[int]string) string {
+	if shift.TeamId == nil {
+		return ctx.GetMessage(i18n.MessageOnCallDefaultTeam)
 	}
 
-	// Create role if it does not exist  yet
-	if roleId == nil {
-		tmp, err := logic.CreateOnCallRole(ctx, ctx, team)
-		if err != nil {
-			return err
-		}
+	return teamNames[*shift.TeamId]
+}
 
-		roleId = &tmp
+// getTeamNames returns the names of the guild's support teams, by ID
+func getTeamNames(ctx registry.CommandContext) (map[int]string, error) {
+	teams, err := dbclient.Client.SupportTeam.Get(ctx, ctx.GuildId())
+	if err != nil {
+		return nil, err
 	}
 
-	if err := ctx.Worker().AddGuildMemberRole(ctx.GuildId(), ctx.UserId(), *roleId); err != nil {
-		// If role was deleted, recreate it
-		if err, ok := err.(request.RestError); ok && err.StatusCode == 404 && err.ApiError.Message == "Unknown Role" {
-			if team == nil {
-				if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, ctx.GuildId(), nil); err != nil {
-					return err
-				}
-			} else {
-				if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
-					return err
-				}
-			}
-
-			return assignOnCallRole(ctx, member, nil, team, attempt+1)
-		} else {
-			return err
-		}
+	names := make(map[int]string, len(teams))
+	for _, team := range teams {
+		names[team.Id] = team.Name
 	}
 
-	return nil
+	return names, nil
 }
@@ -0,0 +1,36 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type OnCallScheduleCommand struct {
+}
+
+func (OnCallScheduleCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "schedule",
+		Description:     i18n.HelpOnCallSchedule,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Children: []registry.Command{
+			OnCallScheduleAddCommand{},
+			OnCallScheduleRemoveCommand{},
+			OnCallScheduleListCommand{},
+		},
+		DefaultEphemeral: true,
+	}
+}
+
+func (c OnCallScheduleCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallScheduleCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
@@ -0,0 +1,172 @@
+package tickets
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const (
+	defaultShiftTimezone = "UTC"
+	maxShiftsPerGuild    = 50
+)
+
+type OnCallScheduleAddCommand struct {
+}
+
+func (OnCallScheduleAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpOnCallScheduleAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("user", "The staff member to put on call", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewRequiredArgument("days", "The days the shift starts on, e.g. mon-fri", interaction.OptionTypeString, i18n.MessageOnCallScheduleInvalidDays),
+			command.NewRequiredArgument("start", "The start of the shift, in HH:MM format", interaction.OptionTypeString, i18n.MessageOnCallScheduleInvalidTime),
+			command.NewRequiredArgument("end", "The end of the shift, in HH:MM format. Shifts that end before they start run overnight", interaction.OptionTypeString, i18n.MessageOnCallScheduleInvalidTime),
+			command.NewOptionalArgument("timezone", "The timezone of the shift, e.g. Europe/London. Defaults to UTC", interaction.OptionTypeString, i18n.MessageOnCallScheduleInvalidTimezone),
+			command.NewOptionalAutocompleteableArgument("team", "The support team to put the staff member on call for. Defaults to the default team", interaction.OptionTypeInteger, i18n.MessageOnCallScheduleInvalidTeam, supportTeamAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c OnCallScheduleAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallScheduleAddCommand) Execute(ctx registry.CommandContext, userId uint64, rawDays, rawStart, rawEnd string, timezone *string, teamId *int) {
+	shift := localdb.OnCallShift{
+		GuildId:  ctx.GuildId(),
+		TeamId:   teamId,
+		UserId:   userId,
+		Timezone: defaultShiftTimezone,
+	}
+
+	if timezone != nil {
+		shift.Timezone = strings.TrimSpace(*timezone)
+	}
+
+	if _, err := time.LoadLocation(shift.Timezone); err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleInvalidTimezone)
+		return
+	}
+
+	days, err := sla.ParseDays(rawDays)
+	if err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleInvalidDays)
+		return
+	}
+
+	shift.Days = days
+
+	start, startErr := sla.ParseClock(rawStart)
+	end, endErr := sla.ParseClock(rawEnd)
+	if startErr != nil || endErr != nil || start == end || start >= 24*60 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleInvalidTime)
+		return
+	}
+
+	shift.Start, shift.End = start, end
+
+	teamName := ctx.GetMessage(i18n.MessageOnCallDefaultTeam)
+	if teamId != nil {
+		team, ok, err := dbclient.Client.SupportTeam.GetById(ctx, ctx.GuildId(), *teamId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleInvalidTeam)
+			return
+		}
+
+		teamName = team.Name
+	}
+
+	member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	permissionLevel, err := permcache.GetPermissionLevel(ctx, utils.ToRetriever(ctx.Worker()), member, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permcache.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidUser)
+		return
+	}
+
+	existing, err := dbclient.Local.OnCallShifts.GetByGuild(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(existing) >= maxShiftsPerGuild {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleLimit, maxShiftsPerGuild)
+		return
+	}
+
+	// The scheduler puts the staff member on call at the next shift boundary check, if the shift has already started
+	id, err := dbclient.Local.OnCallShifts.Create(ctx, shift)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallScheduleAdded, id, userId, teamName, formatShiftTimes(shift))
+}
+
+// Auto-complete handler for selecting a support team
+func supportTeamAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	teams, err := dbclient.Client.SupportTeam.Get(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, team := range teams {
+		if strings.Contains(strings.ToLower(team.Name), strings.ToLower(value)) || strconv.Itoa(team.Id) == value {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  utils.StringMax(team.Name, 100),
+				Value: team.Id,
+			})
+		}
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,61 @@
+package tickets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type OnCallScheduleListCommand struct {
+}
+
+func (OnCallScheduleListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "list",
+		Description:      i18n.HelpOnCallScheduleList,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c OnCallScheduleListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallScheduleListCommand) Execute(ctx registry.CommandContext) {
+	shifts, err := dbclient.Local.OnCallShifts.GetByGuild(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(shifts) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallScheduleListEmpty)
+		return
+	}
+
+	teamNames, err := getTeamNames(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	var joined string
+	for _, shift := range shifts {
+		joined += fmt.Sprintf("• `#%d` <@%d> - %s: %s\n", shift.Id, shift.UserId, shiftTeamName(ctx, shift, teamNames), formatShiftTimes(shift))
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallScheduleList, joined)
+}
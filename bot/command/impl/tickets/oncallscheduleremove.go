@@ -0,0 +1,73 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type OnCallScheduleRemoveCommand struct {
+}
+
+func (OnCallScheduleRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpOnCallScheduleRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("id", "The ID of the shift to remove, shown by /on-call schedule list", interaction.OptionTypeInteger, i18n.MessageOnCallScheduleNotFound),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c OnCallScheduleRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallScheduleRemoveCommand) Execute(ctx registry.CommandContext, id int) {
+	shift, ok, err := dbclient.Local.OnCallShifts.Delete(ctx, ctx.GuildId(), id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallScheduleNotFound)
+		return
+	}
+
+	// If the shift is in progress, take the staff member off call now rather than leaving them on call indefinitely
+	if shift.Active {
+		shifts, err := dbclient.Local.OnCallShifts.GetByGuild(ctx, ctx.GuildId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		var active []localdb.OnCallShift
+		for _, other := range shifts {
+			if other.Active {
+				active = append(active, other)
+			}
+		}
+
+		if err := logic.EndOnCallShift(ctx, ctx, shift, active); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallScheduleRemoved, shift.Id)
+}
@@ -0,0 +1,153 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+type OnCallToggleCommand struct {
+}
+
+func (OnCallToggleCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "toggle",
+		Description:      i18n.HelpOnCallToggle,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c OnCallToggleCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallToggleCommand) Execute(ctx registry.CommandContext) {
+	settings, err := ctx.Settings()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !settings.UseThreads {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOnCallChannelMode)
+		return
+	}
+
+	member, err := ctx.Member()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Staff members who are away can still go off call, but not on call
+	_, away, err := dbclient.Local.StaffAway.Get(ctx, ctx.GuildId(), ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if away {
+		isOnCall, err := dbclient.Client.OnCall.IsOnCall(ctx, ctx.GuildId(), ctx.UserId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !isOnCall {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAwayOnCall)
+			return
+		}
+	}
+
+	// Reflects *new* state
+	onCall, err := dbclient.Client.OnCall.Toggle(ctx, ctx.GuildId(), ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, ctx.GuildId(), ctx.UserId(), member)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	teams, err := dbclient.Client.SupportTeam.GetMulti(ctx, ctx.GuildId(), teamIds)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	metadata, err := dbclient.Client.GuildMetadata.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if onCall { // *new* value
+		if defaultTeam {
+			if err := logic.AssignOnCallRole(ctx, ctx, ctx.UserId(), metadata.OnCallRole, nil); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		for i, teamId := range teamIds {
+			if i >= 5 { // Don't get caught up adding roles forever
+				break
+			}
+
+			team, ok := teams[teamId]
+			if !ok {
+				continue
+			}
+
+			if err := logic.AssignOnCallRole(ctx, ctx, ctx.UserId(), team.OnCallRole, &team); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		// TODO: Add assigning roles progress message
+		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageOnCallSuccess)
+	} else {
+		if defaultTeam && metadata.OnCallRole != nil {
+			if err := ctx.Worker().RemoveGuildMemberRole(ctx.GuildId(), ctx.UserId(), *metadata.OnCallRole); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		for i, teamId := range teamIds {
+			if i >= 5 { // Don't get caught up adding roles forever
+				break
+			}
+
+			team, ok := teams[teamId]
+			if !ok {
+				continue
+			}
+
+			if team.OnCallRole == nil {
+				continue
+			}
+
+			if err := ctx.Worker().RemoveGuildMemberRole(ctx.GuildId(), ctx.UserId(), *team.OnCallRole); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageOnCallRemoveSuccess)
+	}
+}
@@ -0,0 +1,81 @@
+package tickets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type OnCallWhoCommand struct {
+}
+
+func (OnCallWhoCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "who",
+		Description:      i18n.HelpOnCallWho,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c OnCallWhoCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OnCallWhoCommand) Execute(ctx registry.CommandContext) {
+	users, err := dbclient.Client.OnCall.GetUsersOnCall(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(users) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallWhoEmpty)
+		return
+	}
+
+	shifts, err := dbclient.Local.OnCallShifts.GetByGuild(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	teamNames, err := getTeamNames(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Users who are on call because of a shift are shown with the team and times of the shift, users who toggled
+	// themselves on call are shown on their own
+	activeShifts := make(map[uint64][]string)
+	for _, shift := range shifts {
+		if shift.Active {
+			activeShifts[shift.UserId] = append(activeShifts[shift.UserId],
+				fmt.Sprintf("%s: %s", shiftTeamName(ctx, shift, teamNames), formatShiftTimes(shift)))
+		}
+	}
+
+	var joined string
+	for _, userId := range users {
+		if descriptions, ok := activeShifts[userId]; ok {
+			joined += fmt.Sprintf("• <@%d> - %s\n", userId, strings.Join(descriptions, "; "))
+		} else {
+			joined += fmt.Sprintf("• <@%d>\n", userId)
+		}
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	ctx.Reply(customisation.Green, i18n.TitleOnCall, i18n.MessageOnCallWho, joined)
+}
@@ -0,0 +1,52 @@
+package tickets
+
+import (
+	"strings"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type PriorityCommand struct {
+}
+
+func (PriorityCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "priority",
+		Description:     i18n.HelpPriority,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Children: []registry.Command{
+			PrioritySetCommand{},
+			PriorityPanelCommand{},
+		},
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c PriorityCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (PriorityCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
+
+func priorityAutoCompleteHandler(_ interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, priority := range localdb.Priorities {
+		if strings.Contains(string(priority), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(priority),
+				Value: string(priority),
+			})
+		}
+	}
+
+	return choices
+}
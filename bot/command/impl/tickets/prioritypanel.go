@@ -0,0 +1,79 @@
+package tickets
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type PriorityPanelCommand struct {
+}
+
+func (PriorityPanelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "panel",
+		Description:     i18n.HelpPriorityPanel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to change the default priority of", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredAutocompleteableArgument("level", "The priority given to tickets opened from the panel", interaction.OptionTypeString, i18n.MessagePriorityInvalid, priorityAutoCompleteHandler),
+			command.NewOptionalArgument("selectable", "Whether the opener can choose the priority of their ticket", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c PriorityPanelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (PriorityPanelCommand) Execute(ctx registry.CommandContext, panelId int, level string, selectable *bool) {
+	priority, ok := localdb.ParsePriority(level)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePriorityInvalid)
+		return
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		return
+	}
+
+	settings, err := dbclient.Local.PanelPriorities.Get(ctx, panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	settings.Priority = priority
+	if selectable != nil {
+		settings.UserSelectable = *selectable
+	}
+
+	if err := dbclient.Local.PanelPriorities.Set(ctx, panel.PanelId, settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if settings.UserSelectable {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePriorityPanelSetSelectable, panel.Title, priority)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePriorityPanelSet, panel.Title, priority)
+	}
+}
@@ -0,0 +1,69 @@
+package tickets
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type PrioritySetCommand struct {
+}
+
+func (PrioritySetCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "set",
+		Description:     i18n.HelpPrioritySet,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("level", "The new priority of the ticket", interaction.OptionTypeString, i18n.MessagePriorityInvalid, priorityAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c PrioritySetCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (PrioritySetCommand) Execute(ctx registry.CommandContext, level string) {
+	priority, ok := localdb.ParsePriority(level)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessagePriorityInvalid)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Check this is a ticket channel
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.TitlePriority, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	renamed, err := logic.SetPriority(ctx, ctx, ticket, priority)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if renamed {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePrioritySet, priority)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitlePriority, i18n.MessagePrioritySetRenameRatelimited, priority)
+	}
+}
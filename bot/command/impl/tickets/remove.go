@@ -9,9 +9,7 @@ import (
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
-	"github.com/rxdn/gdl/objects/channel"
 	"github.com/rxdn/gdl/objects/interaction"
-	"github.com/rxdn/gdl/permission"
 	"time"
 )
 
@@ -80,31 +78,10 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
-	// Remove user from ticket in DB
-	if err := dbclient.Client.TicketMembers.Delete(ctx, ctx.GuildId(), ticket.Id, userId); err != nil {
+	if err := logic.RemoveTicketMember(ctx, ctx.Worker(), ticket, userId); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	// Remove user from ticket
-	if ticket.IsThread {
-		if err := ctx.Worker().RemoveThreadMember(ctx.ChannelId(), userId); err != nil {
-			ctx.HandleError(err)
-			return
-		}
-	} else {
-		data := channel.PermissionOverwrite{
-			Id:    userId,
-			Type:  channel.PermissionTypeMember,
-			Allow: 0,
-			Deny:  permission.BuildPermissions(logic.StandardPermissions[:]...),
-		}
-
-		if err := ctx.Worker().EditChannelPermissions(ctx.ChannelId(), data); err != nil {
-			ctx.HandleError(err)
-			return
-		}
-	}
-
 	ctx.ReplyPermanent(customisation.Green, i18n.TitleRemove, i18n.MessageRemoveSuccess, userId, ctx.ChannelId())
 }
@@ -0,0 +1,57 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ReplyCommand struct {
+}
+
+func (ReplyCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "reply",
+		Description:     i18n.HelpReply,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("message", "Message to send to the ticket opener's DMs", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("attachment", "File to send with the message", interaction.OptionTypeAttachment, i18n.MessageInvalidArgument),
+		),
+		Timeout: time.Second * 30,
+	}
+}
+
+func (c ReplyCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ReplyCommand) Execute(ctx registry.CommandContext, content string, attachment *channel.Attachment) {
+	replyToModmail(ctx, content, attachment, false)
+}
+
+// replyToModmail sends the reply to the DMs of the user who opened the ticket in the current channel
+func replyToModmail(ctx registry.CommandContext, content string, attachment *channel.Attachment, anonymous bool) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	logic.SendModmailReply(ctx, ctx, ticket, content, attachment, anonymous)
+}
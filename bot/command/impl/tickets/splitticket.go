@@ -0,0 +1,61 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type SplitTicketCommand struct {
+}
+
+func (SplitTicketCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "Split Ticket",
+		Type:             interaction.ApplicationCommandTypeMessage,
+		PermissionLevel:  permission.Support,
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+		Timeout:          constants.TimeoutOpenTicket,
+	}
+}
+
+func (c SplitTicketCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (SplitTicketCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	source, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify this is a ticket channel
+	if source.Id == 0 || source.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	// The new ticket is opened for the author of the message, unless a staff member chooses someone else
+	res, err := logic.BuildSplitResponse(ctx, ctx, source, interaction.Interaction.Data.TargetId, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(res)
+}
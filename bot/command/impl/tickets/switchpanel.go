@@ -17,12 +17,8 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/redis"
-	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
-	"github.com/rxdn/gdl/objects/channel"
-	"github.com/rxdn/gdl/objects/channel/embed"
 	"github.com/rxdn/gdl/objects/interaction"
-	"github.com/rxdn/gdl/rest"
 )
 
 type SwitchPanelCommand struct {
@@ -89,80 +85,16 @@ func (SwitchPanelCommand) Execute(ctx *cmdcontext.SlashCommandContext, panelId i
 		return
 	}
 
-	// Update panel ID in database
-	if err := dbclient.Client.Tickets.SetPanelId(ctx, ctx.GuildId(), ticket.Id, panelId); err != nil {
+	if err := logic.SwitchTicketPanel(ctx, ctx, ticket, &panel); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	// Get ticket claimer
-	claimer, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Generate new channel name
-	channelName, err := logic.GenerateChannelName(ctx.Context, ctx, &panel, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Set new channel topic
-	channelTopic := fmt.Sprintf("Panel: %s | Ticket ID: %d", panel.Title, ticket.Id)
-
-	// Handle thread tickets separately
 	if ticket.IsThread {
-		data := rest.ModifyChannelData{
-			Name:  channelName,
-			Topic: &channelTopic,
-		}
-
-		if _, err := ctx.Worker().ModifyChannel(*ticket.ChannelId, data); err != nil {
-			ctx.HandleError(err)
-			return
-		}
-
 		ctx.ReplyRaw(customisation.Green, "Success", fmt.Sprintf("This ticket has been switched to the panel **%s**.\n\nNote: As this is a thread, the permissions could not be bulk updated.", panel.Title))
 		return
 	}
 
-	// Fetch additional ticket members
-	members, err := dbclient.Client.TicketMembers.Get(ctx, ctx.GuildId(), ticket.Id)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Generate new permissions
-	var overwrites []channel.PermissionOverwrite
-	if claimer == 0 {
-		overwrites, err = logic.CreateOverwrites(ctx.Context, ctx, ticket.UserId, &panel, members...)
-	} else {
-		overwrites, err = logic.GenerateClaimedOverwrites(ctx.Context, ctx.Worker(), ticket, claimer)
-		if err != nil {
-			ctx.HandleError(err)
-			return
-		}
-		if overwrites == nil {
-			overwrites, err = logic.CreateOverwrites(ctx.Context, ctx, ticket.UserId, &panel, members...)
-		}
-	}
-
-	// Update channel with new settings
-	data := rest.ModifyChannelData{
-		Name:                 channelName,
-		Topic:                &channelTopic, // <-- Updating the channel topic
-		PermissionOverwrites: overwrites,
-		ParentId:             panel.TargetCategory,
-	}
-
-	if _, err = ctx.Worker().ModifyChannel(*ticket.ChannelId, data); err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
 	ctx.ReplyPermanent(customisation.Green, i18n.TitlePanelSwitched, i18n.MessageSwitchPanelSuccess, panel.Title, ctx.UserId())
 }
 
@@ -0,0 +1,35 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsCommand struct {
+}
+
+func (TicketsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "tickets",
+		Description:     i18n.HelpTickets,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Children: []registry.Command{
+			TicketsBulkCommand{},
+			TicketsSearchCommand{},
+		},
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
@@ -0,0 +1,206 @@
+package tickets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/model"
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+)
+
+// The preview only lists the oldest matching tickets, to stay within the embed description limit
+const bulkPreviewTicketLimit = 15
+
+type TicketsBulkCommand struct {
+}
+
+func (TicketsBulkCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "bulk",
+		Description:     i18n.HelpTicketsBulk,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Children: []registry.Command{
+			TicketsBulkCloseCommand{},
+			TicketsBulkClaimCommand{},
+			TicketsBulkMoveCommand{},
+			TicketsBulkTagCommand{},
+		},
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsBulkCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkCommand) Execute(_ registry.CommandContext) {
+	// Cannot call parent command
+}
+
+// bulkFilterArguments are shared by each bulk action, and must be the last arguments of the command, in this order
+func bulkFilterArguments() []command.Argument {
+	return command.Arguments(
+		command.NewOptionalAutocompleteableArgument("panel", "Only select tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageSwitchPanelInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+		command.NewOptionalArgument("opener", "Only select tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		command.NewOptionalArgument("older-than", "Only select tickets opened longer ago than this, e.g. 7d", interaction.OptionTypeString, i18n.MessageBulkInvalidDuration),
+		command.NewOptionalAutocompleteableArgument("status", "Only select tickets with this status", interaction.OptionTypeString, i18n.MessageBulkInvalidStatus, bulkStatusAutoCompleteHandler),
+		command.NewOptionalArgument("claimed-by", "Only select tickets claimed by this staff member", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		command.NewOptionalArgument("inactive-for", "Only select tickets without any messages for this long, e.g. 3d", interaction.OptionTypeString, i18n.MessageBulkInvalidDuration),
+	)
+}
+
+// parseBulkFilter replies with an error and returns false if any of the filters are invalid
+func parseBulkFilter(
+	ctx registry.CommandContext,
+	panelId *int,
+	openerId *uint64,
+	olderThan, status *string,
+	claimedBy *uint64,
+	inactiveFor *string,
+) (localdb.TicketFilter, bool) {
+	filter := localdb.TicketFilter{
+		OpenerId:  openerId,
+		ClaimedBy: claimedBy,
+	}
+
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return localdb.TicketFilter{}, false
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+			return localdb.TicketFilter{}, false
+		}
+
+		filter.PanelId = panelId
+	}
+
+	if olderThan != nil {
+		duration, err := utils.ParseDuration(*olderThan)
+		if err != nil || duration <= 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidDuration)
+			return localdb.TicketFilter{}, false
+		}
+
+		filter.OpenedBefore = utils.Ptr(time.Now().Add(-duration))
+	}
+
+	if status != nil {
+		switch model.TicketStatus(strings.ToUpper(*status)) {
+		case model.TicketStatusOpen:
+			filter.Status = utils.Ptr(model.TicketStatusOpen)
+		case model.TicketStatusPending:
+			filter.Status = utils.Ptr(model.TicketStatusPending)
+		default:
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidStatus)
+			return localdb.TicketFilter{}, false
+		}
+	}
+
+	if inactiveFor != nil {
+		duration, err := utils.ParseDuration(*inactiveFor)
+		if err != nil || duration <= 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidDuration)
+			return localdb.TicketFilter{}, false
+		}
+
+		filter.InactiveSince = utils.Ptr(time.Now().Add(-duration))
+	}
+
+	return filter, true
+}
+
+// previewBulkOperation selects the tickets matching the filter, and replies with a preview of the operation, which
+// must be confirmed before anything is changed
+func previewBulkOperation(ctx registry.CommandContext, filter localdb.TicketFilter, op logic.BulkOperation, action i18n.MessageId, actionFormat ...interface{}) {
+	// Fetch one more than the limit, to know whether any matching tickets were left out
+	tickets, err := dbclient.Local.TicketQueries.Find(ctx, ctx.GuildId(), filter, logic.BulkTicketLimit+1)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(tickets) == 0 {
+		ctx.Reply(customisation.Red, i18n.TitleBulk, i18n.MessageBulkNoMatches)
+		return
+	}
+
+	limited := len(tickets) > logic.BulkTicketLimit
+	if limited {
+		tickets = tickets[:logic.BulkTicketLimit]
+	}
+
+	op.GuildId = ctx.GuildId()
+	op.UserId = ctx.UserId()
+	op.TicketIds = make([]int, len(tickets))
+	for i, ticket := range tickets {
+		op.TicketIds[i] = ticket.Id
+	}
+
+	id, err := logic.StoreBulkOperation(ctx, op)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	summary := ctx.GetMessage(action, append([]interface{}{len(tickets)}, actionFormat...)...)
+	if limited {
+		summary += "\n" + ctx.GetMessage(i18n.MessageBulkLimited, logic.BulkTicketLimit)
+	}
+
+	var joined string
+	for i, ticket := range tickets {
+		if i == bulkPreviewTicketLimit {
+			joined += ctx.GetMessage(i18n.MessageBulkPreviewMore, len(tickets)-bulkPreviewTicketLimit)
+			break
+		}
+
+		joined += fmt.Sprintf("• <#%d> (#%d) <@%d> <t:%d:R>\n", ticket.ChannelId, ticket.Id, ticket.UserId, ticket.OpenTime.Unix())
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	e := utils.BuildEmbed(ctx, customisation.Orange, i18n.TitleBulk, i18n.MessageBulkPreview, nil, summary, joined)
+	res := command.NewEphemeralEmbedMessageResponseWithComponents(e, utils.Slice(component.BuildActionRow(
+		component.BuildButton(component.Button{
+			Label:    ctx.GetMessage(i18n.Confirm),
+			CustomId: logic.BulkConfirmCustomIdPrefix + id,
+			Style:    component.ButtonStyleDanger,
+		}),
+		component.BuildButton(component.Button{
+			Label:    ctx.GetMessage(i18n.Cancel),
+			CustomId: logic.BulkCancelCustomIdPrefix + id,
+			Style:    component.ButtonStyleSecondary,
+		}),
+	)))
+
+	if _, err := ctx.ReplyWith(res); err != nil {
+		ctx.HandleError(err)
+	}
+}
+
+func bulkStatusAutoCompleteHandler(_ interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, status := range []string{"open", "pending"} {
+		if strings.Contains(status, strings.ToLower(value)) {
+			choices = append(choices, utils.StringChoice(status))
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,84 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkClaimCommand struct {
+}
+
+func (TicketsBulkClaimCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "claim",
+		Description:     i18n.HelpTicketsBulkClaim,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: append(command.Arguments(
+			command.NewOptionalArgument("user", "The staff member to claim the tickets for. Defaults to yourself", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		), bulkFilterArguments()...),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c TicketsBulkClaimCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkClaimCommand) Execute(ctx registry.CommandContext, userId *uint64, panelId *int, openerId *uint64, olderThan, status *string, claimedBy *uint64, inactiveFor *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, status, claimedBy, inactiveFor)
+	if !ok {
+		return
+	}
+
+	claimerId := ctx.UserId()
+	if userId != nil && *userId != ctx.UserId() {
+		member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), *userId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		permissionLevel, err := permcache.GetPermissionLevel(ctx, utils.ToRetriever(ctx.Worker()), member, ctx.GuildId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if permissionLevel < permcache.Support {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidUser)
+			return
+		}
+
+		away, isAway, err := dbclient.Local.StaffAway.Get(ctx, ctx.GuildId(), *userId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if isAway {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAwayTransferTarget, *userId, away.Until.Unix())
+			return
+		}
+
+		claimerId = *userId
+	}
+
+	op := logic.BulkOperation{
+		Action:    logic.BulkActionClaim,
+		ClaimerId: claimerId,
+	}
+
+	previewBulkOperation(ctx, filter, op, i18n.MessageBulkPreviewClaim, claimerId)
+}
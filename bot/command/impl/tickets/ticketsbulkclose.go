@@ -0,0 +1,54 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkCloseCommand struct {
+}
+
+func (TicketsBulkCloseCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "close",
+		Description:     i18n.HelpTicketsBulkClose,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: append(command.Arguments(
+			command.NewOptionalArgument("reason", "The reason the tickets are being closed", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		), bulkFilterArguments()...),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c TicketsBulkCloseCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkCloseCommand) Execute(ctx registry.CommandContext, reason *string, panelId *int, openerId *uint64, olderThan, status *string, claimedBy *uint64, inactiveFor *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, status, claimedBy, inactiveFor)
+	if !ok {
+		return
+	}
+
+	if reason != nil && len(*reason) > 255 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonTooLong)
+		return
+	}
+
+	op := logic.BulkOperation{
+		Action: logic.BulkActionClose,
+		Reason: reason,
+	}
+
+	previewBulkOperation(ctx, filter, op, i18n.MessageBulkPreviewClose)
+}
@@ -0,0 +1,61 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkMoveCommand struct {
+}
+
+func (TicketsBulkMoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "move",
+		Description:     i18n.HelpTicketsBulkMove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: append(command.Arguments(
+			command.NewRequiredAutocompleteableArgument("target-panel", "The panel to move the tickets to", interaction.OptionTypeInteger, i18n.MessageSwitchPanelInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+		), bulkFilterArguments()...),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c TicketsBulkMoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkMoveCommand) Execute(ctx registry.CommandContext, targetPanelId int, panelId *int, openerId *uint64, olderThan, status *string, claimedBy *uint64, inactiveFor *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, status, claimedBy, inactiveFor)
+	if !ok {
+		return
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(ctx, targetPanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		return
+	}
+
+	op := logic.BulkOperation{
+		Action:  logic.BulkActionMove,
+		PanelId: panel.PanelId,
+	}
+
+	previewBulkOperation(ctx, filter, op, i18n.MessageBulkPreviewMove, panel.Title)
+}
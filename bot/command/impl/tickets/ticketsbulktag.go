@@ -0,0 +1,62 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tags"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkTagCommand struct {
+}
+
+func (TicketsBulkTagCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "tag",
+		Description:     i18n.HelpTicketsBulkTag,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Admin,
+		Category:        command.Tickets,
+		Arguments: append(command.Arguments(
+			command.NewRequiredAutocompleteableArgument("tag", "The ID of the tag to send to each ticket", interaction.OptionTypeString, i18n.MessageTagInvalidTag, tags.TagCommand{}.AutoCompleteHandler),
+		), bulkFilterArguments()...),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 8,
+	}
+}
+
+func (c TicketsBulkTagCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkTagCommand) Execute(ctx registry.CommandContext, tagId string, panelId *int, openerId *uint64, olderThan, status *string, claimedBy *uint64, inactiveFor *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, status, claimedBy, inactiveFor)
+	if !ok {
+		return
+	}
+
+	tag, ok, err := dbclient.Client.Tag.Get(ctx, ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		return
+	}
+
+	op := logic.BulkOperation{
+		Action: logic.BulkActionTag,
+		TagId:  tag.Id,
+	}
+
+	previewBulkOperation(ctx, filter, op, i18n.MessageBulkPreviewTag, tag.Id)
+}
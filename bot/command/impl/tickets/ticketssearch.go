@@ -0,0 +1,160 @@
+package tickets
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsSearchCommand struct {
+}
+
+func (c TicketsSearchCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "search",
+		Description:     i18n.HelpTicketsSearch,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewOptionalAutocompleteableArgument("id", "Only find the ticket with this ID", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, c.AutoCompleteHandler),
+			command.NewOptionalArgument("opener", "Only find tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalArgument("claimer", "Only find tickets claimed by this staff member", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("panel", "Only find tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageSwitchPanelInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("from", "Only find tickets opened on or after this date, in YYYY-MM-DD format", interaction.OptionTypeString, i18n.MessageSearchInvalidDate),
+			command.NewOptionalArgument("to", "Only find tickets opened on or before this date, in YYYY-MM-DD format", interaction.OptionTypeString, i18n.MessageSearchInvalidDate),
+			command.NewOptionalArgument("close-reason", "Only find tickets whose close reason contains this text", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("form-answer", "Only find tickets with a form answer containing this text", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("rating", "Only find tickets given this rating, from 1 to 5", interaction.OptionTypeInteger, i18n.MessageSearchInvalidRating),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c TicketsSearchCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsSearchCommand) Execute(
+	ctx registry.CommandContext,
+	ticketId *int,
+	openerId, claimedBy *uint64,
+	panelId *int,
+	from, to, closeReason, formAnswer *string,
+	rating *int,
+) {
+	search := localdb.TicketSearch{
+		TicketId:    ticketId,
+		OpenerId:    openerId,
+		ClaimedBy:   claimedBy,
+		CloseReason: closeReason,
+		FormAnswer:  formAnswer,
+	}
+
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+			return
+		}
+
+		search.PanelId = panelId
+	}
+
+	if from != nil {
+		date, err := time.Parse(time.DateOnly, *from)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSearchInvalidDate)
+			return
+		}
+
+		search.OpenedAfter = &date
+	}
+
+	// The end date is inclusive, so tickets opened at any time on that day are found
+	if to != nil {
+		date, err := time.Parse(time.DateOnly, *to)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSearchInvalidDate)
+			return
+		}
+
+		search.OpenedBefore = utils.Ptr(date.AddDate(0, 0, 1))
+	}
+
+	if search.OpenedAfter != nil && search.OpenedBefore != nil && !search.OpenedAfter.Before(*search.OpenedBefore) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSearchInvalidDate)
+		return
+	}
+
+	if rating != nil {
+		if *rating < 1 || *rating > 5 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSearchInvalidRating)
+			return
+		}
+
+		search.Rating = rating
+	}
+
+	searchId, err := logic.StoreTicketSearch(ctx, search)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	res, err := logic.BuildTicketSearchMessage(ctx, ctx, searchId, search, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	res.Flags = message.SumFlags(message.FlagEphemeral)
+	if _, err := ctx.ReplyWith(res); err != nil {
+		ctx.HandleError(err)
+	}
+}
+
+// AutoCompleteHandler suggests the IDs of the most recent tickets
+func (TicketsSearchCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	ids, err := dbclient.Local.TicketQueries.GetRecentIds(ctx, data.GuildId.Value, value, 25)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, len(ids))
+	for i, id := range ids {
+		choices[i] = interaction.ApplicationCommandOptionChoice{
+			Name:  "#" + strconv.Itoa(id),
+			Value: id,
+		}
+	}
+
+	return choices
+}
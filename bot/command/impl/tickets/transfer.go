@@ -79,7 +79,18 @@ func (TransferCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
-	if err := logic.ClaimTicket(ctx, ctx, ticket, userId); err != nil {
+	away, isAway, err := dbclient.Local.StaffAway.Get(ctx, ctx.GuildId(), userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if isAway {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAwayTransferTarget, userId, away.Until.Unix())
+		return
+	}
+
+	if err := logic.TransferTicket(ctx, ctx, ticket, userId); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -0,0 +1,59 @@
+package tickets
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type UnwatchCommand struct {
+}
+
+func (UnwatchCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "unwatch",
+		Description:      i18n.HelpUnwatch,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 3,
+	}
+}
+
+func (c UnwatchCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (UnwatchCommand) Execute(ctx registry.CommandContext) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify this is a ticket channel
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	removed, err := dbclient.Local.TicketWatchers.Remove(ctx, ticket.GuildId, ticket.Id, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !removed {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageWatchNotWatching)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleWatch, i18n.MessageUnwatchSuccess, ticket.Id)
+}
@@ -0,0 +1,39 @@
+package tickets
+
+import (
+	"time"
+
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ViewHistoryCommand struct {
+}
+
+func (ViewHistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "View History",
+		Type:             interaction.ApplicationCommandTypeUser,
+		PermissionLevel:  permcache.Support,
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c ViewHistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ViewHistoryCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	replyWithHistory(ctx, interaction.Interaction.Data.TargetId)
+}
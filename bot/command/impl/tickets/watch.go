@@ -0,0 +1,59 @@
+package tickets
+
+import (
+	"time"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type WatchCommand struct {
+}
+
+func (WatchCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "watch",
+		Description:      i18n.HelpWatch,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Tickets,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 3,
+	}
+}
+
+func (c WatchCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (WatchCommand) Execute(ctx registry.CommandContext) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify this is a ticket channel
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	added, err := dbclient.Local.TicketWatchers.Add(ctx, ticket.GuildId, ticket.Id, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !added {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageWatchAlreadyWatching)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleWatch, i18n.MessageWatchSuccess, ticket.Id)
+}
@@ -43,6 +43,7 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["premium"] = settings.PremiumCommand{}
 	cm.registry["removeadmin"] = settings.RemoveAdminCommand{}
 	cm.registry["removesupport"] = settings.RemoveSupportCommand{}
+	cm.registry["settings"] = settings.SettingsCommand{}
 	cm.registry["premium"] = settings.PremiumCommand{}
 	cm.registry["setup"] = setup.SetupCommand{}
 	cm.registry["viewstaff"] = settings.ViewStaffCommand{}
@@ -53,19 +54,33 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["tag"] = tags.TagCommand{}
 
 	cm.registry["add"] = tickets.AddCommand{}
+	cm.registry["adopt"] = tickets.AdoptCommand{}
+	cm.registry["areply"] = tickets.AReplyCommand{}
+	cm.registry["away"] = tickets.AwayCommand{}
 	cm.registry["claim"] = tickets.ClaimCommand{}
 	cm.registry["close"] = tickets.CloseCommand{}
 	cm.registry["closerequest"] = tickets.CloseRequestCommand{}
+	cm.registry["history"] = tickets.HistoryCommand{}
+	cm.registry["label"] = tickets.LabelCommand{}
+	cm.registry["merge"] = tickets.MergeCommand{}
+	cm.registry["note"] = tickets.NoteCommand{}
 	cm.registry["notes"] = tickets.NotesCommand{}
 	cm.registry["on-call"] = tickets.OnCallCommand{}
 	cm.registry["open"] = tickets.OpenCommand{}
 	cm.registry["Start Ticket"] = tickets.StartTicketCommand{}
+	cm.registry["priority"] = tickets.PriorityCommand{}
 	cm.registry["remove"] = tickets.RemoveCommand{}
 	cm.registry["rename"] = tickets.RenameCommand{}
 	cm.registry["reopen"] = tickets.ReopenCommand{}
+	cm.registry["reply"] = tickets.ReplyCommand{}
+	cm.registry["Split Ticket"] = tickets.SplitTicketCommand{}
 	cm.registry["switchpanel"] = tickets.SwitchPanelCommand{}
+	cm.registry["tickets"] = tickets.TicketsCommand{}
 	cm.registry["transfer"] = tickets.TransferCommand{}
 	cm.registry["unclaim"] = tickets.UnclaimCommand{}
+	cm.registry["unwatch"] = tickets.UnwatchCommand{}
+	cm.registry["View History"] = tickets.ViewHistoryCommand{}
+	cm.registry["watch"] = tickets.WatchCommand{}
 }
 
 func (cm *CommandManager) RunSetupFuncs() {
@@ -154,8 +169,14 @@ func buildOption(cmd registry.Command) interaction.ApplicationCommandOption {
 
 	options := append(required, optional...)
 
+	// Children that have children of their own are subcommand groups
+	optionType := interaction.OptionTypeSubCommand
+	if len(properties.Children) > 0 {
+		optionType = interaction.OptionTypeSubCommandGroup
+	}
+
 	return interaction.ApplicationCommandOption{
-		Type:        interaction.OptionTypeSubCommand,
+		Type:        optionType,
 		Name:        properties.Name,
 		Description: i18n.GetMessage(i18n.LocaleEnglish, properties.Description),
 		Default:     false,
@@ -4,14 +4,22 @@ import (
 	"context"
 	"fmt"
 	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/localdb"
 	"github.com/TicketsBot/worker/config"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.uber.org/zap"
+	"time"
 )
 
 var Client *database.Database
 
+// Local holds the tables owned by the worker, which are not part of the shared database module
+var Local *localdb.Database
+
+// pool is shared by Client and Local, and is kept for health checks, as Client does not expose it
+var pool *pgxpool.Pool
+
 func Connect(logger *zap.Logger) {
 	cfg, err := pgxpool.ParseConfig(fmt.Sprintf(
 		"postgres://%s:%s@%s/%s?pool_max_conns=%d",
@@ -31,11 +39,25 @@ func Connect(logger *zap.Logger) {
 	cfg.ConnConfig.LogLevel = pgx.LogLevelWarn
 	cfg.ConnConfig.Logger = NewLogAdapter(logger)
 
-	pool, err := pgxpool.ConnectConfig(context.Background(), cfg)
+	pool, err = pgxpool.ConnectConfig(context.Background(), cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 		return
 	}
 
 	Client = database.NewDatabase(pool)
+	Local = localdb.NewDatabase(pool)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	if err := Local.CreateTables(ctx); err != nil {
+		logger.Fatal("Failed to create worker tables", zap.Error(err))
+		return
+	}
+}
+
+// Ping checks that a connection to the database can be acquired and used
+func Ping(ctx context.Context) error {
+	return pool.Ping(ctx)
 }
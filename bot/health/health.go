@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Check returns an error if the dependency is unhealthy
+type Check func(ctx context.Context) error
+
+type DependencyStatus struct {
+	Healthy   bool   `json:"healthy"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+var (
+	mu     sync.RWMutex
+	checks = make(map[string]Check)
+)
+
+// Register adds a dependency to the readiness check. Registering the same name twice replaces the previous check.
+func Register(name string, check Check) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	checks[name] = check
+}
+
+// Run checks all registered dependencies in parallel, returning the status of each and whether they are all healthy
+func Run(ctx context.Context) (map[string]DependencyStatus, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var (
+		wg       sync.WaitGroup
+		statusMu sync.Mutex
+		statuses = make(map[string]DependencyStatus, len(checks))
+		healthy  = true
+	)
+
+	for name, check := range checks {
+		name, check := name, check
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := check(ctx)
+
+			status := DependencyStatus{
+				Healthy:   err == nil,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+
+			if err != nil {
+				status.Error = err.Error()
+			}
+
+			statusMu.Lock()
+			defer statusMu.Unlock()
+
+			statuses[name] = status
+			if err != nil {
+				healthy = false
+			}
+		}()
+	}
+
+	wg.Wait()
+	return statuses, healthy
+}
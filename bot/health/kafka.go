@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// NewKafkaLagCheck returns a check that fails if the consumer group has fallen more than maxLag records behind, or if
+// the group cannot be described. The returned client should be closed on shutdown.
+func NewKafkaLagCheck(brokers []string, consumerGroup string, maxLag int64) (Check, *kadm.Client, error) {
+	client, err := kadm.NewOptClient(kgo.SeedBrokers(brokers...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	check := func(ctx context.Context) error {
+		lags, err := client.Lag(ctx, consumerGroup)
+		if err != nil {
+			return err
+		}
+
+		lag, ok := lags[consumerGroup]
+		if !ok {
+			return fmt.Errorf("consumer group %s not found", consumerGroup)
+		}
+
+		if err := lag.Error(); err != nil {
+			return err
+		}
+
+		if total := lag.Lag.Total(); total > maxLag {
+			return fmt.Errorf("consumer lag of %d exceeds maximum of %d", total, maxLag)
+		}
+
+		return nil
+	}
+
+	return check, client, nil
+}
@@ -28,6 +28,16 @@ func OnMemberLeave(worker *worker.Context, e events.GuildMemberRemove) {
 		sentry.Error(err)
 	}
 
+	// Hand over the tickets that were automatically assigned to the user
+	ticketIds, err := dbclient.Local.AutoAssign.GetAssignedTickets(ctx, e.GuildId, e.User.Id)
+	if err != nil {
+		sentry.Error(err)
+	} else {
+		for _, ticketId := range ticketIds {
+			reassignTicket(worker, e.GuildId, ticketId, e.User.Id)
+		}
+	}
+
 	// auto close
 	settings, err := dbclient.Client.AutoClose.Get(ctx, e.GuildId)
 	if err != nil {
@@ -74,3 +84,29 @@ func OnMemberLeave(worker *worker.Context, e events.GuildMemberRemove) {
 		}
 	}
 }
+
+func reassignTicket(worker *worker.Context, guildId uint64, ticketId int, previous uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutOpenTicket)
+	defer cancel()
+
+	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, guildId)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	if ticket.ChannelId == nil {
+		return
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	cc := cmdcontext.NewAutoCloseContext(ctx, worker, guildId, *ticket.ChannelId, worker.BotId, premiumTier)
+	if err := logic.ReassignTicket(ctx, cc, ticket, previous); err != nil {
+		sentry.Error(err)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
 	"github.com/TicketsBot/worker/bot/redis"
@@ -33,8 +35,12 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 
 	statsd.Client.IncrementKey(statsd.KeyMessages)
 
-	// ignore DMs
+	// DMs are relayed to the author's modmail ticket
 	if e.GuildId == 0 {
+		if e.Author.Id != worker.BotId && !e.Author.Bot {
+			onDirectMessage(worker, e)
+		}
+
 		return
 	}
 
@@ -60,6 +66,35 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 			}
 		})
 
+		// The opener replying means they still need help, so any scheduled close no longer applies
+		if e.Author.Id == ticket.UserId {
+			sentry.WithSpan0(span.Context(), "Cancel scheduled close", func(span *sentry.Span) {
+				if err := logic.CancelScheduledCloseOnReply(ctx, worker, ticket); err != nil {
+					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				}
+			})
+
+			sentry.WithSpan0(span.Context(), "Record watch event", func(span *sentry.Span) {
+				if err := dbclient.Local.WatchEvents.Record(ctx, e.GuildId, ticket.Id, localdb.WatchEventMessage, e.Author.Id, nil); err != nil {
+					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				}
+			})
+		}
+
+		// Let the author know if they are waiting on someone who is away
+		if len(e.Mentions) > 0 {
+			sentry.WithSpan0(span.Context(), "Notify away mentions", func(span *sentry.Span) {
+				mentioned := make([]uint64, len(e.Mentions))
+				for i, user := range e.Mentions {
+					mentioned[i] = user.Id
+				}
+
+				if err := logic.NotifyAwayMentions(ctx, worker, ticket, e.Author.Id, mentioned); err != nil {
+					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				}
+			})
+		}
+
 		isStaffCached, err = sentry.WithSpan2(span.Context(), "Update ticket last activity", func(span *sentry.Span) (*bool, error) {
 			v, err := isStaff(ctx, e, ticket)
 			return &v, err
@@ -81,6 +116,18 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 						sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 					}
 				})
+
+				sentry.WithSpan0(span.Context(), "Stop SLA response clocks", func(span *sentry.Span) {
+					if err := dbclient.Local.TicketSla.SetResponded(ctx, e.GuildId, ticket.Id); err != nil {
+						sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+					}
+				})
+			} else {
+				sentry.WithSpan0(span.Context(), "Start SLA next response clock", func(span *sentry.Span) {
+					if err := dbclient.Local.TicketSla.SetAwaiting(ctx, e.GuildId, ticket.Id); err != nil {
+						sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+					}
+				})
 			}
 		}
 	}
@@ -135,6 +182,11 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 				}
 
+				status := string(newStatus)
+				if err := dbclient.Local.WatchEvents.Record(ctx, e.GuildId, ticket.Id, localdb.WatchEventStatus, e.Author.Id, &status); err != nil {
+					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				}
+
 				if !ticket.IsThread {
 					if err := sentry.WithSpan1(span.Context(), "Update status update queue", func(span *sentry.Span) error {
 						return dbclient.Client.CategoryUpdateQueue.Add(ctx, e.GuildId, ticket.Id, newStatus)
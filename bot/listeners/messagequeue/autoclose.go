@@ -10,7 +10,7 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
-	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 	gdlUtils "github.com/rxdn/gdl/utils"
 )
@@ -19,13 +19,13 @@ const AutoCloseReason = "Automatically closed due to inactivity"
 
 func ListenAutoClose() {
 	ch := make(chan autoclose.Ticket)
-	go autoclose.Listen(redis.Client, ch)
+	go listenQueue(queueAutoClose, ch)
 
 	for ticket := range ch {
 		statsd.Client.IncrementKey(statsd.AutoClose)
 
 		ticket := ticket
-		go func() {
+		shutdown.Go(func() {
 			ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutCloseTicket)
 			defer cancel()
 
@@ -57,6 +57,6 @@ func ListenAutoClose() {
 
 			cc := cmdcontext.NewAutoCloseContext(ctx, worker, ticket.GuildId, *ticket.ChannelId, worker.BotId, premiumTier)
 			logic.CloseTicket(ctx, cc, gdlUtils.StrPtr(AutoCloseReason), true)
-		}()
+		})
 	}
 }
@@ -2,7 +2,6 @@ package messagequeue
 
 import (
 	"context"
-	"github.com/TicketsBot/common/closerequest"
 	"github.com/TicketsBot/common/sentry"
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker/bot/cache"
@@ -11,19 +10,19 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
-	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 )
 
 func ListenCloseRequestTimer() {
 	ch := make(chan database.CloseRequest)
-	go closerequest.Listen(redis.Client, ch)
+	go listenQueue(queueCloseRequestTimer, ch)
 
 	for request := range ch {
 		statsd.Client.IncrementKey(statsd.AutoClose)
 
 		request := request
-		go func() {
+		shutdown.Go(func() {
 			ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutCloseTicket)
 			defer cancel()
 
@@ -55,6 +54,6 @@ func ListenCloseRequestTimer() {
 
 			cc := cmdcontext.NewAutoCloseContext(ctx, worker, ticket.GuildId, *ticket.ChannelId, request.UserId, premiumTier)
 			logic.CloseTicket(ctx, cc, request.Reason, true)
-		}()
+		})
 	}
 }
@@ -10,12 +10,16 @@ import (
 )
 
 func buildContext(ctx context.Context, ticket database.Ticket, cache *cache.PgCache) (*worker.Context, error) {
+	return buildGuildContext(ctx, ticket.GuildId, cache)
+}
+
+func buildGuildContext(ctx context.Context, guildId uint64, cache *cache.PgCache) (*worker.Context, error) {
 	worker := &worker.Context{
 		Cache:       cache,
 		RateLimiter: nil, // Use http-proxy ratelimiting functionality
 	}
 
-	whitelabelBotId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, ticket.GuildId)
+	whitelabelBotId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/redis"
+)
+
+const journalCleanupInterval = time.Hour
+
+// ListenJournalCleanup removes the transcript journal of tickets that were closed without going through
+// logic.CloseTicket, which would otherwise be kept forever
+func ListenJournalCleanup() {
+	ticker := time.NewTicker(journalCleanupInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		runJournalCleanup()
+	}
+}
+
+func runJournalCleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	locked, err := redis.TakeJobLock(ctx, "journal_cleanup", journalCleanupInterval-time.Minute)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Another worker is running the job
+	if !locked {
+		return
+	}
+
+	if err := dbclient.Local.TranscriptJournal.DeleteClosed(ctx); err != nil {
+		sentry.Error(err)
+	}
+}
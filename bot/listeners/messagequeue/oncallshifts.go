@@ -0,0 +1,129 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/cache"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+)
+
+const onCallShiftInterval = time.Minute
+
+// ListenOnCallShifts puts staff members on and off call at the boundaries of their scheduled shifts. Shifts that
+// started or ended while no worker was running are caught up on the next run.
+func ListenOnCallShifts() {
+	ticker := time.NewTicker(onCallShiftInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		runOnCallShifts()
+	}
+}
+
+func runOnCallShifts() {
+	ctx, cancel := context.WithTimeout(context.Background(), onCallShiftInterval)
+	defer cancel()
+
+	locked, err := redis.TakeJobLock(ctx, "on_call_shifts", onCallShiftInterval-time.Second*5)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Another worker is running the job
+	if !locked {
+		return
+	}
+
+	shifts, err := dbclient.Local.OnCallShifts.GetAll(ctx)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	now := time.Now()
+
+	var active, starting, ending []localdb.OnCallShift
+	for _, shift := range shifts {
+		inProgress, err := shift.ActiveAt(now)
+		if err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		if inProgress {
+			active = append(active, shift)
+		}
+
+		if inProgress && !shift.Active {
+			starting = append(starting, shift)
+		} else if !inProgress && shift.Active {
+			ending = append(ending, shift)
+		}
+	}
+
+	contexts := make(map[uint64]registry.CommandContext)
+
+	// End shifts first, so that roles are not removed from staff members whose next shift has just started
+	for _, shift := range ending {
+		cmd, err := getShiftContext(ctx, contexts, shift.GuildId)
+		if err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		if err := logic.EndOnCallShift(ctx, cmd, shift, active); err != nil {
+			sentry.Error(err)
+		}
+
+		if err := dbclient.Local.OnCallShifts.SetActive(ctx, shift.Id, false); err != nil {
+			sentry.Error(err)
+		}
+	}
+
+	for _, shift := range starting {
+		cmd, err := getShiftContext(ctx, contexts, shift.GuildId)
+		if err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		// The shift is marked as active even if the staff member could not be put on call, e.g. because they have left
+		// the guild, so that it is not retried every minute
+		if err := logic.StartOnCallShift(ctx, cmd, shift); err != nil {
+			sentry.Error(err)
+		}
+
+		if err := dbclient.Local.OnCallShifts.SetActive(ctx, shift.Id, true); err != nil {
+			sentry.Error(err)
+		}
+	}
+}
+
+func getShiftContext(ctx context.Context, contexts map[uint64]registry.CommandContext, guildId uint64) (registry.CommandContext, error) {
+	if cmd, ok := contexts[guildId]; ok {
+		return cmd, nil
+	}
+
+	worker, err := buildGuildContext(ctx, guildId, cache.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := cmdcontext.NewAutoCloseContext(ctx, worker, guildId, 0, worker.BotId, premiumTier)
+	contexts[guildId] = cmd
+	return cmd, nil
+}
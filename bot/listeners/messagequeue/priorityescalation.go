@@ -0,0 +1,107 @@
+package messagequeue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+)
+
+const priorityEscalationInterval = time.Minute
+
+// ListenPriorityEscalation pings the escalation role of urgent tickets that have had no staff response within the
+// guild's escalation time. Whether staff have responded is decided by the first response time data, which is
+// recorded when a staff member first sends a message in the ticket.
+func ListenPriorityEscalation() {
+	ticker := time.NewTicker(priorityEscalationInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		runPriorityEscalation()
+	}
+}
+
+func runPriorityEscalation() {
+	ctx, cancel := context.WithTimeout(context.Background(), priorityEscalationInterval)
+	defer cancel()
+
+	locked, err := redis.TakeJobLock(ctx, "priority_escalation", priorityEscalationInterval-time.Second*5)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Another worker is running the job
+	if !locked {
+		return
+	}
+
+	escalations, err := dbclient.Local.TicketPriorities.GetPendingEscalations(ctx)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	for _, escalation := range escalations {
+		if err := escalate(ctx, escalation); err != nil {
+			sentry.Error(err)
+		}
+	}
+}
+
+func escalate(ctx context.Context, escalation localdb.PendingEscalation) error {
+	// Mark first, so that a failure to send the message does not cause the role to be pinged every minute
+	ok, err := dbclient.Local.TicketPriorities.MarkEscalated(ctx, escalation.GuildId, escalation.TicketId)
+	if err != nil || !ok {
+		return err
+	}
+
+	ticket, err := dbclient.Client.Tickets.Get(ctx, escalation.TicketId, escalation.GuildId)
+	if err != nil {
+		return err
+	}
+
+	worker, err := buildContext(ctx, ticket, cache.Client)
+	if err != nil {
+		return err
+	}
+
+	settings, err := dbclient.Local.PrioritySettings.Get(ctx, escalation.GuildId)
+	if err != nil {
+		return err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, escalation.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, customisation.Red, escalation.GuildId)
+	if err != nil {
+		return err
+	}
+
+	title := i18n.GetMessageFromGuild(escalation.GuildId, i18n.TitleEscalation)
+	content := i18n.GetMessageFromGuild(escalation.GuildId, i18n.MessagePriorityEscalation, settings.EscalationMinutes)
+
+	data := rest.CreateMessageData{
+		Content: fmt.Sprintf("<@&%d>", escalation.RoleId),
+		Embeds:  utils.Embeds(utils.BuildEmbedRaw(colour, title, content, nil, premiumTier)),
+		AllowedMentions: message.AllowedMention{
+			Roles: []uint64{escalation.RoleId},
+		},
+	}
+
+	_, err = worker.CreateMessageComplex(escalation.ChannelId, data)
+	return err
+}
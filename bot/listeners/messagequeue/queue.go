@@ -0,0 +1,62 @@
+package messagequeue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/shutdown"
+)
+
+// Must match the lists used by the publishers in TicketsBot/common
+const (
+	queueTicketClose       = "tickets:close"
+	queueAutoClose         = "tickets:autoclose"
+	queueCloseRequestTimer = "tickets:closerequest:timer"
+)
+
+const queuePollTimeout = time.Second * 5
+
+// listenQueue pops payloads from the list until the worker begins draining, then closes ch. Unlike the listeners in
+// TicketsBot/common, the list is polled with a timeout, so that no payload is popped and then dropped during shutdown.
+func listenQueue[T any](key string, ch chan<- T) {
+	defer close(ch)
+
+	for !shutdown.IsDraining() {
+		res, err := redis.Client.BLPop(context.Background(), queuePollTimeout, key).Result()
+		if err != nil {
+			if !errors.Is(err, redis.ErrNil) {
+				sentry.Error(err)
+				time.Sleep(time.Second)
+			}
+
+			continue
+		}
+
+		// res = [list_name, content]
+		if len(res) < 2 {
+			continue
+		}
+
+		var data T
+		if err := json.Unmarshal([]byte(res[1]), &data); err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		ch <- data
+	}
+}
+
+// nextTick waits for the ticker to fire, returning false if the worker begins draining first
+func nextTick(ticker *time.Ticker) bool {
+	select {
+	case <-ticker.C:
+		return true
+	case <-shutdown.Draining():
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/cache"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/constants"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/shutdown"
+	"github.com/TicketsBot/worker/bot/utils"
+)
+
+const (
+	scheduledCloseInterval = time.Second * 30
+	// scheduledCloseLease is how long a worker has to perform a close before another worker retries it
+	scheduledCloseLease = time.Minute * 5
+)
+
+// ListenScheduledClose closes tickets that were scheduled to be closed with /close in:, once they are due. The
+// schedule is stored in the database, so closes that fall due while no worker is running, or that fail or are
+// interrupted by a restart, are performed later.
+func ListenScheduledClose() {
+	ticker := time.NewTicker(scheduledCloseInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		due, err := dbclient.Local.ScheduledCloses.ClaimDue(ctx, scheduledCloseLease)
+		cancel()
+
+		if err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		for _, scheduled := range due {
+			scheduled := scheduled
+			shutdown.Go(func() { performScheduledClose(scheduled) })
+		}
+	}
+}
+
+func performScheduledClose(scheduled localdb.ScheduledClose) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutCloseTicket)
+	defer cancel()
+
+	if err := closeScheduledTicket(ctx, scheduled); err != nil {
+		sentry.Error(err)
+		return // Retried once the lease expires
+	}
+
+	if err := dbclient.Local.ScheduledCloses.Complete(ctx, scheduled); err != nil {
+		sentry.Error(err)
+	}
+}
+
+func closeScheduledTicket(ctx context.Context, scheduled localdb.ScheduledClose) error {
+	// get ticket
+	ticket, err := dbclient.Client.Tickets.Get(ctx, scheduled.TicketId, scheduled.GuildId)
+	if err != nil {
+		return err
+	}
+
+	// The ticket may have been closed some other way in the meantime
+	if !ticket.Open || ticket.ChannelId == nil {
+		return nil
+	}
+
+	// get worker
+	worker, err := buildContext(ctx, ticket, cache.Client)
+	if err != nil {
+		return err
+	}
+
+	// get premium status
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	cc := cmdcontext.NewAutoCloseContext(ctx, worker, ticket.GuildId, *ticket.ChannelId, scheduled.UserId, premiumTier)
+	return logic.CloseTicket(ctx, cc, scheduled.Reason, true)
+}
@@ -0,0 +1,203 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/rest"
+)
+
+const slaEvaluationInterval = time.Minute
+
+var slaKindNames = map[sla.Kind]i18n.MessageId{
+	sla.KindFirstResponse: i18n.MessageSlaKindFirstResponse,
+	sla.KindNextResponse:  i18n.MessageSlaKindNextResponse,
+	sla.KindResolution:    i18n.MessageSlaKindResolution,
+}
+
+// ListenSlaEvaluator checks the SLA clocks of every tracked ticket, posting warnings before a target is breached and
+// recording breaches.
+func ListenSlaEvaluator() {
+	ticker := time.NewTicker(slaEvaluationInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		runSlaEvaluation()
+	}
+}
+
+func runSlaEvaluation() {
+	ctx, cancel := context.WithTimeout(context.Background(), slaEvaluationInterval)
+	defer cancel()
+
+	locked, err := redis.TakeJobLock(ctx, "sla_evaluation", slaEvaluationInterval-time.Second*5)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Another worker is running the job
+	if !locked {
+		return
+	}
+
+	tickets, err := dbclient.Local.TicketSla.GetTracked(ctx)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	now := time.Now()
+	calendars := make(map[uint64]*sla.BusinessHours)
+
+	for _, ticket := range tickets {
+		var hours *sla.BusinessHours
+		if ticket.Policy.BusinessHours {
+			hours, err = getBusinessHours(ctx, calendars, ticket.GuildId)
+			if err != nil {
+				sentry.Error(err)
+				continue
+			}
+		}
+
+		for _, kind := range sla.Kinds {
+			if err := evaluateSlaClock(ctx, ticket, kind, hours, now); err != nil {
+				sentry.Error(err)
+			}
+		}
+	}
+}
+
+func evaluateSlaClock(ctx context.Context, ticket localdb.TrackedTicket, kind sla.Kind, hours *sla.BusinessHours, now time.Time) error {
+	target := ticket.Policy.Target(kind)
+	if target == nil {
+		return nil
+	}
+
+	var start time.Time
+	switch kind {
+	case sla.KindFirstResponse:
+		if ticket.Responded {
+			return nil
+		}
+
+		start = ticket.OpenTime
+	case sla.KindNextResponse:
+		if !ticket.Responded || ticket.AwaitingSince == nil {
+			return nil
+		}
+
+		start = *ticket.AwaitingSince
+	case sla.KindResolution:
+		start = ticket.OpenTime
+	}
+
+	warning, breach, err := sla.Deadlines(start, *target, ticket.Policy.WarningPercent, hours)
+	if err != nil {
+		return err
+	}
+
+	if now.After(breach) {
+		return recordSlaEvent(ctx, ticket, kind, sla.StageBreach, start, breach)
+	} else if now.After(warning) {
+		return recordSlaEvent(ctx, ticket, kind, sla.StageWarning, start, breach)
+	}
+
+	return nil
+}
+
+func recordSlaEvent(ctx context.Context, ticket localdb.TrackedTicket, kind sla.Kind, stage sla.Stage, start, breach time.Time) error {
+	recorded, err := dbclient.Local.SlaEvents.Record(ctx, ticket.GuildId, ticket.TicketId, kind, stage, start)
+	if err != nil || !recorded {
+		return err
+	}
+
+	if stage == sla.StageBreach {
+		prometheus.SlaBreaches.WithLabelValues(string(kind)).Inc()
+	} else {
+		prometheus.SlaWarnings.WithLabelValues(string(kind)).Inc()
+	}
+
+	dbTicket, err := dbclient.Client.Tickets.Get(ctx, ticket.TicketId, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	worker, err := buildContext(ctx, dbTicket, cache.Client)
+	if err != nil {
+		return err
+	}
+
+	kindName := i18n.GetMessageFromGuild(ticket.GuildId, slaKindNames[kind])
+
+	// Breaches are only reported to staff, warnings are also posted in the ticket so that whoever is looking at it
+	// knows to respond
+	if stage == sla.StageWarning {
+		if err := sendSlaNotice(ctx, worker, ticket.GuildId, ticket.ChannelId, customisation.Orange, i18n.MessageSlaWarning, kindName, breach.Unix()); err != nil {
+			return err
+		}
+	}
+
+	if ticket.Policy.StaffChannelId == nil {
+		return nil
+	}
+
+	if stage == sla.StageBreach {
+		return sendSlaNotice(ctx, worker, ticket.GuildId, *ticket.Policy.StaffChannelId, customisation.Red, i18n.MessageSlaBreachStaff, kindName, ticket.ChannelId)
+	} else {
+		return sendSlaNotice(ctx, worker, ticket.GuildId, *ticket.Policy.StaffChannelId, customisation.Orange, i18n.MessageSlaWarningStaff, kindName, ticket.ChannelId, breach.Unix())
+	}
+}
+
+func sendSlaNotice(ctx context.Context, worker *worker.Context, guildId, channelId uint64, colour customisation.Colour, messageId i18n.MessageId, format ...interface{}) error {
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	colourCode, err := utils.GetColourForGuild(ctx, worker, colour, guildId)
+	if err != nil {
+		return err
+	}
+
+	title := i18n.GetMessageFromGuild(guildId, i18n.TitleSla)
+	content := i18n.GetMessageFromGuild(guildId, messageId, format...)
+
+	data := rest.CreateMessageData{
+		Embeds: utils.Embeds(utils.BuildEmbedRaw(colourCode, title, content, nil, premiumTier)),
+	}
+
+	_, err = worker.CreateMessageComplex(channelId, data)
+	return err
+}
+
+func getBusinessHours(ctx context.Context, calendars map[uint64]*sla.BusinessHours, guildId uint64) (*sla.BusinessHours, error) {
+	if hours, ok := calendars[guildId]; ok {
+		return hours, nil
+	}
+
+	hours, ok, err := dbclient.Local.BusinessHours.Get(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guilds that have not configured business hours are treated as always open
+	if !ok {
+		calendars[guildId] = nil
+		return nil, nil
+	}
+
+	calendars[guildId] = &hours
+	return &hours, nil
+}
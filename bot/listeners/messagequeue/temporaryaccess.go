@@ -0,0 +1,84 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/cache"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/shutdown"
+	"github.com/TicketsBot/worker/bot/utils"
+)
+
+const (
+	temporaryAccessInterval = time.Second * 30
+	// temporaryAccessLease is how long a worker has to remove a user before another worker retries it
+	temporaryAccessLease = time.Minute * 5
+)
+
+// ListenTemporaryAccess removes users that were added to tickets with /add duration:, once their access expires. The
+// expiry is stored in the database, so access that expires while no worker is running, or whose removal fails or is
+// interrupted by a restart, is removed later.
+func ListenTemporaryAccess() {
+	ticker := time.NewTicker(temporaryAccessInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		due, err := dbclient.Local.TemporaryAccess.ClaimDue(ctx, temporaryAccessLease)
+		cancel()
+
+		if err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		for _, access := range due {
+			access := access
+			shutdown.Go(func() { expireTemporaryAccess(access) })
+		}
+	}
+}
+
+func expireTemporaryAccess(access localdb.TemporaryAccess) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	ticket, err := dbclient.Client.Tickets.Get(ctx, access.TicketId, access.GuildId)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Closed tickets can no longer be accessed anyway
+	if !ticket.Open || ticket.ChannelId == nil {
+		if err := dbclient.Local.TemporaryAccess.Delete(ctx, access.GuildId, access.TicketId, access.UserId); err != nil {
+			sentry.Error(err)
+		}
+
+		return
+	}
+
+	worker, err := buildContext(ctx, ticket, cache.Client)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// The expiry is removed by ExpireTemporaryAccess once the user has been removed, otherwise it is retried once the
+	// lease expires
+	cc := cmdcontext.NewAutoCloseContext(ctx, worker, ticket.GuildId, *ticket.ChannelId, access.AddedBy, premiumTier)
+	if err := logic.ExpireTemporaryAccess(ctx, cc, ticket, access.UserId); err != nil {
+		sentry.Error(err)
+	}
+}
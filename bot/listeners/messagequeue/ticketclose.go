@@ -11,7 +11,7 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/errorcontext"
 	"github.com/TicketsBot/worker/bot/logic"
-	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 )
@@ -19,12 +19,12 @@ import (
 // TODO: Make this good
 func ListenTicketClose() {
 	ch := make(chan closerelay.TicketClose)
-	go closerelay.Listen(redis.Client, ch)
+	go listenQueue(queueTicketClose, ch)
 
 	for payload := range ch {
 		payload := payload
 
-		go func() {
+		shutdown.Go(func() {
 			ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutCloseTicket)
 			defer cancel()
 
@@ -104,6 +104,6 @@ func ListenTicketClose() {
 			// ticket.ChannelId cannot be nil
 			cc := cmdcontext.NewDashboardContext(ctx, workerCtx, ticket.GuildId, *ticket.ChannelId, payload.UserId, premiumTier)
 			logic.CloseTicket(ctx, &cc, &payload.Reason, false)
-		}()
+		})
 	}
 }
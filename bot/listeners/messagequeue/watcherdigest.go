@@ -0,0 +1,204 @@
+package messagequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/rest"
+)
+
+// Activity is collected for this long before it is sent, so that a busy ticket results in one DM rather than many
+const watcherDigestInterval = time.Minute * 5
+
+// Events that still could not be sent after this long are dropped
+const watchEventExpiry = time.Hour * 24
+
+type digestKey struct {
+	guildId, userId uint64
+}
+
+// ListenWatcherDigest sends each ticket watcher a DM summarising the activity on the tickets they watch
+func ListenWatcherDigest() {
+	ticker := time.NewTicker(watcherDigestInterval)
+	defer ticker.Stop()
+
+	for nextTick(ticker) {
+		runWatcherDigest()
+	}
+}
+
+func runWatcherDigest() {
+	// Stop before the lock expires, so that another worker cannot send the same events while they are being sent here
+	ctx, cancel := context.WithTimeout(context.Background(), watcherDigestInterval-time.Second*10)
+	defer cancel()
+
+	locked, err := redis.TakeJobLock(ctx, "watcher_digest", watcherDigestInterval-time.Second*5)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	// Another worker is running the job
+	if !locked {
+		return
+	}
+
+	if err := dbclient.Local.WatchEvents.DeleteBefore(ctx, time.Now().Add(-watchEventExpiry)); err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	events, err := dbclient.Local.WatchEvents.GetAll(ctx)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	var order []digestKey
+	digests := make(map[digestKey][]localdb.WatchEvent)
+	for _, event := range events {
+		key := digestKey{guildId: event.GuildId, userId: event.UserId}
+		if _, ok := digests[key]; !ok {
+			order = append(order, key)
+		}
+
+		digests[key] = append(digests[key], event)
+	}
+
+	workers := make(map[uint64]*worker.Context)
+	for _, key := range order {
+		worker, ok := workers[key.guildId]
+		if !ok {
+			worker, err = buildGuildContext(ctx, key.guildId, cache.Client)
+			if err != nil {
+				sentry.Error(err)
+				continue
+			}
+
+			workers[key.guildId] = worker
+		}
+
+		// Events that fail to send are kept, and retried in the next digest
+		if err := sendWatcherDigest(ctx, worker, key, digests[key]); err != nil {
+			sentry.Error(err)
+			continue
+		}
+
+		ids := make([]int, len(digests[key]))
+		for i, event := range digests[key] {
+			ids[i] = event.Id
+		}
+
+		if err := dbclient.Local.WatchEvents.Delete(ctx, ids); err != nil {
+			sentry.Error(err)
+		}
+	}
+}
+
+func sendWatcherDigest(ctx context.Context, worker *worker.Context, key digestKey, events []localdb.WatchEvent) error {
+	channelId, ok, err := logic.OpenDM(worker, key.userId)
+	if err != nil || !ok {
+		return err
+	}
+
+	// Group the events by ticket, keeping the order in which tickets first had activity
+	var ticketIds []int
+	byTicket := make(map[int][]localdb.WatchEvent)
+	for _, event := range events {
+		if _, ok := byTicket[event.TicketId]; !ok {
+			ticketIds = append(ticketIds, event.TicketId)
+		}
+
+		byTicket[event.TicketId] = append(byTicket[event.TicketId], event)
+	}
+
+	var content string
+	for _, ticketId := range ticketIds {
+		content += fmt.Sprintf("**%s**\n", i18n.GetMessageFromGuild(key.guildId, i18n.MessageWatchDigestTicket, ticketId))
+		for _, line := range formatWatchEvents(key.guildId, byTicket[ticketId]) {
+			content += fmt.Sprintf("• %s\n", line)
+		}
+		content += "\n"
+	}
+	content = utils.StringMax(strings.TrimSuffix(content, "\n\n"), 4000, "...")
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, key.guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, customisation.Blue, key.guildId)
+	if err != nil {
+		return err
+	}
+
+	guild, err := worker.GetGuild(key.guildId)
+	if err != nil {
+		return err
+	}
+
+	title := i18n.GetMessageFromGuild(key.guildId, i18n.TitleWatch)
+	content = i18n.GetMessageFromGuild(key.guildId, i18n.MessageWatchDigest, guild.Name) + "\n\n" + content
+
+	data := rest.CreateMessageData{
+		Embeds: utils.Embeds(utils.BuildEmbedRaw(colour, title, content, nil, premiumTier)),
+	}
+
+	_, err = worker.CreateMessageComplex(channelId, data)
+	return err
+}
+
+// formatWatchEvents describes a ticket's activity, with consecutive opener messages summarised as a single line
+func formatWatchEvents(guildId uint64, events []localdb.WatchEvent) []string {
+	var lines []string
+	var messages int
+
+	flushMessages := func() {
+		if messages > 0 {
+			lines = append(lines, i18n.GetMessageFromGuild(guildId, i18n.MessageWatchEventMessages, messages))
+			messages = 0
+		}
+	}
+
+	for _, event := range events {
+		if event.Kind == localdb.WatchEventMessage {
+			messages++
+			continue
+		}
+
+		flushMessages()
+
+		var data string
+		if event.Data != nil {
+			data = *event.Data
+		}
+
+		switch event.Kind {
+		case localdb.WatchEventStatus:
+			lines = append(lines, i18n.GetMessageFromGuild(guildId, i18n.MessageWatchEventStatus, strings.ToLower(data)))
+		case localdb.WatchEventClaim:
+			lines = append(lines, i18n.GetMessageFromGuild(guildId, i18n.MessageWatchEventClaim, event.ActorId))
+		case localdb.WatchEventTransfer:
+			targetId, _ := strconv.ParseUint(data, 10, 64)
+			lines = append(lines, i18n.GetMessageFromGuild(guildId, i18n.MessageWatchEventTransfer, event.ActorId, targetId))
+		case localdb.WatchEventClose:
+			lines = append(lines, i18n.GetMessageFromGuild(guildId, i18n.MessageWatchEventClose, event.ActorId))
+		}
+	}
+
+	flushMessages()
+	return lines
+}
@@ -0,0 +1,54 @@
+package listeners
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/gateway/payloads/events"
+)
+
+// onDirectMessage relays the message to the author's modmail ticket, or asks them which guild to open a ticket in if
+// they do not have one open
+func onDirectMessage(worker *worker.Context, e events.MessageCreate) {
+	// Attachments are downloaded and uploaded again, so allow longer than other listeners
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	session, ok, err := dbclient.Local.ModmailSessions.Get(ctx, worker.BotId, e.Author.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if ok {
+		relayed, err := logic.RelayModmailMessage(ctx, worker, session, e.Message)
+		if err != nil {
+			sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+			return
+		}
+
+		if relayed {
+			return
+		}
+	}
+
+	// The user has no open ticket, so offer to open one
+	data, ok, err := logic.BuildModmailPrompt(ctx, worker, e.Author.Id, e.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	if _, err := worker.CreateMessageComplex(e.ChannelId, data); err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+	}
+}
@@ -7,6 +7,10 @@ func init() {
 	GuildMemberRemoveListeners = append(GuildMemberRemoveListeners, OnMemberLeave)
 	GuildMemberUpdateListeners = append(GuildMemberUpdateListeners, OnMemberUpdate)
 	MessageCreateListeners = append(MessageCreateListeners, OnMessage)
+	MessageCreateListeners = append(MessageCreateListeners, OnMessageJournal)
+	MessageUpdateListeners = append(MessageUpdateListeners, OnMessageUpdateJournal)
+	MessageDeleteListeners = append(MessageDeleteListeners, OnMessageDeleteJournal)
+	MessageDeleteBulkListeners = append(MessageDeleteBulkListeners, OnMessageDeleteBulkJournal)
 	GuildRoleDeleteListeners = append(GuildRoleDeleteListeners, OnRoleDelete)
 	ThreadMembersUpdateListeners = append(ThreadMembersUpdateListeners, OnThreadMembersUpdate)
 	ThreadUpdateListeners = append(ThreadUpdateListeners, OnThreadUpdate)
@@ -0,0 +1,94 @@
+package listeners
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/gateway/payloads/events"
+)
+
+// The listeners in this file feed the transcript journal, so that closing a ticket does not have to page through
+// the channel history.
+
+func OnMessageJournal(worker *worker.Context, e events.MessageCreate) {
+	if e.GuildId == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	ticket, isTicket, err := getTicket(ctx, e.ChannelId)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	// Messages sent after the ticket is closed, such as the close message in threads, are not part of the transcript
+	if !isTicket || !ticket.Open {
+		return
+	}
+
+	if err := dbclient.Local.TranscriptJournal.Append(ctx, e.GuildId, ticket.Id, e.Message); err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+	}
+}
+
+func OnMessageUpdateJournal(worker *worker.Context, e events.MessageUpdate) {
+	// Discord also sends updates when embeds are resolved, which are partial and are not edits
+	if e.GuildId == 0 || e.EditedTimestamp == nil || e.Author.Id == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	ticket, isTicket, err := getTicket(ctx, e.ChannelId)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	if !isTicket || !ticket.Open {
+		return
+	}
+
+	if err := dbclient.Local.TranscriptJournal.Append(ctx, e.GuildId, ticket.Id, e.Message); err != nil {
+		sentry.Error(err)
+	}
+}
+
+func OnMessageDeleteJournal(worker *worker.Context, e events.MessageDelete) {
+	markJournalDeleted(e.GuildId, e.ChannelId, []uint64{e.Id})
+}
+
+func OnMessageDeleteBulkJournal(worker *worker.Context, e events.MessageDeleteBulk) {
+	markJournalDeleted(e.GuildId, e.ChannelId, e.Id)
+}
+
+func markJournalDeleted(guildId, channelId uint64, messageIds []uint64) {
+	if guildId == 0 || len(messageIds) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	ticket, isTicket, err := getTicket(ctx, channelId)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	if !isTicket || !ticket.Open {
+		return
+	}
+
+	if err := dbclient.Local.TranscriptJournal.MarkDeleted(ctx, guildId, ticket.Id, messageIds); err != nil {
+		sentry.Error(err)
+	}
+}
@@ -0,0 +1,99 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// AdoptableCategories are categories whose channels may be closed as tickets, even though they were not opened by
+// the bot. The channel is registered as a ticket at close time.
+type AdoptableCategories struct {
+	*pgxpool.Pool
+}
+
+func newAdoptableCategories(db *pgxpool.Pool) *AdoptableCategories {
+	return &AdoptableCategories{
+		db,
+	}
+}
+
+func (c AdoptableCategories) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS adoptable_categories(
+	"guild_id" int8 NOT NULL,
+	"category_id" int8 NOT NULL,
+	PRIMARY KEY("guild_id", "category_id")
+);
+CREATE INDEX IF NOT EXISTS adoptable_categories_guild_id ON adoptable_categories("guild_id");`
+}
+
+func (c *AdoptableCategories) GetAll(ctx context.Context, guildId uint64) ([]uint64, error) {
+	query := `SELECT "category_id" FROM adoptable_categories WHERE "guild_id" = $1;`
+
+	rows, err := c.Query(ctx, query, guildId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []uint64
+	for rows.Next() {
+		var categoryId uint64
+		if err := rows.Scan(&categoryId); err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, categoryId)
+	}
+
+	return categories, rows.Err()
+}
+
+func (c *AdoptableCategories) IsAdoptable(ctx context.Context, guildId, categoryId uint64) (bool, error) {
+	query := `SELECT 1 FROM adoptable_categories WHERE "guild_id" = $1 AND "category_id" = $2;`
+
+	var exists int
+	if err := c.QueryRow(ctx, query, guildId, categoryId).Scan(&exists); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *AdoptableCategories) GetCount(ctx context.Context, guildId uint64) (count int, err error) {
+	query := `SELECT COUNT(*) FROM adoptable_categories WHERE "guild_id" = $1;`
+	err = c.QueryRow(ctx, query, guildId).Scan(&count)
+	return
+}
+
+func (c *AdoptableCategories) Add(ctx context.Context, guildId, categoryId uint64) (err error) {
+	query := `
+INSERT INTO adoptable_categories("guild_id", "category_id")
+VALUES($1, $2)
+ON CONFLICT("guild_id", "category_id") DO NOTHING;`
+
+	_, err = c.Exec(ctx, query, guildId, categoryId)
+	return
+}
+
+// Remove returns false if the category was not adoptable
+func (c *AdoptableCategories) Remove(ctx context.Context, guildId, categoryId uint64) (bool, error) {
+	query := `DELETE FROM adoptable_categories WHERE "guild_id" = $1 AND "category_id" = $2;`
+
+	res, err := c.Exec(ctx, query, guildId, categoryId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (c *AdoptableCategories) DeleteByCategory(ctx context.Context, categoryId uint64) (err error) {
+	_, err = c.Exec(ctx, `DELETE FROM adoptable_categories WHERE "category_id" = $1;`, categoryId)
+	return
+}
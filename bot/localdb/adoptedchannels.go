@@ -0,0 +1,83 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// AdoptedChannel records a channel that was not created by the bot, but was registered as a ticket after the fact.
+type AdoptedChannel struct {
+	GuildId    uint64
+	TicketId   int
+	ChannelId  uint64
+	CategoryId *uint64
+	AdoptedBy  uint64
+	AdoptedAt  time.Time
+}
+
+type AdoptedChannels struct {
+	*pgxpool.Pool
+}
+
+func newAdoptedChannels(db *pgxpool.Pool) *AdoptedChannels {
+	return &AdoptedChannels{
+		db,
+	}
+}
+
+func (c AdoptedChannels) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS adopted_channels(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"category_id" int8,
+	"adopted_by" int8 NOT NULL,
+	"adopted_at" timestamptz NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);`
+}
+
+func (c *AdoptedChannels) Get(ctx context.Context, guildId uint64, ticketId int) (AdoptedChannel, bool, error) {
+	query := `
+SELECT "guild_id", "ticket_id", "channel_id", "category_id", "adopted_by", "adopted_at"
+FROM adopted_channels
+WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	var adopted AdoptedChannel
+	err := c.QueryRow(ctx, query, guildId, ticketId).Scan(
+		&adopted.GuildId,
+		&adopted.TicketId,
+		&adopted.ChannelId,
+		&adopted.CategoryId,
+		&adopted.AdoptedBy,
+		&adopted.AdoptedAt,
+	)
+
+	if err == nil {
+		return adopted, true, nil
+	} else if err == pgx.ErrNoRows {
+		return adopted, false, nil
+	} else {
+		return adopted, false, err
+	}
+}
+
+func (c *AdoptedChannels) Create(ctx context.Context, adopted AdoptedChannel) (err error) {
+	query := `
+INSERT INTO adopted_channels("guild_id", "ticket_id", "channel_id", "category_id", "adopted_by", "adopted_at")
+VALUES($1, $2, $3, $4, $5, $6);`
+
+	_, err = c.Exec(ctx, query,
+		adopted.GuildId,
+		adopted.TicketId,
+		adopted.ChannelId,
+		adopted.CategoryId,
+		adopted.AdoptedBy,
+		adopted.AdoptedAt,
+	)
+	return
+}
@@ -0,0 +1,165 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// AssignStrategy is how a staff member is chosen to be assigned to a newly opened ticket
+type AssignStrategy string
+
+const (
+	// AssignRoundRobin takes turns between the eligible staff members
+	AssignRoundRobin AssignStrategy = "round_robin"
+	// AssignLeastLoaded chooses the eligible staff member with the fewest open claimed tickets, taking turns on a tie
+	AssignLeastLoaded AssignStrategy = "least_loaded"
+)
+
+var AssignStrategies = []AssignStrategy{AssignRoundRobin, AssignLeastLoaded}
+
+func ParseAssignStrategy(raw string) (AssignStrategy, bool) {
+	for _, strategy := range AssignStrategies {
+		if string(strategy) == raw {
+			return strategy, true
+		}
+	}
+
+	return "", false
+}
+
+type AutoAssignSettings struct {
+	PanelId  int
+	GuildId  uint64
+	Strategy AssignStrategy
+	// OnCallOnly restricts the eligible staff members to those who are currently on call
+	OnCallOnly bool
+	// LastAssignedId is the staff member who was assigned most recently, used to take turns
+	LastAssignedId *uint64
+}
+
+// AutoAssign stores the auto assignment settings of each panel. Tickets opened from panels without settings are not
+// assigned automatically.
+type AutoAssign struct {
+	*pgxpool.Pool
+}
+
+func newAutoAssign(db *pgxpool.Pool) *AutoAssign {
+	return &AutoAssign{
+		db,
+	}
+}
+
+func (a AutoAssign) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS auto_assign(
+	"panel_id" int4 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"strategy" varchar(16) NOT NULL,
+	"on_call_only" bool NOT NULL DEFAULT 'f',
+	"last_assigned_id" int8,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);`
+}
+
+func (a *AutoAssign) Get(ctx context.Context, panelId int) (AutoAssignSettings, bool, error) {
+	query := `
+SELECT "panel_id", "guild_id", "strategy", "on_call_only", "last_assigned_id"
+FROM auto_assign
+WHERE "panel_id" = $1;`
+
+	var settings AutoAssignSettings
+	if err := a.QueryRow(ctx, query, panelId).Scan(&settings.PanelId, &settings.GuildId, &settings.Strategy, &settings.OnCallOnly, &settings.LastAssignedId); err != nil {
+		if err == pgx.ErrNoRows {
+			return AutoAssignSettings{}, false, nil
+		}
+
+		return AutoAssignSettings{}, false, err
+	}
+
+	return settings, true, nil
+}
+
+// Set does not modify the last assigned staff member, so that turns carry on from where they were
+func (a *AutoAssign) Set(ctx context.Context, settings AutoAssignSettings) (err error) {
+	query := `
+INSERT INTO auto_assign("panel_id", "guild_id", "strategy", "on_call_only")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("panel_id") DO UPDATE SET "strategy" = $3, "on_call_only" = $4;`
+
+	_, err = a.Exec(ctx, query, settings.PanelId, settings.GuildId, settings.Strategy, settings.OnCallOnly)
+	return
+}
+
+func (a *AutoAssign) Delete(ctx context.Context, panelId int) (err error) {
+	_, err = a.Exec(ctx, `DELETE FROM auto_assign WHERE "panel_id" = $1;`, panelId)
+	return
+}
+
+func (a *AutoAssign) SetLastAssigned(ctx context.Context, panelId int, userId uint64) (err error) {
+	_, err = a.Exec(ctx, `UPDATE auto_assign SET "last_assigned_id" = $2 WHERE "panel_id" = $1;`, panelId, userId)
+	return
+}
+
+// GetOpenClaimCounts returns the number of open tickets claimed by each of the given users. Users without any open
+// claimed tickets are omitted.
+func (a *AutoAssign) GetOpenClaimCounts(ctx context.Context, guildId uint64, userIds []uint64) (map[uint64]int, error) {
+	query := `
+SELECT ticket_claims.user_id, COUNT(*)
+FROM ticket_claims
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_claims.guild_id AND tickets.id = ticket_claims.ticket_id
+WHERE ticket_claims.guild_id = $1 AND ticket_claims.user_id = ANY($2) AND tickets.open
+GROUP BY ticket_claims.user_id;`
+
+	rows, err := a.Query(ctx, query, guildId, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uint64]int)
+	for rows.Next() {
+		var userId uint64
+		var count int
+		if err := rows.Scan(&userId, &count); err != nil {
+			return nil, err
+		}
+
+		counts[userId] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetAssignedTickets returns the IDs of the open tickets claimed by the user, that were opened from panels with auto
+// assignment enabled
+func (a *AutoAssign) GetAssignedTickets(ctx context.Context, guildId, userId uint64) ([]int, error) {
+	query := `
+SELECT tickets.id
+FROM ticket_claims
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_claims.guild_id AND tickets.id = ticket_claims.ticket_id
+INNER JOIN auto_assign
+	ON auto_assign.panel_id = tickets.panel_id
+WHERE ticket_claims.guild_id = $1 AND ticket_claims.user_id = $2 AND tickets.open AND tickets.channel_id IS NOT NULL;`
+
+	rows, err := a.Query(ctx, query, guildId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ticketIds []int
+	for rows.Next() {
+		var ticketId int
+		if err := rows.Scan(&ticketId); err != nil {
+			return nil, err
+		}
+
+		ticketIds = append(ticketIds, ticketId)
+	}
+
+	return ticketIds, rows.Err()
+}
@@ -0,0 +1,135 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Database holds the tables owned by the worker itself, rather than by the shared database module. They live in the
+// same Postgres database, and may reference tables created by the shared module.
+type Database struct {
+	pool                *pgxpool.Pool
+	AdoptableCategories *AdoptableCategories
+	AdoptedChannels     *AdoptedChannels
+	TranscriptSettings  *TranscriptSettingsTable
+	TranscriptJournal   *TranscriptJournal
+	OpenRateLimits      *OpenRateLimits
+	TicketPriorities    *TicketPriorities
+	PrioritySettings    *PrioritySettingsTable
+	PanelPriorities     *PanelPriorities
+	SlaPolicies         *SlaPolicies
+	BusinessHours       *BusinessHoursTable
+	TicketSla           *TicketSla
+	SlaEvents           *SlaEvents
+	ScheduledCloses     *ScheduledCloses
+	TicketLinks         *TicketLinks
+	AutoAssign          *AutoAssign
+	OnCallShifts        *OnCallShifts
+	StaffAway           *StaffAway
+	TicketWatchers      *TicketWatchers
+	WatchEvents         *WatchEvents
+	FormAnswers         *FormAnswers
+	TicketQueries       *TicketQueries
+	HistorySettings     *HistorySettingsTable
+	Labels              *Labels
+	TicketLabels        *TicketLabels
+	TemporaryAccess     *TemporaryAccessTable
+	TicketNotes         *TicketNotes
+	ModmailSettings     *ModmailSettingsTable
+	ModmailSessions     *ModmailSessions
+}
+
+type Table interface {
+	Schema() string
+}
+
+// Arbitrary key for pg_advisory_xact_lock, so that only one worker creates the tables at a time
+const schemaLockKey = 0x7469636b6574
+
+func NewDatabase(pool *pgxpool.Pool) *Database {
+	return &Database{
+		pool:                pool,
+		AdoptableCategories: newAdoptableCategories(pool),
+		AdoptedChannels:     newAdoptedChannels(pool),
+		TranscriptSettings:  newTranscriptSettingsTable(pool),
+		TranscriptJournal:   newTranscriptJournal(pool),
+		OpenRateLimits:      newOpenRateLimits(pool),
+		TicketPriorities:    newTicketPriorities(pool),
+		PrioritySettings:    newPrioritySettingsTable(pool),
+		PanelPriorities:     newPanelPriorities(pool),
+		SlaPolicies:         newSlaPolicies(pool),
+		BusinessHours:       newBusinessHoursTable(pool),
+		TicketSla:           newTicketSla(pool),
+		SlaEvents:           newSlaEvents(pool),
+		ScheduledCloses:     newScheduledCloses(pool),
+		TicketLinks:         newTicketLinks(pool),
+		AutoAssign:          newAutoAssign(pool),
+		OnCallShifts:        newOnCallShifts(pool),
+		StaffAway:           newStaffAway(pool),
+		TicketWatchers:      newTicketWatchers(pool),
+		WatchEvents:         newWatchEvents(pool),
+		FormAnswers:         newFormAnswers(pool),
+		TicketQueries:       newTicketQueries(pool),
+		HistorySettings:     newHistorySettingsTable(pool),
+		Labels:              newLabels(pool),
+		TicketLabels:        newTicketLabels(pool),
+		TemporaryAccess:     newTemporaryAccessTable(pool),
+		TicketNotes:         newTicketNotes(pool),
+		ModmailSettings:     newModmailSettingsTable(pool),
+		ModmailSessions:     newModmailSessions(pool),
+	}
+}
+
+func (d *Database) CreateTables(ctx context.Context) error {
+	return create(ctx, d.pool,
+		d.AdoptableCategories,
+		d.AdoptedChannels,
+		d.TranscriptSettings,
+		d.TranscriptJournal,
+		d.OpenRateLimits,
+		d.TicketPriorities,
+		d.PrioritySettings,
+		d.PanelPriorities,
+		d.SlaPolicies,
+		d.BusinessHours,
+		d.TicketSla,
+		d.SlaEvents,
+		d.ScheduledCloses,
+		d.TicketLinks,
+		d.AutoAssign,
+		d.OnCallShifts,
+		d.StaffAway,
+		d.TicketWatchers,
+		d.WatchEvents,
+		d.FormAnswers,
+		d.HistorySettings,
+		d.Labels,
+		d.TicketLabels,
+		d.TemporaryAccess,
+		d.TicketNotes,
+		d.ModmailSettings,
+		d.ModmailSessions,
+	)
+}
+
+func create(ctx context.Context, pool *pgxpool.Pool, tables ...Table) error {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, schemaLockKey); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, table.Schema()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
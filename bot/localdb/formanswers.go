@@ -0,0 +1,67 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// FormAnswers stores the answers given to a panel's form when a ticket was opened, so that tickets can be searched by
+// them. Tickets opened before answers were stored only have their answers in the welcome message.
+type FormAnswers struct {
+	*pgxpool.Pool
+}
+
+func newFormAnswers(db *pgxpool.Pool) *FormAnswers {
+	return &FormAnswers{
+		db,
+	}
+}
+
+func (f FormAnswers) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_form_answers(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"label" varchar(255) NOT NULL,
+	"answer" text NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "label")
+);`
+}
+
+// Set stores the answers, keyed by the label of the form input they were given for
+func (f *FormAnswers) Set(ctx context.Context, guildId uint64, ticketId int, answers map[string]string) error {
+	query := `
+INSERT INTO ticket_form_answers("guild_id", "ticket_id", "label", "answer")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "ticket_id", "label") DO UPDATE SET "answer" = EXCLUDED."answer";`
+
+	batch := &pgx.Batch{}
+	for label, answer := range answers {
+		batch.Queue(query, guildId, ticketId, label, answer)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return f.SendBatch(ctx, batch).Close()
+}
+
+// Move moves the answers of the source ticket to the target ticket. If the target ticket already has an answer for
+// the same label, its own answer is kept.
+func (f *FormAnswers) Move(ctx context.Context, guildId uint64, sourceTicketId, targetTicketId int) (err error) {
+	query := `
+WITH moved AS (
+	DELETE FROM ticket_form_answers
+	WHERE "guild_id" = $1 AND "ticket_id" = $2
+	RETURNING "label", "answer"
+)
+INSERT INTO ticket_form_answers("guild_id", "ticket_id", "label", "answer")
+SELECT $1, $3, "label", "answer" FROM moved
+ON CONFLICT("guild_id", "ticket_id", "label") DO NOTHING;`
+
+	_, err = f.Exec(ctx, query, guildId, sourceTicketId, targetTicketId)
+	return
+}
@@ -0,0 +1,56 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type HistorySettings struct {
+	// PostOnOpen posts a summary of the user's previous tickets when a returning user opens a ticket
+	PostOnOpen bool
+}
+
+type HistorySettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newHistorySettingsTable(db *pgxpool.Pool) *HistorySettingsTable {
+	return &HistorySettingsTable{
+		db,
+	}
+}
+
+func (h HistorySettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS history_settings(
+	"guild_id" int8 NOT NULL,
+	"post_on_open" bool NOT NULL DEFAULT 'f',
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (h *HistorySettingsTable) Get(ctx context.Context, guildId uint64) (HistorySettings, error) {
+	query := `SELECT "post_on_open" FROM history_settings WHERE "guild_id" = $1;`
+
+	var settings HistorySettings
+	if err := h.QueryRow(ctx, query, guildId).Scan(&settings.PostOnOpen); err != nil {
+		if err == pgx.ErrNoRows {
+			return HistorySettings{}, nil
+		}
+
+		return HistorySettings{}, err
+	}
+
+	return settings, nil
+}
+
+func (h *HistorySettingsTable) Set(ctx context.Context, guildId uint64, settings HistorySettings) (err error) {
+	query := `
+INSERT INTO history_settings("guild_id", "post_on_open")
+VALUES($1, $2)
+ON CONFLICT("guild_id") DO UPDATE SET "post_on_open" = $2;`
+
+	_, err = h.Exec(ctx, query, guildId, settings.PostOnOpen)
+	return
+}
@@ -0,0 +1,105 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Label categorises tickets. Labels are defined per guild, and any number of them can be applied to a ticket.
+type Label struct {
+	Id      int
+	GuildId uint64
+	Name    string
+	Colour  int
+	Emoji   *string
+}
+
+type Labels struct {
+	*pgxpool.Pool
+}
+
+func newLabels(db *pgxpool.Pool) *Labels {
+	return &Labels{
+		db,
+	}
+}
+
+func (l Labels) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS labels(
+	"label_id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"name" varchar(32) NOT NULL,
+	"colour" int4 NOT NULL,
+	"emoji" varchar(64),
+	UNIQUE("guild_id", "name"),
+	PRIMARY KEY("label_id")
+);
+CREATE INDEX IF NOT EXISTS labels_guild_id ON labels("guild_id");`
+}
+
+func (l *Labels) Get(ctx context.Context, guildId uint64, labelId int) (Label, bool, error) {
+	query := `SELECT "label_id", "guild_id", "name", "colour", "emoji" FROM labels WHERE "guild_id" = $1 AND "label_id" = $2;`
+
+	var label Label
+	if err := l.QueryRow(ctx, query, guildId, labelId).Scan(&label.Id, &label.GuildId, &label.Name, &label.Colour, &label.Emoji); err != nil {
+		if err == pgx.ErrNoRows {
+			return Label{}, false, nil
+		}
+
+		return Label{}, false, err
+	}
+
+	return label, true, nil
+}
+
+// GetByGuild returns the guild's labels, ordered by name
+func (l *Labels) GetByGuild(ctx context.Context, guildId uint64) ([]Label, error) {
+	query := `SELECT "label_id", "guild_id", "name", "colour", "emoji" FROM labels WHERE "guild_id" = $1 ORDER BY "name" ASC;`
+
+	rows, err := l.Query(ctx, query, guildId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []Label
+	for rows.Next() {
+		var label Label
+		if err := rows.Scan(&label.Id, &label.GuildId, &label.Name, &label.Colour, &label.Emoji); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// Create returns false if the guild already has a label with the same name
+func (l *Labels) Create(ctx context.Context, label Label) (int, bool, error) {
+	query := `
+INSERT INTO labels("guild_id", "name", "colour", "emoji")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "name") DO NOTHING
+RETURNING "label_id";`
+
+	var id int
+	if err := l.QueryRow(ctx, query, label.GuildId, label.Name, label.Colour, label.Emoji).Scan(&id); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+// Delete also removes the label from any tickets it was applied to
+func (l *Labels) Delete(ctx context.Context, guildId uint64, labelId int) (err error) {
+	query := `DELETE FROM labels WHERE "guild_id" = $1 AND "label_id" = $2;`
+	_, err = l.Exec(ctx, query, guildId, labelId)
+	return
+}
@@ -0,0 +1,95 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ModmailSession links a user's DMs with a bot to the ticket that their messages are relayed to
+type ModmailSession struct {
+	BotId    uint64
+	UserId   uint64
+	GuildId  uint64
+	TicketId int
+}
+
+// ModmailSessions stores the ticket that each user is messaging through DMs. Sessions are keyed by bot, as the same
+// user may be talking to the public bot and a whitelabel bot at once.
+type ModmailSessions struct {
+	*pgxpool.Pool
+}
+
+func newModmailSessions(db *pgxpool.Pool) *ModmailSessions {
+	return &ModmailSessions{
+		db,
+	}
+}
+
+func (m ModmailSessions) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS modmail_sessions(
+	"bot_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	UNIQUE("guild_id", "ticket_id"),
+	PRIMARY KEY("bot_id", "user_id")
+);`
+}
+
+func (m *ModmailSessions) Get(ctx context.Context, botId, userId uint64) (ModmailSession, bool, error) {
+	query := `
+SELECT "bot_id", "user_id", "guild_id", "ticket_id"
+FROM modmail_sessions
+WHERE "bot_id" = $1 AND "user_id" = $2;`
+
+	var session ModmailSession
+	if err := m.QueryRow(ctx, query, botId, userId).Scan(&session.BotId, &session.UserId, &session.GuildId, &session.TicketId); err != nil {
+		if err == pgx.ErrNoRows {
+			return ModmailSession{}, false, nil
+		}
+
+		return ModmailSession{}, false, err
+	}
+
+	return session, true, nil
+}
+
+// GetByTicket returns false if the ticket was not opened through modmail, or the session has since been replaced
+func (m *ModmailSessions) GetByTicket(ctx context.Context, guildId uint64, ticketId int) (ModmailSession, bool, error) {
+	query := `
+SELECT "bot_id", "user_id", "guild_id", "ticket_id"
+FROM modmail_sessions
+WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	var session ModmailSession
+	if err := m.QueryRow(ctx, query, guildId, ticketId).Scan(&session.BotId, &session.UserId, &session.GuildId, &session.TicketId); err != nil {
+		if err == pgx.ErrNoRows {
+			return ModmailSession{}, false, nil
+		}
+
+		return ModmailSession{}, false, err
+	}
+
+	return session, true, nil
+}
+
+// Set replaces any existing session the user has with the bot
+func (m *ModmailSessions) Set(ctx context.Context, session ModmailSession) (err error) {
+	query := `
+INSERT INTO modmail_sessions("bot_id", "user_id", "guild_id", "ticket_id")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("bot_id", "user_id") DO UPDATE SET "guild_id" = $3, "ticket_id" = $4;`
+
+	_, err = m.Exec(ctx, query, session.BotId, session.UserId, session.GuildId, session.TicketId)
+	return
+}
+
+func (m *ModmailSessions) Delete(ctx context.Context, botId, userId uint64) (err error) {
+	query := `DELETE FROM modmail_sessions WHERE "bot_id" = $1 AND "user_id" = $2;`
+
+	_, err = m.Exec(ctx, query, botId, userId)
+	return
+}
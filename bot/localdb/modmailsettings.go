@@ -0,0 +1,79 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ModmailSettings struct {
+	// Enabled allows users to open tickets in the guild by messaging the bot
+	Enabled bool
+}
+
+type ModmailSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newModmailSettingsTable(db *pgxpool.Pool) *ModmailSettingsTable {
+	return &ModmailSettingsTable{
+		db,
+	}
+}
+
+func (m ModmailSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS modmail_settings(
+	"guild_id" int8 NOT NULL,
+	"enabled" bool NOT NULL DEFAULT 'f',
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (m *ModmailSettingsTable) Get(ctx context.Context, guildId uint64) (ModmailSettings, error) {
+	query := `SELECT "enabled" FROM modmail_settings WHERE "guild_id" = $1;`
+
+	var settings ModmailSettings
+	if err := m.QueryRow(ctx, query, guildId).Scan(&settings.Enabled); err != nil {
+		if err == pgx.ErrNoRows {
+			return ModmailSettings{}, nil
+		}
+
+		return ModmailSettings{}, err
+	}
+
+	return settings, nil
+}
+
+func (m *ModmailSettingsTable) Set(ctx context.Context, guildId uint64, settings ModmailSettings) (err error) {
+	query := `
+INSERT INTO modmail_settings("guild_id", "enabled")
+VALUES($1, $2)
+ON CONFLICT("guild_id") DO UPDATE SET "enabled" = $2;`
+
+	_, err = m.Exec(ctx, query, guildId, settings.Enabled)
+	return
+}
+
+// FilterEnabled returns the guilds out of those given that have modmail enabled
+func (m *ModmailSettingsTable) FilterEnabled(ctx context.Context, guildIds []uint64) ([]uint64, error) {
+	query := `SELECT "guild_id" FROM modmail_settings WHERE "guild_id" = ANY($1::int8[]) AND "enabled";`
+
+	rows, err := m.Query(ctx, query, guildIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enabled []uint64
+	for rows.Next() {
+		var guildId uint64
+		if err := rows.Scan(&guildId); err != nil {
+			return nil, err
+		}
+
+		enabled = append(enabled, guildId)
+	}
+
+	return enabled, rows.Err()
+}
@@ -0,0 +1,236 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// OnCallShift is a recurring period during which a staff member is on call for a support team
+type OnCallShift struct {
+	Id      int
+	GuildId uint64
+	// TeamId is nil for the default support team
+	TeamId *int
+	UserId uint64
+	Days   []time.Weekday
+	// Start and End are in minutes since midnight. If End is not after Start, the shift runs overnight into the next
+	// day.
+	Start    int
+	End      int
+	Timezone string
+	// Active is whether the scheduler has put the staff member on call for this shift
+	Active bool
+}
+
+// ActiveAt returns whether t falls within the shift
+func (s OnCallShift) ActiveAt(t time.Time) (bool, error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(location)
+	minutes := t.Hour()*60 + t.Minute()
+
+	if s.Start < s.End {
+		return s.onDay(t.Weekday()) && minutes >= s.Start && minutes < s.End, nil
+	}
+
+	// Overnight shifts belong to the day they start on
+	yesterday := (t.Weekday() + 6) % 7
+	return (s.onDay(t.Weekday()) && minutes >= s.Start) || (s.onDay(yesterday) && minutes < s.End), nil
+}
+
+func (s OnCallShift) onDay(day time.Weekday) bool {
+	for _, d := range s.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnclaimedTicket is an open ticket that nobody has claimed, listed in handover messages
+type UnclaimedTicket struct {
+	Id        int
+	ChannelId uint64
+	OpenTime  time.Time
+}
+
+// OnCallShifts stores the on call schedules of support teams
+type OnCallShifts struct {
+	*pgxpool.Pool
+}
+
+func newOnCallShifts(db *pgxpool.Pool) *OnCallShifts {
+	return &OnCallShifts{
+		db,
+	}
+}
+
+func (o OnCallShifts) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS on_call_shifts(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"team_id" int4,
+	"user_id" int8 NOT NULL,
+	"days" int2 NOT NULL,
+	"start_minute" int2 NOT NULL,
+	"end_minute" int2 NOT NULL,
+	"timezone" varchar(64) NOT NULL,
+	"active" bool NOT NULL DEFAULT 'f',
+	FOREIGN KEY("team_id") REFERENCES support_team("id") ON DELETE CASCADE,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS on_call_shifts_guild_id ON on_call_shifts("guild_id");`
+}
+
+const onCallShiftColumns = `"id", "guild_id", "team_id", "user_id", "days", "start_minute", "end_minute", "timezone", "active"`
+
+func (o *OnCallShifts) Create(ctx context.Context, shift OnCallShift) (id int, err error) {
+	query := `
+INSERT INTO on_call_shifts("guild_id", "team_id", "user_id", "days", "start_minute", "end_minute", "timezone")
+VALUES($1, $2, $3, $4, $5, $6, $7)
+RETURNING "id";`
+
+	err = o.QueryRow(ctx, query, shift.GuildId, shift.TeamId, shift.UserId, daysToMask(shift.Days), shift.Start, shift.End,
+		shift.Timezone).Scan(&id)
+	return
+}
+
+// Delete returns the deleted shift, or false if the guild has no shift with the given ID
+func (o *OnCallShifts) Delete(ctx context.Context, guildId uint64, id int) (OnCallShift, bool, error) {
+	query := `
+DELETE FROM on_call_shifts
+WHERE "guild_id" = $1 AND "id" = $2
+RETURNING ` + onCallShiftColumns + `;`
+
+	shift, err := scanOnCallShift(o.QueryRow(ctx, query, guildId, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return OnCallShift{}, false, nil
+		}
+
+		return OnCallShift{}, false, err
+	}
+
+	return shift, true, nil
+}
+
+func (o *OnCallShifts) GetByGuild(ctx context.Context, guildId uint64) ([]OnCallShift, error) {
+	query := `SELECT ` + onCallShiftColumns + ` FROM on_call_shifts WHERE "guild_id" = $1 ORDER BY "id" ASC;`
+	return o.query(ctx, query, guildId)
+}
+
+// GetAll returns the shifts of every guild, for the scheduler
+func (o *OnCallShifts) GetAll(ctx context.Context) ([]OnCallShift, error) {
+	query := `SELECT ` + onCallShiftColumns + ` FROM on_call_shifts ORDER BY "id" ASC;`
+	return o.query(ctx, query)
+}
+
+func (o *OnCallShifts) SetActive(ctx context.Context, id int, active bool) (err error) {
+	_, err = o.Exec(ctx, `UPDATE on_call_shifts SET "active" = $2 WHERE "id" = $1;`, id, active)
+	return
+}
+
+// GetUnclaimedTickets returns the open, unclaimed tickets that the support team handles, oldest first. If teamId is
+// nil, the tickets handled by the default support team are returned.
+func (o *OnCallShifts) GetUnclaimedTickets(ctx context.Context, guildId uint64, teamId *int) ([]UnclaimedTicket, error) {
+	query := `
+SELECT tickets.id, tickets.channel_id, tickets.open_time
+FROM tickets
+LEFT OUTER JOIN panels
+	ON panels.panel_id = tickets.panel_id
+WHERE tickets.guild_id = $1
+	AND tickets.open
+	AND tickets.channel_id IS NOT NULL
+	AND NOT EXISTS(
+		SELECT 1
+		FROM ticket_claims
+		WHERE ticket_claims.guild_id = tickets.guild_id AND ticket_claims.ticket_id = tickets.id
+	)
+	AND (
+		($2::int4 IS NULL AND (panels.panel_id IS NULL OR panels.default_team))
+		OR EXISTS(
+			SELECT 1
+			FROM panel_teams
+			WHERE panel_teams.panel_id = tickets.panel_id AND panel_teams.team_id = $2
+		)
+	)
+ORDER BY tickets.id ASC;`
+
+	rows, err := o.Query(ctx, query, guildId, teamId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []UnclaimedTicket
+	for rows.Next() {
+		var ticket UnclaimedTicket
+		if err := rows.Scan(&ticket.Id, &ticket.ChannelId, &ticket.OpenTime); err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+func (o *OnCallShifts) query(ctx context.Context, query string, args ...interface{}) ([]OnCallShift, error) {
+	rows, err := o.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shifts []OnCallShift
+	for rows.Next() {
+		shift, err := scanOnCallShift(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		shifts = append(shifts, shift)
+	}
+
+	return shifts, rows.Err()
+}
+
+func scanOnCallShift(row pgx.Row) (OnCallShift, error) {
+	var shift OnCallShift
+	var days int16
+	if err := row.Scan(&shift.Id, &shift.GuildId, &shift.TeamId, &shift.UserId, &days, &shift.Start, &shift.End,
+		&shift.Timezone, &shift.Active); err != nil {
+		return OnCallShift{}, err
+	}
+
+	shift.Days = maskToDays(days)
+	return shift, nil
+}
+
+// Days are stored as a bitmask, indexed by time.Weekday
+func daysToMask(days []time.Weekday) int16 {
+	var mask int16
+	for _, day := range days {
+		mask |= 1 << day
+	}
+
+	return mask
+}
+
+func maskToDays(mask int16) []time.Weekday {
+	var days []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if mask&(1<<day) != 0 {
+			days = append(days, day)
+		}
+	}
+
+	return days
+}
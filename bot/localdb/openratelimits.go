@@ -0,0 +1,84 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type RateLimitScope string
+
+const (
+	RateLimitScopeGuild RateLimitScope = "guild"
+	RateLimitScopeUser  RateLimitScope = "user"
+	RateLimitScopePanel RateLimitScope = "panel"
+)
+
+type OpenRateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// OpenRateLimits stores the ticket open rate limits that a guild has configured. Scopes without a row use the
+// default limits.
+type OpenRateLimits struct {
+	*pgxpool.Pool
+}
+
+func newOpenRateLimits(db *pgxpool.Pool) *OpenRateLimits {
+	return &OpenRateLimits{
+		db,
+	}
+}
+
+func (r OpenRateLimits) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS open_rate_limits(
+	"guild_id" int8 NOT NULL,
+	"scope" varchar(8) NOT NULL,
+	"limit" int4 NOT NULL,
+	"window_seconds" int4 NOT NULL,
+	PRIMARY KEY("guild_id", "scope")
+);`
+}
+
+func (r *OpenRateLimits) GetAll(ctx context.Context, guildId uint64) (map[RateLimitScope]OpenRateLimit, error) {
+	query := `SELECT "scope", "limit", "window_seconds" FROM open_rate_limits WHERE "guild_id" = $1;`
+
+	rows, err := r.Query(ctx, query, guildId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	limits := make(map[RateLimitScope]OpenRateLimit)
+	for rows.Next() {
+		var scope RateLimitScope
+		var limit, windowSeconds int
+		if err := rows.Scan(&scope, &limit, &windowSeconds); err != nil {
+			return nil, err
+		}
+
+		limits[scope] = OpenRateLimit{
+			Limit:  limit,
+			Window: time.Duration(windowSeconds) * time.Second,
+		}
+	}
+
+	return limits, rows.Err()
+}
+
+func (r *OpenRateLimits) Set(ctx context.Context, guildId uint64, scope RateLimitScope, limit OpenRateLimit) (err error) {
+	query := `
+INSERT INTO open_rate_limits("guild_id", "scope", "limit", "window_seconds")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "scope") DO UPDATE SET "limit" = $3, "window_seconds" = $4;`
+
+	_, err = r.Exec(ctx, query, guildId, scope, limit.Limit, int(limit.Window.Seconds()))
+	return
+}
+
+func (r *OpenRateLimits) Delete(ctx context.Context, guildId uint64, scope RateLimitScope) (err error) {
+	_, err = r.Exec(ctx, `DELETE FROM open_rate_limits WHERE "guild_id" = $1 AND "scope" = $2;`, guildId, scope)
+	return
+}
@@ -0,0 +1,127 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PrioritySettings struct {
+	// EscalationRoleId is pinged when an urgent ticket has no staff response within EscalationMinutes. Escalation is
+	// disabled if nil.
+	EscalationRoleId  *uint64
+	EscalationMinutes int
+	// ChannelPrefix prefixes the names of ticket channels with an indicator of their priority
+	ChannelPrefix bool
+}
+
+const DefaultEscalationMinutes = 30
+
+type PrioritySettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newPrioritySettingsTable(db *pgxpool.Pool) *PrioritySettingsTable {
+	return &PrioritySettingsTable{
+		db,
+	}
+}
+
+func (p PrioritySettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS priority_settings(
+	"guild_id" int8 NOT NULL,
+	"escalation_role_id" int8,
+	"escalation_minutes" int4 NOT NULL,
+	"channel_prefix" bool NOT NULL DEFAULT 't',
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (p *PrioritySettingsTable) Get(ctx context.Context, guildId uint64) (PrioritySettings, error) {
+	query := `SELECT "escalation_role_id", "escalation_minutes", "channel_prefix" FROM priority_settings WHERE "guild_id" = $1;`
+
+	var settings PrioritySettings
+	if err := p.QueryRow(ctx, query, guildId).Scan(&settings.EscalationRoleId, &settings.EscalationMinutes, &settings.ChannelPrefix); err != nil {
+		if err == pgx.ErrNoRows {
+			return PrioritySettings{
+				EscalationRoleId:  nil,
+				EscalationMinutes: DefaultEscalationMinutes,
+				ChannelPrefix:     true,
+			}, nil
+		}
+
+		return PrioritySettings{}, err
+	}
+
+	return settings, nil
+}
+
+func (p *PrioritySettingsTable) Set(ctx context.Context, guildId uint64, settings PrioritySettings) (err error) {
+	query := `
+INSERT INTO priority_settings("guild_id", "escalation_role_id", "escalation_minutes", "channel_prefix")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id") DO UPDATE SET "escalation_role_id" = $2, "escalation_minutes" = $3, "channel_prefix" = $4;`
+
+	_, err = p.Exec(ctx, query, guildId, settings.EscalationRoleId, settings.EscalationMinutes, settings.ChannelPrefix)
+	return
+}
+
+// PanelPriority is the priority given to tickets opened from a panel, and whether the opener may choose a different
+// priority from a select menu in the ticket
+type PanelPriority struct {
+	Priority       Priority
+	UserSelectable bool
+}
+
+// PanelPriorities stores the priority settings of each panel, if they are not the defaults
+type PanelPriorities struct {
+	*pgxpool.Pool
+}
+
+func newPanelPriorities(db *pgxpool.Pool) *PanelPriorities {
+	return &PanelPriorities{
+		db,
+	}
+}
+
+func (p PanelPriorities) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_priorities(
+	"panel_id" int4 NOT NULL,
+	"priority" varchar(8) NOT NULL,
+	"user_selectable" bool NOT NULL DEFAULT 'f',
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);`
+}
+
+func (p *PanelPriorities) Get(ctx context.Context, panelId int) (PanelPriority, error) {
+	query := `SELECT "priority", "user_selectable" FROM panel_priorities WHERE "panel_id" = $1;`
+
+	var settings PanelPriority
+	if err := p.QueryRow(ctx, query, panelId).Scan(&settings.Priority, &settings.UserSelectable); err != nil {
+		if err == pgx.ErrNoRows {
+			return PanelPriority{Priority: PriorityNormal}, nil
+		}
+
+		return PanelPriority{}, err
+	}
+
+	return settings, nil
+}
+
+func (p *PanelPriorities) Set(ctx context.Context, panelId int, settings PanelPriority) (err error) {
+	if settings.Priority == PriorityNormal && !settings.UserSelectable {
+		_, err = p.Exec(ctx, `DELETE FROM panel_priorities WHERE "panel_id" = $1;`, panelId)
+		return
+	}
+
+	query := `
+INSERT INTO panel_priorities("panel_id", "priority", "user_selectable")
+VALUES($1, $2, $3)
+ON CONFLICT("panel_id") DO UPDATE SET "priority" = $2, "user_selectable" = $3;`
+
+	_, err = p.Exec(ctx, query, panelId, settings.Priority, settings.UserSelectable)
+	return
+}
@@ -0,0 +1,137 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type ScheduledClose struct {
+	GuildId   uint64
+	TicketId  int
+	ChannelId uint64
+	// UserId is the user who scheduled the close, who is recorded as the closer
+	UserId  uint64
+	Reason  *string
+	CloseAt time.Time
+	// MessageId is the countdown message, which is edited if the close is cancelled
+	MessageId *uint64
+}
+
+// ScheduledCloses stores tickets that will be closed at a later time, so that they survive worker restarts
+type ScheduledCloses struct {
+	*pgxpool.Pool
+}
+
+func newScheduledCloses(db *pgxpool.Pool) *ScheduledCloses {
+	return &ScheduledCloses{
+		db,
+	}
+}
+
+func (s ScheduledCloses) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS scheduled_closes(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"reason" text,
+	"close_at" timestamptz NOT NULL,
+	"message_id" int8,
+	"claimed_until" timestamptz,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+CREATE INDEX IF NOT EXISTS scheduled_closes_close_at ON scheduled_closes("close_at");`
+}
+
+// Set schedules the ticket to be closed, replacing any close that was already scheduled, including one that a worker
+// has already claimed
+func (s *ScheduledCloses) Set(ctx context.Context, scheduled ScheduledClose) (err error) {
+	query := `
+INSERT INTO scheduled_closes("guild_id", "ticket_id", "channel_id", "user_id", "reason", "close_at", "message_id")
+VALUES($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET
+	"channel_id" = $3,
+	"user_id" = $4,
+	"reason" = $5,
+	"close_at" = $6,
+	"message_id" = $7,
+	"claimed_until" = NULL;`
+
+	_, err = s.Exec(ctx, query, scheduled.GuildId, scheduled.TicketId, scheduled.ChannelId, scheduled.UserId,
+		scheduled.Reason, scheduled.CloseAt, scheduled.MessageId)
+	return
+}
+
+func (s *ScheduledCloses) SetMessageId(ctx context.Context, guildId uint64, ticketId int, messageId uint64) (err error) {
+	query := `UPDATE scheduled_closes SET "message_id" = $3 WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	_, err = s.Exec(ctx, query, guildId, ticketId, messageId)
+	return
+}
+
+// Delete cancels the scheduled close of the ticket, returning it if there was one
+func (s *ScheduledCloses) Delete(ctx context.Context, guildId uint64, ticketId int) (ScheduledClose, bool, error) {
+	query := `
+DELETE FROM scheduled_closes
+WHERE "guild_id" = $1 AND "ticket_id" = $2
+RETURNING "guild_id", "ticket_id", "channel_id", "user_id", "reason", "close_at", "message_id";`
+
+	var scheduled ScheduledClose
+	if err := s.QueryRow(ctx, query, guildId, ticketId).Scan(
+		&scheduled.GuildId, &scheduled.TicketId, &scheduled.ChannelId, &scheduled.UserId, &scheduled.Reason,
+		&scheduled.CloseAt, &scheduled.MessageId,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return ScheduledClose{}, false, nil
+		}
+
+		return ScheduledClose{}, false, err
+	}
+
+	return scheduled, true, nil
+}
+
+// ClaimDue returns the closes that are due and have not been claimed by another worker, and claims them for the
+// duration of lease. The close must be removed with Complete once it has been performed, otherwise it is retried by
+// any worker once the lease expires.
+func (s *ScheduledCloses) ClaimDue(ctx context.Context, lease time.Duration) ([]ScheduledClose, error) {
+	query := `
+UPDATE scheduled_closes
+SET "claimed_until" = NOW() + make_interval(secs => $1)
+WHERE "close_at" <= NOW() AND ("claimed_until" IS NULL OR "claimed_until" <= NOW())
+RETURNING "guild_id", "ticket_id", "channel_id", "user_id", "reason", "close_at", "message_id";`
+
+	rows, err := s.Query(ctx, query, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []ScheduledClose
+	for rows.Next() {
+		var scheduled ScheduledClose
+		if err := rows.Scan(
+			&scheduled.GuildId, &scheduled.TicketId, &scheduled.ChannelId, &scheduled.UserId, &scheduled.Reason,
+			&scheduled.CloseAt, &scheduled.MessageId,
+		); err != nil {
+			return nil, err
+		}
+
+		due = append(due, scheduled)
+	}
+
+	return due, rows.Err()
+}
+
+// Complete removes a claimed close once it has been performed. The close is only removed if it has not been
+// rescheduled since it was claimed.
+func (s *ScheduledCloses) Complete(ctx context.Context, scheduled ScheduledClose) (err error) {
+	query := `DELETE FROM scheduled_closes WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "close_at" = $3;`
+
+	_, err = s.Exec(ctx, query, scheduled.GuildId, scheduled.TicketId, scheduled.CloseAt)
+	return
+}
@@ -0,0 +1,201 @@
+package localdb
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type SlaPolicy struct {
+	PanelId int
+	GuildId uint64
+	// Targets are nil if the policy does not measure them
+	FirstResponse  *time.Duration
+	NextResponse   *time.Duration
+	Resolution     *time.Duration
+	WarningPercent int
+	// StaffChannelId receives warnings and breach notices, in addition to the ticket channel receiving warnings
+	StaffChannelId *uint64
+	// BusinessHours only runs the clocks during the guild's business hours
+	BusinessHours bool
+}
+
+// Target returns the target of the policy for the given kind of clock, or nil if it is not measured
+func (p SlaPolicy) Target(kind sla.Kind) *time.Duration {
+	switch kind {
+	case sla.KindFirstResponse:
+		return p.FirstResponse
+	case sla.KindNextResponse:
+		return p.NextResponse
+	case sla.KindResolution:
+		return p.Resolution
+	default:
+		return nil
+	}
+}
+
+// SlaPolicies stores the SLA policy of each panel. Tickets opened from panels without a policy are not tracked.
+type SlaPolicies struct {
+	*pgxpool.Pool
+}
+
+func newSlaPolicies(db *pgxpool.Pool) *SlaPolicies {
+	return &SlaPolicies{
+		db,
+	}
+}
+
+func (s SlaPolicies) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS sla_policies(
+	"panel_id" int4 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"first_response_minutes" int4,
+	"next_response_minutes" int4,
+	"resolution_minutes" int4,
+	"warning_percent" int4 NOT NULL,
+	"staff_channel_id" int8,
+	"business_hours" bool NOT NULL DEFAULT 'f',
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);
+CREATE INDEX IF NOT EXISTS sla_policies_guild_id ON sla_policies("guild_id");`
+}
+
+func (s *SlaPolicies) Get(ctx context.Context, panelId int) (SlaPolicy, bool, error) {
+	query := `
+SELECT "panel_id", "guild_id", "first_response_minutes", "next_response_minutes", "resolution_minutes", "warning_percent", "staff_channel_id", "business_hours"
+FROM sla_policies
+WHERE "panel_id" = $1;`
+
+	policy, err := scanSlaPolicy(s.QueryRow(ctx, query, panelId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SlaPolicy{}, false, nil
+		}
+
+		return SlaPolicy{}, false, err
+	}
+
+	return policy, true, nil
+}
+
+func (s *SlaPolicies) Set(ctx context.Context, policy SlaPolicy) (err error) {
+	query := `
+INSERT INTO sla_policies("panel_id", "guild_id", "first_response_minutes", "next_response_minutes", "resolution_minutes", "warning_percent", "staff_channel_id", "business_hours")
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT("panel_id") DO UPDATE SET
+	"first_response_minutes" = $3,
+	"next_response_minutes" = $4,
+	"resolution_minutes" = $5,
+	"warning_percent" = $6,
+	"staff_channel_id" = $7,
+	"business_hours" = $8;`
+
+	_, err = s.Exec(ctx, query, policy.PanelId, policy.GuildId, toMinutes(policy.FirstResponse), toMinutes(policy.NextResponse),
+		toMinutes(policy.Resolution), policy.WarningPercent, policy.StaffChannelId, policy.BusinessHours)
+	return
+}
+
+func (s *SlaPolicies) Delete(ctx context.Context, panelId int) (err error) {
+	_, err = s.Exec(ctx, `DELETE FROM sla_policies WHERE "panel_id" = $1;`, panelId)
+	return
+}
+
+func scanSlaPolicy(row pgx.Row, extra ...interface{}) (SlaPolicy, error) {
+	var policy SlaPolicy
+	var firstResponse, nextResponse, resolution *int
+
+	dest := append([]interface{}{&policy.PanelId, &policy.GuildId, &firstResponse, &nextResponse, &resolution,
+		&policy.WarningPercent, &policy.StaffChannelId, &policy.BusinessHours}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return SlaPolicy{}, err
+	}
+
+	policy.FirstResponse = fromMinutes(firstResponse)
+	policy.NextResponse = fromMinutes(nextResponse)
+	policy.Resolution = fromMinutes(resolution)
+	return policy, nil
+}
+
+func toMinutes(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+
+	minutes := int(d.Minutes())
+	return &minutes
+}
+
+func fromMinutes(minutes *int) *time.Duration {
+	if minutes == nil {
+		return nil
+	}
+
+	d := time.Duration(*minutes) * time.Minute
+	return &d
+}
+
+// BusinessHoursTable stores the business hours calendar of each guild, used by SLA policies
+type BusinessHoursTable struct {
+	*pgxpool.Pool
+}
+
+func newBusinessHoursTable(db *pgxpool.Pool) *BusinessHoursTable {
+	return &BusinessHoursTable{
+		db,
+	}
+}
+
+func (b BusinessHoursTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS business_hours(
+	"guild_id" int8 NOT NULL,
+	"calendar" jsonb NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+// Get returns false if the guild has not configured business hours
+func (b *BusinessHoursTable) Get(ctx context.Context, guildId uint64) (sla.BusinessHours, bool, error) {
+	query := `SELECT "calendar" FROM business_hours WHERE "guild_id" = $1;`
+
+	var raw []byte
+	if err := b.QueryRow(ctx, query, guildId).Scan(&raw); err != nil {
+		if err == pgx.ErrNoRows {
+			return sla.BusinessHours{}, false, nil
+		}
+
+		return sla.BusinessHours{}, false, err
+	}
+
+	var hours sla.BusinessHours
+	if err := json.Unmarshal(raw, &hours); err != nil {
+		return sla.BusinessHours{}, false, err
+	}
+
+	return hours, true, nil
+}
+
+func (b *BusinessHoursTable) Set(ctx context.Context, guildId uint64, hours sla.BusinessHours) error {
+	raw, err := json.Marshal(hours)
+	if err != nil {
+		return err
+	}
+
+	query := `
+INSERT INTO business_hours("guild_id", "calendar")
+VALUES($1, $2)
+ON CONFLICT("guild_id") DO UPDATE SET "calendar" = $2;`
+
+	_, err = b.Exec(ctx, query, guildId, raw)
+	return err
+}
+
+func (b *BusinessHoursTable) Delete(ctx context.Context, guildId uint64) (err error) {
+	_, err = b.Exec(ctx, `DELETE FROM business_hours WHERE "guild_id" = $1;`, guildId)
+	return
+}
@@ -0,0 +1,117 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// AwayStatus marks a staff member as unavailable until the given time
+type AwayStatus struct {
+	GuildId uint64
+	UserId  uint64
+	Until   time.Time
+	Note    *string
+}
+
+// StaffAway stores the out of office status of staff members. Statuses that have passed their end time are ignored.
+type StaffAway struct {
+	*pgxpool.Pool
+}
+
+func newStaffAway(db *pgxpool.Pool) *StaffAway {
+	return &StaffAway{
+		db,
+	}
+}
+
+func (s StaffAway) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS staff_away(
+	"guild_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"until" timestamptz NOT NULL,
+	"note" varchar(255),
+	PRIMARY KEY("guild_id", "user_id")
+);`
+}
+
+func (s *StaffAway) Get(ctx context.Context, guildId, userId uint64) (AwayStatus, bool, error) {
+	query := `
+SELECT "guild_id", "user_id", "until", "note"
+FROM staff_away
+WHERE "guild_id" = $1 AND "user_id" = $2 AND "until" > NOW();`
+
+	var status AwayStatus
+	if err := s.QueryRow(ctx, query, guildId, userId).Scan(&status.GuildId, &status.UserId, &status.Until, &status.Note); err != nil {
+		if err == pgx.ErrNoRows {
+			return AwayStatus{}, false, nil
+		}
+
+		return AwayStatus{}, false, err
+	}
+
+	return status, true, nil
+}
+
+// GetByGuild returns the staff members who are currently away, ordered by when they return
+func (s *StaffAway) GetByGuild(ctx context.Context, guildId uint64) ([]AwayStatus, error) {
+	query := `
+SELECT "guild_id", "user_id", "until", "note"
+FROM staff_away
+WHERE "guild_id" = $1 AND "until" > NOW()
+ORDER BY "until" ASC;`
+
+	return s.query(ctx, query, guildId)
+}
+
+// GetMulti returns the statuses of those of the given users who are currently away
+func (s *StaffAway) GetMulti(ctx context.Context, guildId uint64, userIds []uint64) ([]AwayStatus, error) {
+	query := `
+SELECT "guild_id", "user_id", "until", "note"
+FROM staff_away
+WHERE "guild_id" = $1 AND "user_id" = ANY($2) AND "until" > NOW();`
+
+	return s.query(ctx, query, guildId, userIds)
+}
+
+func (s *StaffAway) Set(ctx context.Context, status AwayStatus) (err error) {
+	query := `
+INSERT INTO staff_away("guild_id", "user_id", "until", "note")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "user_id") DO UPDATE SET "until" = $3, "note" = $4;`
+
+	_, err = s.Exec(ctx, query, status.GuildId, status.UserId, status.Until, status.Note)
+	return
+}
+
+// Delete returns false if the staff member was not away
+func (s *StaffAway) Delete(ctx context.Context, guildId, userId uint64) (bool, error) {
+	res, err := s.Exec(ctx, `DELETE FROM staff_away WHERE "guild_id" = $1 AND "user_id" = $2 AND "until" > NOW();`, guildId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (s *StaffAway) query(ctx context.Context, query string, args ...interface{}) ([]AwayStatus, error) {
+	rows, err := s.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []AwayStatus
+	for rows.Next() {
+		var status AwayStatus
+		if err := rows.Scan(&status.GuildId, &status.UserId, &status.Until, &status.Note); err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
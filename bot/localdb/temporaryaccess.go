@@ -0,0 +1,92 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type TemporaryAccess struct {
+	GuildId  uint64
+	TicketId int
+	// UserId is the user who was added to the ticket
+	UserId uint64
+	// AddedBy is the user who ran /add, who is recorded as removing the user when access expires
+	AddedBy   uint64
+	ExpiresAt time.Time
+}
+
+// TemporaryAccessTable stores when users added to tickets with a duration should be removed again, so that the
+// removal survives worker restarts
+type TemporaryAccessTable struct {
+	*pgxpool.Pool
+}
+
+func newTemporaryAccessTable(db *pgxpool.Pool) *TemporaryAccessTable {
+	return &TemporaryAccessTable{
+		db,
+	}
+}
+
+func (t TemporaryAccessTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS temporary_ticket_access(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"added_by" int8 NOT NULL,
+	"expires_at" timestamptz NOT NULL,
+	"claimed_until" timestamptz,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "user_id")
+);
+CREATE INDEX IF NOT EXISTS temporary_ticket_access_expires_at ON temporary_ticket_access("expires_at");`
+}
+
+// Set replaces any existing expiry of the user's access to the ticket
+func (t *TemporaryAccessTable) Set(ctx context.Context, access TemporaryAccess) (err error) {
+	query := `
+INSERT INTO temporary_ticket_access("guild_id", "ticket_id", "user_id", "added_by", "expires_at")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id", "ticket_id", "user_id") DO UPDATE SET "added_by" = $4, "expires_at" = $5, "claimed_until" = NULL;`
+
+	_, err = t.Exec(ctx, query, access.GuildId, access.TicketId, access.UserId, access.AddedBy, access.ExpiresAt)
+	return
+}
+
+// Delete makes the user's access to the ticket permanent, if it was temporary
+func (t *TemporaryAccessTable) Delete(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error) {
+	query := `DELETE FROM temporary_ticket_access WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "user_id" = $3;`
+
+	_, err = t.Exec(ctx, query, guildId, ticketId, userId)
+	return
+}
+
+// ClaimDue returns the access that has expired and has not been claimed by another worker, and claims it for the
+// duration of lease. The user's access is made permanent by Delete once they have been removed, otherwise the removal
+// is retried by any worker once the lease expires.
+func (t *TemporaryAccessTable) ClaimDue(ctx context.Context, lease time.Duration) ([]TemporaryAccess, error) {
+	query := `
+UPDATE temporary_ticket_access
+SET "claimed_until" = NOW() + make_interval(secs => $1)
+WHERE "expires_at" <= NOW() AND ("claimed_until" IS NULL OR "claimed_until" <= NOW())
+RETURNING "guild_id", "ticket_id", "user_id", "added_by", "expires_at";`
+
+	rows, err := t.Query(ctx, query, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []TemporaryAccess
+	for rows.Next() {
+		var access TemporaryAccess
+		if err := rows.Scan(&access.GuildId, &access.TicketId, &access.UserId, &access.AddedBy, &access.ExpiresAt); err != nil {
+			return nil, err
+		}
+
+		due = append(due, access)
+	}
+
+	return due, rows.Err()
+}
@@ -0,0 +1,151 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// TicketLabels stores the labels applied to each ticket
+type TicketLabels struct {
+	*pgxpool.Pool
+}
+
+type LabelStats struct {
+	Total int
+	Open  int
+	// AverageRating is nil if none of the tickets have been rated
+	AverageRating *float64
+	Ratings       int
+	// AverageDuration is nil if none of the tickets have been closed
+	AverageDuration *time.Duration
+}
+
+func newTicketLabels(db *pgxpool.Pool) *TicketLabels {
+	return &TicketLabels{
+		db,
+	}
+}
+
+func (t TicketLabels) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_labels(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"label_id" int4 NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	FOREIGN KEY("label_id") REFERENCES labels("label_id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "label_id")
+);
+CREATE INDEX IF NOT EXISTS ticket_labels_label_id ON ticket_labels("label_id");`
+}
+
+// GetByTicket returns the labels applied to the ticket, ordered by name
+func (t *TicketLabels) GetByTicket(ctx context.Context, guildId uint64, ticketId int) ([]Label, error) {
+	query := `
+SELECT labels.label_id, labels.guild_id, labels.name, labels.colour, labels.emoji
+FROM ticket_labels
+INNER JOIN labels ON labels.label_id = ticket_labels.label_id
+WHERE ticket_labels.guild_id = $1 AND ticket_labels.ticket_id = $2
+ORDER BY labels.name ASC;`
+
+	rows, err := t.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []Label
+	for rows.Next() {
+		var label Label
+		if err := rows.Scan(&label.Id, &label.GuildId, &label.Name, &label.Colour, &label.Emoji); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// Add returns false if the label was already applied to the ticket
+func (t *TicketLabels) Add(ctx context.Context, guildId uint64, ticketId, labelId int) (bool, error) {
+	query := `
+INSERT INTO ticket_labels("guild_id", "ticket_id", "label_id")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "ticket_id", "label_id") DO NOTHING;`
+
+	res, err := t.Exec(ctx, query, guildId, ticketId, labelId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Remove returns false if the label was not applied to the ticket
+func (t *TicketLabels) Remove(ctx context.Context, guildId uint64, ticketId, labelId int) (bool, error) {
+	query := `DELETE FROM ticket_labels WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "label_id" = $3;`
+
+	res, err := t.Exec(ctx, query, guildId, ticketId, labelId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Set replaces the labels applied to the ticket. IDs of labels belonging to other guilds are ignored.
+func (t *TicketLabels) Set(ctx context.Context, guildId uint64, ticketId int, labelIds []int) error {
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM ticket_labels WHERE "guild_id" = $1 AND "ticket_id" = $2;`, guildId, ticketId); err != nil {
+		return err
+	}
+
+	query := `
+INSERT INTO ticket_labels("guild_id", "ticket_id", "label_id")
+SELECT $1, $2, labels.label_id
+FROM labels
+WHERE labels.guild_id = $1 AND labels.label_id = ANY($3::int4[]);`
+
+	if _, err := tx.Exec(ctx, query, guildId, ticketId, labelIds); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetStats summarises the tickets that the label has been applied to
+func (t *TicketLabels) GetStats(ctx context.Context, guildId uint64, labelId int) (LabelStats, error) {
+	query := `
+SELECT
+	COUNT(*),
+	COUNT(*) FILTER (WHERE tickets.open),
+	AVG(service_ratings.rating)::float8,
+	COUNT(service_ratings.rating),
+	(EXTRACT(EPOCH FROM AVG(tickets.close_time - tickets.open_time) FILTER (WHERE NOT tickets.open)) * 1000)::int8
+FROM ticket_labels
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_labels.guild_id AND tickets.id = ticket_labels.ticket_id
+LEFT OUTER JOIN service_ratings
+	ON service_ratings.guild_id = tickets.guild_id AND service_ratings.ticket_id = tickets.id
+WHERE ticket_labels.guild_id = $1 AND ticket_labels.label_id = $2;`
+
+	var stats LabelStats
+	var durationMillis *int64
+	if err := t.QueryRow(ctx, query, guildId, labelId).Scan(&stats.Total, &stats.Open, &stats.AverageRating, &stats.Ratings, &durationMillis); err != nil {
+		return LabelStats{}, err
+	}
+
+	if durationMillis != nil {
+		duration := time.Duration(*durationMillis) * time.Millisecond
+		stats.AverageDuration = &duration
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,108 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// LinkRelation is how a ticket relates to the ticket it is linked to
+type LinkRelation string
+
+const (
+	// RelationMergedInto is the source ticket of a merge, which was closed
+	RelationMergedInto LinkRelation = "merged_into"
+	// RelationMergedFrom is the target ticket of a merge, which the source ticket's members were moved into
+	RelationMergedFrom LinkRelation = "merged_from"
+	// RelationSplitInto is the ticket that messages were split out of
+	RelationSplitInto LinkRelation = "split_into"
+	// RelationSplitFrom is the new ticket that was opened with the messages split out of another ticket
+	RelationSplitFrom LinkRelation = "split_from"
+)
+
+// Inverse returns the relation of the linked ticket back to the ticket
+func (r LinkRelation) Inverse() LinkRelation {
+	switch r {
+	case RelationMergedInto:
+		return RelationMergedFrom
+	case RelationMergedFrom:
+		return RelationMergedInto
+	case RelationSplitInto:
+		return RelationSplitFrom
+	case RelationSplitFrom:
+		return RelationSplitInto
+	default:
+		return r
+	}
+}
+
+type TicketLink struct {
+	TicketId       int
+	LinkedTicketId int
+	Relation       LinkRelation
+	CreatedAt      time.Time
+}
+
+// TicketLinks stores relationships between tickets in the same guild, such as merges and splits, so that the
+// transcripts of both tickets can reference each other
+type TicketLinks struct {
+	*pgxpool.Pool
+}
+
+func newTicketLinks(db *pgxpool.Pool) *TicketLinks {
+	return &TicketLinks{
+		db,
+	}
+}
+
+func (t TicketLinks) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_links(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"linked_ticket_id" int4 NOT NULL,
+	"relation" varchar(16) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	FOREIGN KEY("guild_id", "linked_ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "linked_ticket_id", "relation")
+);`
+}
+
+// Link records the relation of ticketId to linkedTicketId, and the inverse relation of linkedTicketId to ticketId
+func (t *TicketLinks) Link(ctx context.Context, guildId uint64, ticketId, linkedTicketId int, relation LinkRelation) (err error) {
+	query := `
+INSERT INTO ticket_links("guild_id", "ticket_id", "linked_ticket_id", "relation")
+VALUES($1, $2, $3, $4), ($1, $3, $2, $5)
+ON CONFLICT DO NOTHING;`
+
+	_, err = t.Exec(ctx, query, guildId, ticketId, linkedTicketId, relation, relation.Inverse())
+	return
+}
+
+// GetLinks returns the links of the ticket, oldest first
+func (t *TicketLinks) GetLinks(ctx context.Context, guildId uint64, ticketId int) ([]TicketLink, error) {
+	query := `
+SELECT "ticket_id", "linked_ticket_id", "relation", "created_at"
+FROM ticket_links
+WHERE "guild_id" = $1 AND "ticket_id" = $2
+ORDER BY "created_at" ASC;`
+
+	rows, err := t.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []TicketLink
+	for rows.Next() {
+		var link TicketLink
+		if err := rows.Scan(&link.TicketId, &link.LinkedTicketId, &link.Relation, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
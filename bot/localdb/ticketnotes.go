@@ -0,0 +1,194 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// TicketNote is a private note left on a ticket by a staff member
+type TicketNote struct {
+	Id        int
+	GuildId   uint64
+	TicketId  int
+	AuthorId  uint64
+	Content   string
+	CreatedAt time.Time
+	EditedAt  *time.Time
+}
+
+// TicketNoteRevision is a previous version of a note, replaced when the note was edited
+type TicketNoteRevision struct {
+	NoteId   int
+	Content  string
+	EditedBy uint64
+	EditedAt time.Time
+}
+
+// TicketNotes stores staff notes against tickets, independently of the ticket channel, so that they are available in
+// both channel and thread mode. Edits keep the previous version of the note.
+type TicketNotes struct {
+	*pgxpool.Pool
+}
+
+func newTicketNotes(db *pgxpool.Pool) *TicketNotes {
+	return &TicketNotes{
+		db,
+	}
+}
+
+func (t TicketNotes) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_notes(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"author_id" int8 NOT NULL,
+	"content" text NOT NULL,
+	"created_at" timestamptz NOT NULL,
+	"edited_at" timestamptz,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS ticket_notes_ticket ON ticket_notes("guild_id", "ticket_id");
+CREATE TABLE IF NOT EXISTS ticket_note_revisions(
+	"id" SERIAL NOT NULL UNIQUE,
+	"note_id" int4 NOT NULL,
+	"content" text NOT NULL,
+	"edited_by" int8 NOT NULL,
+	"edited_at" timestamptz NOT NULL,
+	FOREIGN KEY("note_id") REFERENCES ticket_notes("id") ON DELETE CASCADE,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS ticket_note_revisions_note_id ON ticket_note_revisions("note_id");`
+}
+
+func (t *TicketNotes) Create(ctx context.Context, guildId uint64, ticketId int, authorId uint64, content string) (int, error) {
+	query := `
+INSERT INTO ticket_notes("guild_id", "ticket_id", "author_id", "content", "created_at")
+VALUES($1, $2, $3, $4, NOW())
+RETURNING "id";`
+
+	var id int
+	err := t.QueryRow(ctx, query, guildId, ticketId, authorId, content).Scan(&id)
+	return id, err
+}
+
+func (t *TicketNotes) Get(ctx context.Context, guildId uint64, noteId int) (TicketNote, bool, error) {
+	query := `
+SELECT "id", "guild_id", "ticket_id", "author_id", "content", "created_at", "edited_at"
+FROM ticket_notes
+WHERE "guild_id" = $1 AND "id" = $2;`
+
+	var note TicketNote
+	if err := t.QueryRow(ctx, query, guildId, noteId).Scan(
+		&note.Id, &note.GuildId, &note.TicketId, &note.AuthorId, &note.Content, &note.CreatedAt, &note.EditedAt,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return TicketNote{}, false, nil
+		}
+
+		return TicketNote{}, false, err
+	}
+
+	return note, true, nil
+}
+
+// GetByTicket returns the ticket's notes, oldest first
+func (t *TicketNotes) GetByTicket(ctx context.Context, guildId uint64, ticketId int) ([]TicketNote, error) {
+	query := `
+SELECT "id", "guild_id", "ticket_id", "author_id", "content", "created_at", "edited_at"
+FROM ticket_notes
+WHERE "guild_id" = $1 AND "ticket_id" = $2
+ORDER BY "id" ASC;`
+
+	rows, err := t.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []TicketNote
+	for rows.Next() {
+		var note TicketNote
+		if err := rows.Scan(
+			&note.Id, &note.GuildId, &note.TicketId, &note.AuthorId, &note.Content, &note.CreatedAt, &note.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// GetRevisions returns the previous versions of each of the ticket's notes, keyed by note ID, oldest first
+func (t *TicketNotes) GetRevisions(ctx context.Context, guildId uint64, ticketId int) (map[int][]TicketNoteRevision, error) {
+	query := `
+SELECT ticket_note_revisions.note_id, ticket_note_revisions.content, ticket_note_revisions.edited_by, ticket_note_revisions.edited_at
+FROM ticket_note_revisions
+INNER JOIN ticket_notes ON ticket_notes.id = ticket_note_revisions.note_id
+WHERE ticket_notes.guild_id = $1 AND ticket_notes.ticket_id = $2
+ORDER BY ticket_note_revisions.id ASC;`
+
+	rows, err := t.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make(map[int][]TicketNoteRevision)
+	for rows.Next() {
+		var revision TicketNoteRevision
+		if err := rows.Scan(&revision.NoteId, &revision.Content, &revision.EditedBy, &revision.EditedAt); err != nil {
+			return nil, err
+		}
+
+		revisions[revision.NoteId] = append(revisions[revision.NoteId], revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (t *TicketNotes) GetCount(ctx context.Context, guildId uint64, ticketId int) (int, error) {
+	query := `SELECT COUNT(*) FROM ticket_notes WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	var count int
+	err := t.QueryRow(ctx, query, guildId, ticketId).Scan(&count)
+	return count, err
+}
+
+// Edit replaces the content of the note, keeping the previous content as a revision
+func (t *TicketNotes) Edit(ctx context.Context, guildId uint64, noteId int, editorId uint64, content string) error {
+	tx, err := t.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	revisionQuery := `
+INSERT INTO ticket_note_revisions("note_id", "content", "edited_by", "edited_at")
+SELECT "id", "content", $3, NOW()
+FROM ticket_notes
+WHERE "guild_id" = $1 AND "id" = $2;`
+
+	if _, err := tx.Exec(ctx, revisionQuery, guildId, noteId, editorId); err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE ticket_notes SET "content" = $3, "edited_at" = NOW() WHERE "guild_id" = $1 AND "id" = $2;`
+	if _, err := tx.Exec(ctx, updateQuery, guildId, noteId, content); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (t *TicketNotes) Delete(ctx context.Context, guildId uint64, noteId int) (err error) {
+	query := `DELETE FROM ticket_notes WHERE "guild_id" = $1 AND "id" = $2;`
+
+	_, err = t.Exec(ctx, query, guildId, noteId)
+	return
+}
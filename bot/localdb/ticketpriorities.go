@@ -0,0 +1,180 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strings"
+)
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// Priorities are ordered from least to most urgent
+var Priorities = []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+func ParsePriority(raw string) (Priority, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for _, priority := range Priorities {
+		if string(priority) == raw {
+			return priority, true
+		}
+	}
+
+	return "", false
+}
+
+// ChannelPrefix returns the prefix added to the names of ticket channels with this priority, if any
+func (p Priority) ChannelPrefix() string {
+	switch p {
+	case PriorityLow:
+		return "🟢"
+	case PriorityHigh:
+		return "🟠"
+	case PriorityUrgent:
+		return "🔴"
+	default:
+		return ""
+	}
+}
+
+// PendingEscalation is an urgent ticket which has not received a staff response within the guild's escalation time
+type PendingEscalation struct {
+	GuildId   uint64
+	TicketId  int
+	ChannelId uint64
+	RoleId    uint64
+}
+
+// TicketPriorities stores the priority of each ticket. Tickets without a row have the normal priority.
+type TicketPriorities struct {
+	*pgxpool.Pool
+}
+
+func newTicketPriorities(db *pgxpool.Pool) *TicketPriorities {
+	return &TicketPriorities{
+		db,
+	}
+}
+
+func (p TicketPriorities) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_priorities(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"priority" varchar(8) NOT NULL,
+	"set_by" int8,
+	"set_at" timestamptz NOT NULL DEFAULT NOW(),
+	"escalated" bool NOT NULL DEFAULT 'f',
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+CREATE INDEX IF NOT EXISTS ticket_priorities_pending_escalation ON ticket_priorities("set_at") WHERE "priority" = 'urgent' AND NOT "escalated";`
+}
+
+func (p *TicketPriorities) Get(ctx context.Context, guildId uint64, ticketId int) (Priority, error) {
+	query := `SELECT "priority" FROM ticket_priorities WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	var priority Priority
+	if err := p.QueryRow(ctx, query, guildId, ticketId).Scan(&priority); err != nil {
+		if err == pgx.ErrNoRows {
+			return PriorityNormal, nil
+		}
+
+		return "", err
+	}
+
+	return priority, nil
+}
+
+// GetSetBy returns the user who last changed the priority of the ticket, or nil if it was never changed by a user
+func (p *TicketPriorities) GetSetBy(ctx context.Context, guildId uint64, ticketId int) (*uint64, error) {
+	query := `SELECT "set_by" FROM ticket_priorities WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	var setBy *uint64
+	if err := p.QueryRow(ctx, query, guildId, ticketId).Scan(&setBy); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return setBy, nil
+}
+
+// Set changes the priority of the ticket, restarting the escalation timer. setBy is nil if the priority was chosen
+// automatically when the ticket was opened.
+func (p *TicketPriorities) Set(ctx context.Context, guildId uint64, ticketId int, priority Priority, setBy *uint64) (err error) {
+	query := `
+INSERT INTO ticket_priorities("guild_id", "ticket_id", "priority", "set_by", "set_at", "escalated")
+VALUES($1, $2, $3, $4, NOW(), 'f')
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET "priority" = $3, "set_by" = $4, "set_at" = NOW(), "escalated" = 'f';`
+
+	_, err = p.Exec(ctx, query, guildId, ticketId, priority, setBy)
+	return
+}
+
+// GetPendingEscalations returns the open urgent tickets that have not been escalated yet, and which have had no staff
+// response within the escalation time configured by their guild.
+func (p *TicketPriorities) GetPendingEscalations(ctx context.Context) ([]PendingEscalation, error) {
+	query := `
+SELECT ticket_priorities.guild_id, ticket_priorities.ticket_id, tickets.channel_id, priority_settings.escalation_role_id
+FROM ticket_priorities
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_priorities.guild_id AND tickets.id = ticket_priorities.ticket_id
+INNER JOIN priority_settings
+	ON priority_settings.guild_id = ticket_priorities.guild_id
+WHERE ticket_priorities.priority = 'urgent'
+	AND NOT ticket_priorities.escalated
+	AND tickets.open
+	AND tickets.channel_id IS NOT NULL
+	AND priority_settings.escalation_role_id IS NOT NULL
+	AND ticket_priorities.set_at < NOW() - make_interval(mins => priority_settings.escalation_minutes)
+	AND NOT EXISTS(
+		SELECT 1
+		FROM first_response_time
+		WHERE first_response_time.guild_id = ticket_priorities.guild_id
+			AND first_response_time.ticket_id = ticket_priorities.ticket_id
+	);`
+
+	rows, err := p.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var escalations []PendingEscalation
+	for rows.Next() {
+		var escalation PendingEscalation
+		if err := rows.Scan(&escalation.GuildId, &escalation.TicketId, &escalation.ChannelId, &escalation.RoleId); err != nil {
+			return nil, err
+		}
+
+		escalations = append(escalations, escalation)
+	}
+
+	return escalations, rows.Err()
+}
+
+// MarkEscalated returns false if the ticket has already been escalated, or has had its priority changed, so that
+// the escalation role is only pinged once.
+func (p *TicketPriorities) MarkEscalated(ctx context.Context, guildId uint64, ticketId int) (bool, error) {
+	query := `
+UPDATE ticket_priorities
+SET "escalated" = 't'
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "priority" = 'urgent' AND NOT "escalated";`
+
+	res, err := p.Exec(ctx, query, guildId, ticketId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
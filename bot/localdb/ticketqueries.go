@@ -0,0 +1,265 @@
+package localdb
+
+import (
+	"context"
+	"github.com/TicketsBot/common/model"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strings"
+	"time"
+)
+
+// TicketFilter selects open tickets. Nil fields are not filtered on.
+type TicketFilter struct {
+	PanelId      *int
+	OpenerId     *uint64
+	OpenedBefore *time.Time
+	Status       *model.TicketStatus
+	ClaimedBy    *uint64
+	// InactiveSince matches tickets without a message since the given time. Tickets without any messages are
+	// considered to have been active when they were opened.
+	InactiveSince *time.Time
+}
+
+type TicketSummary struct {
+	Id        int
+	ChannelId uint64
+	UserId    uint64
+	OpenTime  time.Time
+}
+
+// TicketSearch selects tickets, both open and closed. Nil fields are not filtered on.
+type TicketSearch struct {
+	TicketId     *int       `json:"ticket_id,omitempty"`
+	OpenerId     *uint64    `json:"opener_id,omitempty"`
+	ClaimedBy    *uint64    `json:"claimed_by,omitempty"`
+	PanelId      *int       `json:"panel_id,omitempty"`
+	OpenedAfter  *time.Time `json:"opened_after,omitempty"`
+	OpenedBefore *time.Time `json:"opened_before,omitempty"`
+	// CloseReason and FormAnswer match tickets containing the text, ignoring case
+	CloseReason *string `json:"close_reason,omitempty"`
+	FormAnswer  *string `json:"form_answer,omitempty"`
+	Rating      *int    `json:"rating,omitempty"`
+}
+
+type TicketSearchResult struct {
+	Id            int
+	ChannelId     *uint64
+	UserId        uint64
+	PanelTitle    *string
+	Open          bool
+	OpenTime      time.Time
+	CloseTime     *time.Time
+	HasTranscript bool
+	ClaimedBy     *uint64
+	CloseReason   *string
+	Rating        *int16
+}
+
+// UserTicketStats summarises the tickets that a user has opened in a guild
+type UserTicketStats struct {
+	Total int
+	Open  int
+	// AverageRating is nil if none of the user's tickets have been rated
+	AverageRating *float64
+	Ratings       int
+}
+
+// TicketQueries runs queries across the tickets tables created by the shared database module. It does not own any
+// tables itself.
+type TicketQueries struct {
+	*pgxpool.Pool
+}
+
+func newTicketQueries(db *pgxpool.Pool) *TicketQueries {
+	return &TicketQueries{
+		db,
+	}
+}
+
+// Find returns up to limit open tickets matching the filter, oldest first
+func (t *TicketQueries) Find(ctx context.Context, guildId uint64, filter TicketFilter, limit int) ([]TicketSummary, error) {
+	query := `
+SELECT tickets.id, tickets.channel_id, tickets.user_id, tickets.open_time
+FROM tickets
+WHERE tickets.guild_id = $1
+	AND tickets.open
+	AND tickets.channel_id IS NOT NULL
+	AND ($2::int4 IS NULL OR tickets.panel_id = $2)
+	AND ($3::int8 IS NULL OR tickets.user_id = $3)
+	AND ($4::timestamptz IS NULL OR tickets.open_time < $4)
+	AND ($5::ticket_status IS NULL OR tickets.status = $5)
+	AND ($6::int8 IS NULL OR EXISTS(
+		SELECT 1
+		FROM ticket_claims
+		WHERE ticket_claims.guild_id = tickets.guild_id
+			AND ticket_claims.ticket_id = tickets.id
+			AND ticket_claims.user_id = $6
+	))
+	AND ($7::timestamptz IS NULL OR COALESCE((
+		SELECT ticket_last_message.last_message_time
+		FROM ticket_last_message
+		WHERE ticket_last_message.guild_id = tickets.guild_id AND ticket_last_message.ticket_id = tickets.id
+	), tickets.open_time) < $7)
+ORDER BY tickets.id ASC
+LIMIT $8;`
+
+	var status *string
+	if filter.Status != nil {
+		tmp := string(*filter.Status)
+		status = &tmp
+	}
+
+	rows, err := t.Query(ctx, query, guildId, filter.PanelId, filter.OpenerId, filter.OpenedBefore, status,
+		filter.ClaimedBy, filter.InactiveSince, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []TicketSummary
+	for rows.Next() {
+		var ticket TicketSummary
+		if err := rows.Scan(&ticket.Id, &ticket.ChannelId, &ticket.UserId, &ticket.OpenTime); err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// Search returns the tickets matching the search, newest first
+func (t *TicketQueries) Search(ctx context.Context, guildId uint64, search TicketSearch, limit, offset int) ([]TicketSearchResult, error) {
+	query := `
+SELECT
+	tickets.id,
+	tickets.channel_id,
+	tickets.user_id,
+	panels.title,
+	tickets.open,
+	tickets.open_time,
+	tickets.close_time,
+	tickets.has_transcript,
+	ticket_claims.user_id,
+	close_reason.close_reason,
+	service_ratings.rating
+FROM tickets
+LEFT OUTER JOIN panels
+	ON panels.panel_id = tickets.panel_id
+LEFT OUTER JOIN ticket_claims
+	ON ticket_claims.guild_id = tickets.guild_id AND ticket_claims.ticket_id = tickets.id
+LEFT OUTER JOIN close_reason
+	ON close_reason.guild_id = tickets.guild_id AND close_reason.ticket_id = tickets.id
+LEFT OUTER JOIN service_ratings
+	ON service_ratings.guild_id = tickets.guild_id AND service_ratings.ticket_id = tickets.id
+WHERE tickets.guild_id = $1
+	AND ($2::int4 IS NULL OR tickets.id = $2)
+	AND ($3::int8 IS NULL OR tickets.user_id = $3)
+	AND ($4::int8 IS NULL OR ticket_claims.user_id = $4)
+	AND ($5::int4 IS NULL OR tickets.panel_id = $5)
+	AND ($6::timestamptz IS NULL OR tickets.open_time >= $6)
+	AND ($7::timestamptz IS NULL OR tickets.open_time < $7)
+	AND ($8::text IS NULL OR close_reason.close_reason ILIKE '%' || $8 || '%')
+	AND ($9::text IS NULL OR EXISTS(
+		SELECT 1
+		FROM ticket_form_answers
+		WHERE ticket_form_answers.guild_id = tickets.guild_id
+			AND ticket_form_answers.ticket_id = tickets.id
+			AND ticket_form_answers.answer ILIKE '%' || $9 || '%'
+	))
+	AND ($10::int2 IS NULL OR service_ratings.rating = $10)
+ORDER BY tickets.id DESC
+LIMIT $11 OFFSET $12;`
+
+	rows, err := t.Query(ctx, query, guildId, search.TicketId, search.OpenerId, search.ClaimedBy, search.PanelId,
+		search.OpenedAfter, search.OpenedBefore, escapeLike(search.CloseReason), escapeLike(search.FormAnswer),
+		search.Rating, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TicketSearchResult
+	for rows.Next() {
+		var result TicketSearchResult
+		if err := rows.Scan(
+			&result.Id,
+			&result.ChannelId,
+			&result.UserId,
+			&result.PanelTitle,
+			&result.Open,
+			&result.OpenTime,
+			&result.CloseTime,
+			&result.HasTranscript,
+			&result.ClaimedBy,
+			&result.CloseReason,
+			&result.Rating,
+		); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// GetUserStats counts the tickets opened by the user, including those that are still open
+func (t *TicketQueries) GetUserStats(ctx context.Context, guildId, userId uint64) (UserTicketStats, error) {
+	query := `
+SELECT
+	COUNT(*),
+	COUNT(*) FILTER (WHERE tickets.open),
+	AVG(service_ratings.rating)::float8,
+	COUNT(service_ratings.rating)
+FROM tickets
+LEFT OUTER JOIN service_ratings
+	ON service_ratings.guild_id = tickets.guild_id AND service_ratings.ticket_id = tickets.id
+WHERE tickets.guild_id = $1 AND tickets.user_id = $2;`
+
+	var stats UserTicketStats
+	err := t.QueryRow(ctx, query, guildId, userId).Scan(&stats.Total, &stats.Open, &stats.AverageRating, &stats.Ratings)
+	return stats, err
+}
+
+// GetRecentIds returns the IDs of the guild's most recent tickets, newest first, optionally only those starting with
+// the prefix
+func (t *TicketQueries) GetRecentIds(ctx context.Context, guildId uint64, prefix string, limit int) ([]int, error) {
+	query := `
+SELECT id
+FROM tickets
+WHERE guild_id = $1 AND id::text LIKE $2 || '%'
+ORDER BY id DESC
+LIMIT $3;`
+
+	rows, err := t.Query(ctx, query, guildId, *escapeLike(&prefix), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the wildcards in a LIKE pattern, so that the text is matched literally
+func escapeLike(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	escaped := likeEscaper.Replace(*s)
+	return &escaped
+}
@@ -0,0 +1,205 @@
+package localdb
+
+import (
+	"context"
+	"github.com/TicketsBot/worker/bot/sla"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// TrackedTicket is an open ticket with an SLA policy, as seen by the SLA evaluator
+type TrackedTicket struct {
+	GuildId   uint64
+	TicketId  int
+	ChannelId uint64
+	OpenTime  time.Time
+	// Responded is true once staff have responded to the ticket for the first time
+	Responded bool
+	// AwaitingSince is when the opener sent a message that staff have not yet responded to, after the first response
+	AwaitingSince *time.Time
+	Policy        SlaPolicy
+}
+
+// SlaBreachStats counts the tracked tickets opened in a period, and how many of those breached any of their targets
+type SlaBreachStats struct {
+	Tickets  int
+	Breached int
+}
+
+// TicketSla stores the state of the SLA clocks of tickets that were opened from a panel with an SLA policy
+type TicketSla struct {
+	*pgxpool.Pool
+}
+
+func newTicketSla(db *pgxpool.Pool) *TicketSla {
+	return &TicketSla{
+		db,
+	}
+}
+
+func (t TicketSla) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_sla(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"responded" bool NOT NULL DEFAULT 'f',
+	"awaiting_since" timestamptz,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);`
+}
+
+func (t *TicketSla) Create(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	query := `INSERT INTO ticket_sla("guild_id", "ticket_id") VALUES($1, $2) ON CONFLICT DO NOTHING;`
+
+	_, err = t.Exec(ctx, query, guildId, ticketId)
+	return
+}
+
+// SetResponded stops the first and next response clocks of the ticket
+func (t *TicketSla) SetResponded(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	query := `
+UPDATE ticket_sla
+SET "responded" = 't', "awaiting_since" = NULL
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND (NOT "responded" OR "awaiting_since" IS NOT NULL);`
+
+	_, err = t.Exec(ctx, query, guildId, ticketId)
+	return
+}
+
+// SetAwaiting starts the next response clock of the ticket, if staff have already responded once and the clock is not
+// already running
+func (t *TicketSla) SetAwaiting(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	query := `
+UPDATE ticket_sla
+SET "awaiting_since" = NOW()
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "responded" AND "awaiting_since" IS NULL;`
+
+	_, err = t.Exec(ctx, query, guildId, ticketId)
+	return
+}
+
+// GetTracked returns every open ticket that has an SLA policy, across all guilds
+func (t *TicketSla) GetTracked(ctx context.Context) ([]TrackedTicket, error) {
+	query := `
+SELECT
+	sla_policies.panel_id,
+	sla_policies.guild_id,
+	sla_policies.first_response_minutes,
+	sla_policies.next_response_minutes,
+	sla_policies.resolution_minutes,
+	sla_policies.warning_percent,
+	sla_policies.staff_channel_id,
+	sla_policies.business_hours,
+	ticket_sla.ticket_id,
+	tickets.channel_id,
+	tickets.open_time,
+	ticket_sla.responded,
+	ticket_sla.awaiting_since
+FROM ticket_sla
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_sla.guild_id AND tickets.id = ticket_sla.ticket_id
+INNER JOIN sla_policies
+	ON sla_policies.panel_id = tickets.panel_id
+WHERE tickets.open AND tickets.channel_id IS NOT NULL;`
+
+	rows, err := t.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tracked []TrackedTicket
+	for rows.Next() {
+		var ticket TrackedTicket
+
+		policy, err := scanSlaPolicy(rows, &ticket.TicketId, &ticket.ChannelId, &ticket.OpenTime, &ticket.Responded, &ticket.AwaitingSince)
+		if err != nil {
+			return nil, err
+		}
+
+		ticket.GuildId = policy.GuildId
+		ticket.Policy = policy
+		tracked = append(tracked, ticket)
+	}
+
+	return tracked, rows.Err()
+}
+
+// GetBreachStats counts the tracked tickets opened since the given time. If userId is non-nil, only the tickets
+// claimed by the user are counted.
+func (t *TicketSla) GetBreachStats(ctx context.Context, guildId uint64, userId *uint64, since time.Time) (SlaBreachStats, error) {
+	query := `
+SELECT
+	COUNT(*),
+	COUNT(*) FILTER (
+		WHERE EXISTS(
+			SELECT 1
+			FROM sla_events
+			WHERE sla_events.guild_id = ticket_sla.guild_id
+				AND sla_events.ticket_id = ticket_sla.ticket_id
+				AND sla_events.stage = 'breach'
+		)
+	)
+FROM ticket_sla
+INNER JOIN tickets
+	ON tickets.guild_id = ticket_sla.guild_id AND tickets.id = ticket_sla.ticket_id
+WHERE ticket_sla.guild_id = $1
+	AND tickets.open_time > $2
+	AND (
+		$3::int8 IS NULL OR EXISTS(
+			SELECT 1
+			FROM ticket_claims
+			WHERE ticket_claims.guild_id = ticket_sla.guild_id
+				AND ticket_claims.ticket_id = ticket_sla.ticket_id
+				AND ticket_claims.user_id = $3
+		)
+	);`
+
+	var stats SlaBreachStats
+	if err := t.QueryRow(ctx, query, guildId, since, userId).Scan(&stats.Tickets, &stats.Breached); err != nil {
+		return SlaBreachStats{}, err
+	}
+
+	return stats, nil
+}
+
+// SlaEvents records the warnings and breaches of SLA clocks
+type SlaEvents struct {
+	*pgxpool.Pool
+}
+
+func newSlaEvents(db *pgxpool.Pool) *SlaEvents {
+	return &SlaEvents{
+		db,
+	}
+}
+
+func (s SlaEvents) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS sla_events(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"kind" varchar(16) NOT NULL,
+	"stage" varchar(8) NOT NULL,
+	"clock_start" timestamptz NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "kind", "stage", "clock_start")
+);`
+}
+
+// Record returns false if the event has already been recorded for this run of the clock, identified by clockStart
+func (s *SlaEvents) Record(ctx context.Context, guildId uint64, ticketId int, kind sla.Kind, stage sla.Stage, clockStart time.Time) (bool, error) {
+	query := `
+INSERT INTO sla_events("guild_id", "ticket_id", "kind", "stage", "clock_start")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;`
+
+	res, err := s.Exec(ctx, query, guildId, ticketId, kind, stage, clockStart)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
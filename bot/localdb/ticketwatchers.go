@@ -0,0 +1,83 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// TicketWatchers stores the staff members following each ticket, who are sent digests of its activity
+type TicketWatchers struct {
+	*pgxpool.Pool
+}
+
+func newTicketWatchers(db *pgxpool.Pool) *TicketWatchers {
+	return &TicketWatchers{
+		db,
+	}
+}
+
+func (t TicketWatchers) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_watchers(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"user_id" int8 NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "user_id")
+);`
+}
+
+func (t *TicketWatchers) IsWatching(ctx context.Context, guildId uint64, ticketId int, userId uint64) (watching bool, err error) {
+	query := `SELECT EXISTS(SELECT 1 FROM ticket_watchers WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "user_id" = $3);`
+	err = t.QueryRow(ctx, query, guildId, ticketId, userId).Scan(&watching)
+	return
+}
+
+// Add returns false if the user was already watching the ticket
+func (t *TicketWatchers) Add(ctx context.Context, guildId uint64, ticketId int, userId uint64) (bool, error) {
+	query := `
+INSERT INTO ticket_watchers("guild_id", "ticket_id", "user_id")
+VALUES($1, $2, $3)
+ON CONFLICT DO NOTHING;`
+
+	res, err := t.Exec(ctx, query, guildId, ticketId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Remove returns false if the user was not watching the ticket
+func (t *TicketWatchers) Remove(ctx context.Context, guildId uint64, ticketId int, userId uint64) (bool, error) {
+	query := `DELETE FROM ticket_watchers WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "user_id" = $3;`
+
+	res, err := t.Exec(ctx, query, guildId, ticketId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (t *TicketWatchers) Get(ctx context.Context, guildId uint64, ticketId int) ([]uint64, error) {
+	query := `SELECT "user_id" FROM ticket_watchers WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	rows, err := t.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIds []uint64
+	for rows.Next() {
+		var userId uint64
+		if err := rows.Scan(&userId); err != nil {
+			return nil, err
+		}
+
+		userIds = append(userIds, userId)
+	}
+
+	return userIds, rows.Err()
+}
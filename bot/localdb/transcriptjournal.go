@@ -0,0 +1,131 @@
+package localdb
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"time"
+)
+
+// JournalEntry is a single version of a message sent in a ticket. Each edit is stored as a new revision, so that the
+// edit history is preserved.
+type JournalEntry struct {
+	MessageId uint64
+	// Revision is the position of the version in the message's edit history, starting from 0
+	Revision  int
+	Message   message.Message
+	DeletedAt *time.Time
+}
+
+// TranscriptJournal records ticket messages as they are received over the gateway, so that the transcript does not
+// have to be fetched from Discord when the ticket is closed.
+type TranscriptJournal struct {
+	*pgxpool.Pool
+}
+
+func newTranscriptJournal(db *pgxpool.Pool) *TranscriptJournal {
+	return &TranscriptJournal{
+		db,
+	}
+}
+
+func (j TranscriptJournal) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS transcript_journal(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"message_id" int8 NOT NULL,
+	"version" timestamptz NOT NULL,
+	"data" jsonb NOT NULL,
+	"deleted_at" timestamptz,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "message_id", "version")
+);`
+}
+
+// Append stores a new revision of the message. Revisions are identified by the time the message was sent or last
+// edited, rather than by counting the existing revisions, so that events for the same message which are handled
+// concurrently cannot overwrite each other, and are ordered correctly even if they are received out of order. Receiving
+// the same version of the message twice is a no-op.
+func (j *TranscriptJournal) Append(ctx context.Context, guildId uint64, ticketId int, msg message.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	version := msg.Timestamp
+	if msg.EditedTimestamp != nil {
+		version = *msg.EditedTimestamp
+	}
+
+	query := `
+INSERT INTO transcript_journal("guild_id", "ticket_id", "message_id", "version", "data")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id", "ticket_id", "message_id", "version") DO NOTHING;`
+
+	_, err = j.Exec(ctx, query, guildId, ticketId, msg.Id, version, data)
+	return err
+}
+
+// MarkDeleted flags every revision of the messages as deleted. Messages that are not in the journal are ignored.
+func (j *TranscriptJournal) MarkDeleted(ctx context.Context, guildId uint64, ticketId int, messageIds []uint64) (err error) {
+	query := `
+UPDATE transcript_journal
+SET "deleted_at" = NOW()
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "message_id" = ANY($3) AND "deleted_at" IS NULL;`
+
+	_, err = j.Exec(ctx, query, guildId, ticketId, messageIds)
+	return
+}
+
+// GetAll returns every revision of every message, ordered by message ID and then revision
+func (j *TranscriptJournal) GetAll(ctx context.Context, guildId uint64, ticketId int) ([]JournalEntry, error) {
+	query := `
+SELECT "message_id", ROW_NUMBER() OVER (PARTITION BY "message_id" ORDER BY "version" ASC) - 1, "data", "deleted_at"
+FROM transcript_journal
+WHERE "guild_id" = $1 AND "ticket_id" = $2
+ORDER BY "message_id" ASC, "version" ASC;`
+
+	rows, err := j.Query(ctx, query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []JournalEntry
+	for rows.Next() {
+		var entry JournalEntry
+		var data []byte
+		if err := rows.Scan(&entry.MessageId, &entry.Revision, &data, &entry.DeletedAt); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &entry.Message); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (j *TranscriptJournal) Delete(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	_, err = j.Exec(ctx, `DELETE FROM transcript_journal WHERE "guild_id" = $1 AND "ticket_id" = $2;`, guildId, ticketId)
+	return
+}
+
+// DeleteClosed removes the journal of every closed ticket. The journal is normally removed when the ticket is closed,
+// but tickets can also be closed without the transcript being collected, such as when the channel is deleted.
+func (j *TranscriptJournal) DeleteClosed(ctx context.Context) (err error) {
+	query := `
+DELETE FROM transcript_journal
+USING tickets
+WHERE transcript_journal."guild_id" = tickets."guild_id"
+	AND transcript_journal."ticket_id" = tickets."id"
+	AND tickets."open" = false;`
+
+	_, err = j.Exec(ctx, query)
+	return
+}
@@ -0,0 +1,65 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TranscriptSettings struct {
+	// Format is the name of the exporter used to render the transcript
+	Format string
+	// AttachToClose attaches the rendered transcript to the close DM and archive channel message
+	AttachToClose bool
+}
+
+// DefaultTranscriptFormat is used for guilds that have not chosen a format
+const DefaultTranscriptFormat = "html"
+
+type TranscriptSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newTranscriptSettingsTable(db *pgxpool.Pool) *TranscriptSettingsTable {
+	return &TranscriptSettingsTable{
+		db,
+	}
+}
+
+func (t TranscriptSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS transcript_settings(
+	"guild_id" int8 NOT NULL,
+	"format" varchar(16) NOT NULL,
+	"attach_to_close" bool NOT NULL DEFAULT 'f',
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (t *TranscriptSettingsTable) Get(ctx context.Context, guildId uint64) (TranscriptSettings, error) {
+	query := `SELECT "format", "attach_to_close" FROM transcript_settings WHERE "guild_id" = $1;`
+
+	var settings TranscriptSettings
+	if err := t.QueryRow(ctx, query, guildId).Scan(&settings.Format, &settings.AttachToClose); err != nil {
+		if err == pgx.ErrNoRows {
+			return TranscriptSettings{
+				Format:        DefaultTranscriptFormat,
+				AttachToClose: false,
+			}, nil
+		}
+
+		return TranscriptSettings{}, err
+	}
+
+	return settings, nil
+}
+
+func (t *TranscriptSettingsTable) Set(ctx context.Context, guildId uint64, settings TranscriptSettings) (err error) {
+	query := `
+INSERT INTO transcript_settings("guild_id", "format", "attach_to_close")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET "format" = $2, "attach_to_close" = $3;`
+
+	_, err = t.Exec(ctx, query, guildId, settings.Format, settings.AttachToClose)
+	return
+}
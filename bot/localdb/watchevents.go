@@ -0,0 +1,114 @@
+package localdb
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// WatchEventKind is the type of ticket activity that watchers are notified of
+type WatchEventKind string
+
+const (
+	// WatchEventMessage is a message sent by the ticket opener
+	WatchEventMessage WatchEventKind = "message"
+	// WatchEventStatus is a change of the ticket's status, with the new status as the data
+	WatchEventStatus WatchEventKind = "status"
+	WatchEventClaim  WatchEventKind = "claim"
+	// WatchEventTransfer is the ticket being claimed on behalf of another staff member, with their ID as the data
+	WatchEventTransfer WatchEventKind = "transfer"
+	WatchEventClose    WatchEventKind = "close"
+)
+
+// WatchEvent is an item of ticket activity, waiting to be sent to a watcher in their next digest
+type WatchEvent struct {
+	Id        int
+	GuildId   uint64
+	TicketId  int
+	UserId    uint64
+	Kind      WatchEventKind
+	ActorId   uint64
+	Data      *string
+	CreatedAt time.Time
+}
+
+// WatchEvents queues ticket activity for watchers, so that it can be sent in batches rather than a DM per event
+type WatchEvents struct {
+	*pgxpool.Pool
+}
+
+func newWatchEvents(db *pgxpool.Pool) *WatchEvents {
+	return &WatchEvents{
+		db,
+	}
+}
+
+func (w WatchEvents) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS watch_events(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"kind" varchar(16) NOT NULL,
+	"actor_id" int8 NOT NULL,
+	"data" varchar(32),
+	"created_at" timestamptz NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("id")
+);`
+}
+
+// Record queues the event for every watcher of the ticket, other than the staff member who caused it
+func (w *WatchEvents) Record(ctx context.Context, guildId uint64, ticketId int, kind WatchEventKind, actorId uint64, data *string) (err error) {
+	query := `
+INSERT INTO watch_events("guild_id", "ticket_id", "user_id", "kind", "actor_id", "data", "created_at")
+SELECT "guild_id", "ticket_id", "user_id", $3, $4, $5, NOW()
+FROM ticket_watchers
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "user_id" != $4;`
+
+	_, err = w.Exec(ctx, query, guildId, ticketId, kind, actorId, data)
+	return
+}
+
+// GetAll returns every queued event, in the order they happened. Events are only removed once they have been sent.
+func (w *WatchEvents) GetAll(ctx context.Context) ([]WatchEvent, error) {
+	query := `
+SELECT "id", "guild_id", "ticket_id", "user_id", "kind", "actor_id", "data", "created_at"
+FROM watch_events
+ORDER BY "id" ASC;`
+
+	rows, err := w.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []WatchEvent
+	for rows.Next() {
+		var event WatchEvent
+		if err := rows.Scan(&event.Id, &event.GuildId, &event.TicketId, &event.UserId, &event.Kind, &event.ActorId, &event.Data, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// Delete removes the events once they have been sent
+func (w *WatchEvents) Delete(ctx context.Context, ids []int) (err error) {
+	query := `DELETE FROM watch_events WHERE "id" = ANY($1);`
+
+	_, err = w.Exec(ctx, query, ids)
+	return
+}
+
+// DeleteBefore removes events that could not be sent before the given time, so that they are not retried forever
+func (w *WatchEvents) DeleteBefore(ctx context.Context, before time.Time) (err error) {
+	query := `DELETE FROM watch_events WHERE "created_at" < $1;`
+
+	_, err = w.Exec(ctx, query, before)
+	return
+}
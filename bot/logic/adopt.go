@@ -0,0 +1,254 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"github.com/TicketsBot/common/collections"
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/rest"
+	"time"
+)
+
+var (
+	ErrAlreadyTicket       = errors.New("channel is already a ticket")
+	ErrChannelNotAdoptable = errors.New("only text channels and threads can be adopted")
+)
+
+// The number of messages read from the channel history when seeding participants
+const adoptHistoryLimit = 1000
+
+type AdoptOptions struct {
+	GuildId   uint64
+	Channel   channel.Channel
+	OpenerId  uint64
+	AdoptedBy uint64
+	PanelId   *int
+}
+
+// AdoptChannel registers an existing channel or thread, that was not created by the bot, as a ticket. It does not
+// modify the channel itself.
+func AdoptChannel(ctx context.Context, opts AdoptOptions) (database.Ticket, error) {
+	isThread := opts.Channel.Type == channel.ChannelTypeGuildPrivateThread || opts.Channel.Type == channel.ChannelTypeGuildPublicThread
+
+	id, err := dbclient.Client.Tickets.Create(ctx, opts.GuildId, opts.OpenerId, isThread, opts.PanelId)
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	if err := dbclient.Client.Tickets.SetChannelId(ctx, opts.GuildId, id, opts.Channel.Id); err != nil {
+		return database.Ticket{}, err
+	}
+
+	var categoryId *uint64
+	if !isThread && !opts.Channel.ParentId.IsNull {
+		categoryId = &opts.Channel.ParentId.Value
+	}
+
+	adopted := localdb.AdoptedChannel{
+		GuildId:    opts.GuildId,
+		TicketId:   id,
+		ChannelId:  opts.Channel.Id,
+		CategoryId: categoryId,
+		AdoptedBy:  opts.AdoptedBy,
+		AdoptedAt:  time.Now(),
+	}
+
+	if err := dbclient.Local.AdoptedChannels.Create(ctx, adopted); err != nil {
+		return database.Ticket{}, err
+	}
+
+	if err := redis.SetTicketChannelStatus(ctx, opts.Channel.Id, true); err != nil {
+		return database.Ticket{}, err
+	}
+
+	return dbclient.Client.Tickets.Get(ctx, id, opts.GuildId)
+}
+
+// IsAdoptableChannel returns whether the channel is a text channel inside a category that the guild has marked as
+// adoptable.
+func IsAdoptableChannel(ctx context.Context, guildId uint64, ch channel.Channel) (bool, error) {
+	if ch.Type != channel.ChannelTypeGuildText || ch.ParentId.IsNull {
+		return false, nil
+	}
+
+	return dbclient.Local.AdoptableCategories.IsAdoptable(ctx, guildId, ch.ParentId.Value)
+}
+
+// FindChannelOpener returns the author of the earliest message in the channel that was not sent by a bot, or false
+// if there is no such message in the first page of history.
+func FindChannelOpener(worker *worker.Context, channelId uint64) (uint64, bool, error) {
+	// Messages after snowflake 1 are returned from the start of the channel
+	msgs, err := worker.GetChannelMessages(channelId, rest.GetChannelMessagesData{
+		After: 1,
+		Limit: 100,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	var openerId, firstMessageId uint64
+	for _, msg := range msgs {
+		if msg.Author.Bot {
+			continue
+		}
+
+		if firstMessageId == 0 || msg.Id < firstMessageId {
+			firstMessageId = msg.Id
+			openerId = msg.Author.Id
+		}
+	}
+
+	return openerId, firstMessageId != 0, nil
+}
+
+// AdoptTicket registers an existing text channel or thread as a ticket for the given opener and sets it up as if the
+// bot had opened it: the ticket permissions are applied, participants are seeded from the channel history and the
+// welcome message is sent. No channel is created.
+func AdoptTicket(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ch channel.Channel,
+	openerId uint64,
+	panel *database.Panel,
+	canManageWebhooks bool,
+) (database.Ticket, error) {
+	rootSpan := sentry.StartSpan(ctx, "Adopt ticket")
+	defer rootSpan.Finish()
+
+	isThread := ch.Type == channel.ChannelTypeGuildPrivateThread || ch.Type == channel.ChannelTypeGuildPublicThread
+	if ch.GuildId != cmd.GuildId() || (ch.Type != channel.ChannelTypeGuildText && !isThread) {
+		return database.Ticket{}, ErrChannelNotAdoptable
+	}
+
+	span := sentry.StartSpan(rootSpan.Context(), "Check for existing ticket")
+	existing, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ch.Id, cmd.GuildId())
+	span.Finish()
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	if existing.Id != 0 {
+		return database.Ticket{}, ErrAlreadyTicket
+	}
+
+	var panelId *int
+	if panel != nil {
+		panelId = &panel.PanelId
+	}
+
+	span = sentry.StartSpan(rootSpan.Context(), "Register ticket")
+	ticket, err := AdoptChannel(ctx, AdoptOptions{
+		GuildId:   cmd.GuildId(),
+		Channel:   ch,
+		OpenerId:  openerId,
+		AdoptedBy: cmd.UserId(),
+		PanelId:   panelId,
+	})
+	span.Finish()
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	if isThread {
+		span = sentry.StartSpan(rootSpan.Context(), "Add opener to thread")
+		err = cmd.Worker().AddThreadMember(ch.Id, openerId)
+		span.Finish()
+	} else {
+		span = sentry.StartSpan(rootSpan.Context(), "Apply permission overwrites")
+		err = applyTicketOverwrites(ctx, cmd, ch.Id, openerId, panel, canManageWebhooks)
+		span.Finish()
+	}
+
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	span = sentry.StartSpan(rootSpan.Context(), "Seed participants")
+	err = seedParticipants(ctx, cmd, ticket)
+	span.Finish()
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	span = sentry.StartSpan(rootSpan.Context(), "Send welcome message")
+	welcomeMessageId, err := SendWelcomeMessage(ctx, cmd, ticket, "", panel, nil, nil)
+	span.Finish()
+	if err != nil {
+		return database.Ticket{}, err
+	}
+
+	if err := dbclient.Client.Tickets.SetMessageIds(ctx, cmd.GuildId(), ticket.Id, welcomeMessageId, nil); err != nil {
+		return database.Ticket{}, err
+	}
+
+	ticket.WelcomeMessageId = &welcomeMessageId
+
+	if cmd.PremiumTier() > premium.None && !isThread {
+		if err := createWebhook(rootSpan.Context(), cmd, ticket.Id, cmd.GuildId(), ch.Id); err != nil {
+			return database.Ticket{}, err
+		}
+	}
+
+	return ticket, nil
+}
+
+func applyTicketOverwrites(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	channelId, openerId uint64,
+	panel *database.Panel,
+	canManageWebhooks bool,
+) error {
+	overwrites, err := BuildOverwrites(ctx, cmd, canManageWebhooks, openerId, panel)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Worker().ModifyChannel(channelId, rest.ModifyChannelData{
+		PermissionOverwrites: overwrites,
+	})
+
+	return err
+}
+
+// seedParticipants records everyone who has sent a message in the recent channel history as a participant, as the
+// gateway never saw these messages as ticket messages.
+func seedParticipants(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) error {
+	participants := collections.NewSet[uint64]()
+
+	var lastId uint64
+	for read := 0; read < adoptHistoryLimit; {
+		msgs, err := cmd.Worker().GetChannelMessages(*ticket.ChannelId, rest.GetChannelMessagesData{
+			Before: lastId,
+			Limit:  100,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			participants.Add(msg.Author.Id)
+		}
+
+		if len(msgs) < 100 {
+			break
+		}
+
+		read += len(msgs)
+		lastId = msgs[len(msgs)-1].Id
+	}
+
+	if participants.Size() == 0 {
+		return nil
+	}
+
+	return dbclient.Client.Participants.SetBulk(ctx, cmd.GuildId(), ticket.Id, participants.Collect())
+}
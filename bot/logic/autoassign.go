@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"context"
+	"sort"
+
+	"github.com/TicketsBot/common/collections"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/rest"
+)
+
+// autoAssignTicket assigns a newly opened ticket to a staff member, if the panel has auto assignment enabled
+func autoAssignTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, panel *database.Panel) error {
+	if panel == nil {
+		return nil
+	}
+
+	settings, ok, err := dbclient.Local.AutoAssign.Get(ctx, panel.PanelId)
+	if err != nil || !ok {
+		return err
+	}
+
+	assignee, ok, err := chooseAssignee(ctx, cmd.Worker(), ticket, panel, settings)
+	if err != nil || !ok {
+		return err
+	}
+
+	return assignTicket(ctx, cmd, ticket, settings, assignee)
+}
+
+// ReassignTicket assigns the ticket to another staff member, when the current assignee is no longer available. If
+// there is nobody else to assign the ticket to, it is unclaimed, so that any staff member can pick it up.
+func ReassignTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, previous uint64) error {
+	if ticket.PanelId == nil {
+		return nil
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+	if err != nil {
+		return err
+	}
+
+	settings, ok, err := dbclient.Local.AutoAssign.Get(ctx, *ticket.PanelId)
+	if err != nil || !ok || panel.PanelId == 0 {
+		return err
+	}
+
+	assignee, ok, err := chooseAssignee(ctx, cmd.Worker(), ticket, &panel, settings, previous)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		return assignTicket(ctx, cmd, ticket, settings, assignee)
+	}
+
+	if err := dbclient.Client.TicketClaims.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
+		return err
+	}
+
+	if !ticket.IsThread {
+		// The ticket was not opened by this interaction, so the permissions granted to the bot are not known
+		overwrites, err := BuildOverwrites(ctx, cmd, false, ticket.UserId, &panel)
+		if err != nil {
+			return err
+		}
+
+		if _, err := cmd.Worker().ModifyChannel(*ticket.ChannelId, rest.ModifyChannelData{PermissionOverwrites: overwrites}); err != nil {
+			return err
+		}
+	}
+
+	_, err = cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, utils.BuildEmbed(cmd, customisation.Orange, i18n.TitleAutoAssign, i18n.MessageAutoAssignUnassigned, nil, previous))
+	return err
+}
+
+// assignTicket claims the ticket on behalf of the assignee. Thread tickets cannot be claimed, so the assignee is
+// recorded as the claimer and added to the thread instead.
+func assignTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, settings localdb.AutoAssignSettings, assignee uint64) error {
+	if ticket.IsThread {
+		if err := dbclient.Client.TicketClaims.Set(ctx, ticket.GuildId, ticket.Id, assignee); err != nil {
+			return err
+		}
+
+		if err := cmd.Worker().AddThreadMember(*ticket.ChannelId, assignee); err != nil {
+			return err
+		}
+
+		// ClaimTicket notifies watchers itself
+		if err := dbclient.Local.WatchEvents.Record(ctx, ticket.GuildId, ticket.Id, localdb.WatchEventClaim, assignee, nil); err != nil {
+			return err
+		}
+	} else {
+		if err := ClaimTicket(ctx, cmd, ticket, assignee); err != nil {
+			return err
+		}
+	}
+
+	if err := dbclient.Local.AutoAssign.SetLastAssigned(ctx, settings.PanelId, assignee); err != nil {
+		return err
+	}
+
+	_, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, utils.BuildEmbed(cmd, customisation.Green, i18n.TitleAutoAssign, i18n.MessageAutoAssigned, nil, assignee))
+	return err
+}
+
+// chooseAssignee returns false if there are no eligible staff members, other than those excluded
+func chooseAssignee(
+	ctx context.Context,
+	worker *worker.Context,
+	ticket database.Ticket,
+	panel *database.Panel,
+	settings localdb.AutoAssignSettings,
+	exclude ...uint64,
+) (uint64, bool, error) {
+	var candidates []uint64
+	var err error
+	if settings.OnCallOnly {
+		candidates, err = dbclient.Client.OnCall.GetUsersOnCall(ctx, ticket.GuildId)
+	} else {
+		candidates, err = getStaffCandidates(ctx, worker, ticket.GuildId, panel)
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	eligible := make([]uint64, 0, len(candidates))
+	for _, userId := range candidates {
+		if !utils.Contains(exclude, userId) {
+			eligible = append(eligible, userId)
+		}
+	}
+
+	// Members who have left the guild, lost their staff roles, or are not on a team assigned to the panel are removed
+	staff, err := FilterStaffMembers(ctx, worker, ticket.GuildId, ticket, eligible, true, true)
+	if err != nil {
+		return 0, false, err
+	}
+
+	staff, err = ExcludeAwayStaff(ctx, ticket.GuildId, staff)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(staff) == 0 {
+		return 0, false, nil
+	}
+
+	staff = inTurnOrder(staff, settings.LastAssignedId)
+
+	if settings.Strategy != localdb.AssignLeastLoaded {
+		return staff[0], true, nil
+	}
+
+	counts, err := dbclient.Local.AutoAssign.GetOpenClaimCounts(ctx, ticket.GuildId, staff)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Staff are in turn order, so ties go to whoever is next in turn
+	assignee := staff[0]
+	for _, userId := range staff[1:] {
+		if counts[userId] < counts[assignee] {
+			assignee = userId
+		}
+	}
+
+	return assignee, true, nil
+}
+
+// inTurnOrder sorts the staff members by ID, starting from the member after the one who was last assigned
+func inTurnOrder(staff []uint64, lastAssigned *uint64) []uint64 {
+	sort.Slice(staff, func(i, j int) bool {
+		return staff[i] < staff[j]
+	})
+
+	if lastAssigned == nil {
+		return staff
+	}
+
+	next := sort.Search(len(staff), func(i int) bool {
+		return staff[i] > *lastAssigned
+	})
+
+	ordered := make([]uint64, 0, len(staff))
+	ordered = append(ordered, staff[next:]...)
+	return append(ordered, staff[:next]...)
+}
+
+// getStaffCandidates returns the users who may be able to handle tickets from the panel. Members with staff roles are
+// only found if they are in the member cache. The candidates must still be filtered with FilterStaffMembers.
+func getStaffCandidates(ctx context.Context, worker *worker.Context, guildId uint64, panel *database.Panel) ([]uint64, error) {
+	users, roles, err := GetAllowedStaffUsersAndRoles(ctx, guildId, panel)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := collections.NewSet[uint64]()
+	for _, userId := range users {
+		candidates.Add(userId)
+	}
+
+	if len(roles) > 0 {
+		members, err := worker.Cache.GetGuildMembers(ctx, guildId, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			if utils.HasIntersection(roles, member.Roles) {
+				candidates.Add(member.User.Id)
+			}
+		}
+	}
+
+	return candidates.Collect(), nil
+}
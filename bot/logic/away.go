@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/rest"
+)
+
+// ExcludeAwayStaff removes the staff members who are currently away
+func ExcludeAwayStaff(ctx context.Context, guildId uint64, userIds []uint64) ([]uint64, error) {
+	if len(userIds) == 0 {
+		return userIds, nil
+	}
+
+	statuses, err := dbclient.Local.StaffAway.GetMulti(ctx, guildId, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	away := make([]uint64, len(statuses))
+	for i, status := range statuses {
+		away[i] = status.UserId
+	}
+
+	available := make([]uint64, 0, len(userIds))
+	for _, userId := range userIds {
+		if !utils.Contains(away, userId) {
+			available = append(available, userId)
+		}
+	}
+
+	return available, nil
+}
+
+// NotifyAwayMentions posts a notice in the ticket for each mentioned staff member who is away, so that the author
+// knows not to wait for them. Each staff member is only announced once an hour per ticket.
+func NotifyAwayMentions(ctx context.Context, worker *worker.Context, ticket database.Ticket, authorId uint64, mentioned []uint64) error {
+	if len(mentioned) == 0 || ticket.ChannelId == nil {
+		return nil
+	}
+
+	statuses, err := dbclient.Local.StaffAway.GetMulti(ctx, ticket.GuildId, mentioned)
+	if err != nil || len(statuses) == 0 {
+		return err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, customisation.Orange, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	title := i18n.GetMessageFromGuild(ticket.GuildId, i18n.TitleAway)
+
+	for _, status := range statuses {
+		if status.UserId == authorId {
+			continue
+		}
+
+		ok, err := redis.TakeAwayNoticeToken(ctx, *ticket.ChannelId, status.UserId)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		var content string
+		if status.Note == nil {
+			content = i18n.GetMessageFromGuild(ticket.GuildId, i18n.MessageAwayNotice, status.UserId, status.Until.Unix())
+		} else {
+			content = i18n.GetMessageFromGuild(ticket.GuildId, i18n.MessageAwayNoticeWithNote, status.UserId, status.Until.Unix(), *status.Note)
+		}
+
+		data := rest.CreateMessageData{
+			Embeds: utils.Embeds(utils.BuildEmbedRaw(colour, title, content, nil, premiumTier)),
+		}
+
+		if _, err := worker.CreateMessageComplex(*ticket.ChannelId, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
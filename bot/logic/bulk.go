@@ -0,0 +1,194 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/google/uuid"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+)
+
+type BulkAction string
+
+const (
+	BulkActionClose BulkAction = "close"
+	BulkActionClaim BulkAction = "claim"
+	BulkActionMove  BulkAction = "move"
+	BulkActionTag   BulkAction = "tag"
+)
+
+const (
+	// BulkConfirmCustomIdPrefix and BulkCancelCustomIdPrefix are followed by the ID of the stored bulk operation
+	BulkConfirmCustomIdPrefix = "bulk_confirm_"
+	BulkCancelCustomIdPrefix  = "bulk_cancel_"
+
+	// BulkTicketLimit is the most tickets that a single bulk operation can act on
+	BulkTicketLimit = 100
+)
+
+// BulkOperation is a bulk action that has been previewed, and is waiting to be confirmed. The tickets are selected
+// when the preview is generated, so that exactly the previewed tickets are acted on.
+type BulkOperation struct {
+	GuildId   uint64     `json:"guild_id"`
+	UserId    uint64     `json:"user_id"`
+	Action    BulkAction `json:"action"`
+	TicketIds []int      `json:"ticket_ids"`
+	Reason    *string    `json:"reason,omitempty"`
+	ClaimerId uint64     `json:"claimer_id,omitempty"`
+	PanelId   int        `json:"panel_id,omitempty"`
+	TagId     string     `json:"tag_id,omitempty"`
+}
+
+// StoreBulkOperation stores the operation until it is confirmed, returning its ID
+func StoreBulkOperation(ctx context.Context, op BulkOperation) (string, error) {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.New().String()
+	if err := redis.SetBulkOperation(ctx, id, data); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// TakeBulkOperation returns false if the operation has expired, or has already been confirmed or cancelled
+func TakeBulkOperation(ctx context.Context, id string) (BulkOperation, bool, error) {
+	data, err := redis.TakeBulkOperation(ctx, id)
+	if err != nil {
+		if errors.Is(err, redis.ErrBulkOperationNotFound) {
+			return BulkOperation{}, false, nil
+		}
+
+		return BulkOperation{}, false, err
+	}
+
+	var op BulkOperation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return BulkOperation{}, false, err
+	}
+
+	return op, true, nil
+}
+
+// BulkRunner applies a bulk operation to each of its tickets. Anything shared between the tickets, such as the target
+// panel or tag, is loaded once when the runner is created.
+type BulkRunner struct {
+	op    BulkOperation
+	panel *database.Panel
+	tag   *database.Tag
+}
+
+// NewBulkRunner returns false if the target panel or tag has been deleted since the operation was previewed
+func NewBulkRunner(ctx context.Context, op BulkOperation) (*BulkRunner, bool, error) {
+	runner := &BulkRunner{
+		op: op,
+	}
+
+	switch op.Action {
+	case BulkActionMove:
+		panel, err := dbclient.Client.Panel.GetById(ctx, op.PanelId)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != op.GuildId {
+			return nil, false, nil
+		}
+
+		runner.panel = &panel
+	case BulkActionTag:
+		tag, ok, err := dbclient.Client.Tag.Get(ctx, op.GuildId, op.TagId)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		runner.tag = &tag
+	}
+
+	return runner, true, nil
+}
+
+// Run applies the operation to the ticket, returning false if the ticket was skipped. cmd must be a context for the
+// ticket's channel, acting as the user who confirmed the operation.
+func (r *BulkRunner) Run(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) (bool, error) {
+	switch r.op.Action {
+	case BulkActionClose:
+		if err := CloseTicket(ctx, cmd, r.op.Reason, true); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	case BulkActionClaim:
+		// Thread tickets cannot be claimed
+		if ticket.IsThread {
+			return false, nil
+		}
+
+		if err := ClaimTicket(ctx, cmd, ticket, r.op.ClaimerId); err != nil {
+			return false, err
+		}
+
+		e := utils.BuildEmbed(cmd, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", r.op.ClaimerId))
+		if _, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, e); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	case BulkActionMove:
+		if ticket.PanelId != nil && *ticket.PanelId == r.panel.PanelId {
+			return false, nil
+		}
+
+		// Discord only allows channels to be renamed twice every 10 minutes
+		allowed, err := redis.TakeRenameRatelimit(ctx, *ticket.ChannelId)
+		if err != nil || !allowed {
+			return false, err
+		}
+
+		if err := SwitchTicketPanel(ctx, cmd, ticket, r.panel); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	case BulkActionTag:
+		return true, r.sendTag(ctx, cmd, ticket)
+	default:
+		return false, fmt.Errorf("unknown bulk action: %s", r.op.Action)
+	}
+}
+
+func (r *BulkRunner) sendTag(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) error {
+	content := DoPlaceholderSubstitutions(ctx, utils.ValueOrZero(r.tag.Content), cmd.Worker(), ticket, nil)
+
+	var embeds []*embed.Embed
+	if r.tag.Embed != nil {
+		embeds = []*embed.Embed{
+			BuildCustomEmbed(ctx, cmd.Worker(), ticket, *r.tag.Embed.CustomEmbed, r.tag.Embed.Fields, false, nil),
+		}
+	}
+
+	data := rest.CreateMessageData{
+		Content: content,
+		Embeds:  embeds,
+		AllowedMentions: message.AllowedMention{
+			Users: []uint64{ticket.UserId},
+		},
+	}
+
+	_, err := cmd.Worker().CreateMessageComplex(*ticket.ChannelId, data)
+	return err
+}
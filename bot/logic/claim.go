@@ -4,20 +4,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/TicketsBot/common/sentry"
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel"
 	"github.com/rxdn/gdl/permission"
 	"github.com/rxdn/gdl/rest"
 	"golang.org/x/sync/errgroup"
+	"strconv"
 )
 
 // ClaimTicket TODO: Keep /add members
 func ClaimTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, userId uint64) error {
+	return claimTicket(ctx, cmd, ticket, userId, localdb.WatchEventClaim, userId, nil)
+}
+
+// TransferTicket claims the ticket on behalf of another staff member, and notifies watchers of the transfer rather
+// than of a claim
+func TransferTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, userId uint64) error {
+	target := strconv.FormatUint(userId, 10)
+	return claimTicket(ctx, cmd, ticket, userId, localdb.WatchEventTransfer, cmd.UserId(), &target)
+}
+
+func claimTicket(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	userId uint64,
+	eventKind localdb.WatchEventKind,
+	actorId uint64,
+	eventData *string,
+) error {
 	if ticket.ChannelId == nil {
 		return errors.New("channel ID is nil")
 	}
@@ -69,6 +91,11 @@ func ClaimTicket(ctx context.Context, cmd registry.CommandContext, ticket databa
 		}
 	}
 
+	// The ticket has already been claimed, so failing to notify watchers is not fatal
+	if err := dbclient.Local.WatchEvents.Record(ctx, ticket.GuildId, ticket.Id, eventKind, actorId, eventData); err != nil {
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+
 	return nil
 }
 
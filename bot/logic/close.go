@@ -11,12 +11,13 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
 	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/transcript"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel/embed"
-	"github.com/rxdn/gdl/objects/channel/message"
 	"github.com/rxdn/gdl/objects/member"
 	"github.com/rxdn/gdl/rest"
 	"github.com/rxdn/gdl/rest/request"
@@ -34,26 +35,19 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		return err
 	}
 
-	// Check if it's a valid ticket or in an allowed category
+	// Channels in adoptable categories were not opened by the bot, so they are registered as a ticket first, in order
+	// for the transcript, close reason and close embed to be stored against a real ticket.
 	if ticket.Id == 0 || ticket.GuildId != cmd.GuildId() {
-		// Fetch the channel details
-		channel, err := cmd.Worker().GetChannel(cmd.ChannelId())
+		adopted, ok, err := adoptForClose(ctx, cmd, bypassPermissionCheck)
 		if err != nil {
 			return err
 		}
 
-		allowedCategories := map[uint64]bool{
-			1176986720772833421: true, // General Inquiry Tickets
-			1250633639545536523: true, // Internal Affair Inquiries
-			1139516721166827531: true, // Rx queue
-			1191433020868132924: true, // Partnership Inquiry
-		}
-
-		// Check if the channel is in an allowed category
-		if channel.ParentId.IsNull || !allowedCategories[channel.ParentId.Value] {
-			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		if !ok {
 			return nil
 		}
+
+		ticket = adopted
 	}
 
 	defer func() {
@@ -97,43 +91,22 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 	}
 
 	// Archive
+	var transcriptFile, staffTranscriptFile *transcript.File
 	if settings.StoreTranscripts {
-		msgs := make([]message.Message, 0, 50)
-
-		const limit = 100
-
-		lastId := uint64(0)
-		lastChunkSize := limit
-		for lastChunkSize == limit {
-			chunk, err := cmd.Worker().GetChannelMessages(cmd.ChannelId(), rest.GetChannelMessagesData{
-				Before: lastId,
-				Limit:  limit,
-			})
-
-			if err != nil {
-				// First rest interaction, check for 403
-				var restError request.RestError
-				if errors.As(err, &restError) && restError.StatusCode == 403 {
-					if err := dbclient.Client.AutoCloseExclude.ExcludeAll(ctx, cmd.GuildId()); err != nil {
-						sentry.ErrorWithContext(err, errorContext)
-					}
+		history, err := collectTranscript(ctx, cmd, ticket)
+		if err != nil {
+			// First rest interaction, check for 403
+			var restError request.RestError
+			if errors.As(err, &restError) && restError.StatusCode == 403 {
+				if err := dbclient.Client.AutoCloseExclude.ExcludeAll(ctx, cmd.GuildId()); err != nil {
+					sentry.ErrorWithContext(err, errorContext)
 				}
-
-				return err
 			}
 
-			lastChunkSize = len(chunk)
-
-			if lastChunkSize > 0 {
-				lastId = chunk[len(chunk)-1].Id
-				msgs = append(msgs, chunk...)
-			}
+			return err
 		}
 
-		// Reverse messages
-		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
-			msgs[i], msgs[j] = msgs[j], msgs[i]
-		}
+		msgs := history.Messages
 
 		// Update participants, incase the websocket gateway missed any messages
 		participants := collections.NewSet[uint64]()
@@ -145,13 +118,66 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 			return err
 		}
 
-		if err := utils.ArchiverClient.Store(ctx, cmd.GuildId(), ticket.Id, msgs); err != nil {
+		transcriptSettings, err := dbclient.Local.TranscriptSettings.Get(ctx, cmd.GuildId())
+		if err != nil {
 			return err
 		}
 
-		if err := dbclient.Client.Tickets.SetHasTranscript(ctx, cmd.GuildId(), ticket.Id, true); err != nil {
+		if utils.TranscriptSink != nil || transcriptSettings.AttachToClose {
+			file, err := renderTranscript(ctx, cmd, history, participants.Collect(), transcriptSettings.Format)
+			if err != nil {
+				return err
+			}
+
+			if transcriptSettings.AttachToClose {
+				transcriptFile = &file
+				staffTranscriptFile = &file
+			}
+
+			if utils.TranscriptSink != nil {
+				if err := utils.TranscriptSink.Store(ctx, cmd.GuildId(), ticket.Id, file); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Staff notes are never included in the transcript that the opener can access. Instead, a separate staff copy
+		// is always attached to the archive channel message, and stored alongside the transcript by the sink. The
+		// archiver only holds a single transcript per ticket, so when it is in use, the archive channel copy is the only
+		// transcript containing notes.
+		notes, err := collectStaffNotes(ctx, ticket)
+		if err != nil {
 			return err
 		}
+
+		if len(notes) > 0 {
+			history.Notes = notes
+
+			staffFile, err := renderTranscript(ctx, cmd, history, participants.Collect(), transcriptSettings.Format)
+			if err != nil {
+				return err
+			}
+
+			staffTranscriptFile = &staffFile
+
+			if utils.TranscriptSink != nil {
+				if err := utils.TranscriptSink.Store(ctx, cmd.GuildId(), ticket.Id, staffFile); err != nil {
+					return err
+				}
+			}
+		}
+
+		// has_transcript tells the dashboard that the archiver holds a transcript it can show, so is not set when the
+		// transcript was only stored by a sink
+		if utils.TranscriptSink == nil {
+			if err := utils.ArchiverClient.Store(ctx, cmd.GuildId(), ticket.Id, transcript.ArchiverMessages(history)); err != nil {
+				return err
+			}
+
+			if err := dbclient.Client.Tickets.SetHasTranscript(ctx, cmd.GuildId(), ticket.Id, true); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Set ticket state as closed and delete channel
@@ -159,6 +185,11 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		return err
 	}
 
+	// The journal is only needed until the transcript has been stored
+	if err := dbclient.Local.TranscriptJournal.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
+		sentry.ErrorWithContext(err, errorContext)
+	}
+
 	success = true
 	ticket.CloseTime = utils.Ptr(time.Now())
 
@@ -175,6 +206,10 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		return err
 	}
 
+	if err := dbclient.Local.WatchEvents.Record(ctx, ticket.GuildId, ticket.Id, localdb.WatchEventClose, cmd.UserId(), nil); err != nil {
+		sentry.ErrorWithContext(err, errorContext)
+	}
+
 	if ticket.IsThread {
 		// If it is a thread, we need to send a message
 		if reason == nil {
@@ -235,14 +270,14 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		}
 	}
 
-	if err := sendCloseEmbed(ctx, cmd, errorContext, member, settings, ticket, reason); err != nil {
+	if err := sendCloseEmbed(ctx, cmd, errorContext, member, settings, ticket, reason, transcriptFile, staffTranscriptFile); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorContext sentry.ErrorContext, member member.Member, settings database.Settings, ticket database.Ticket, reason *string) error {
+func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorContext sentry.ErrorContext, member member.Member, settings database.Settings, ticket database.Ticket, reason *string, transcriptFile, staffTranscriptFile *transcript.File) error {
 	// Send logs to archive channel
 	archiveChannelId, err := dbclient.Client.ArchiveChannel.Get(ctx, ticket.GuildId)
 	if err != nil {
@@ -272,6 +307,10 @@ func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorConte
 			Components: closeComponents,
 		}
 
+		if staffTranscriptFile != nil {
+			data.Attachments = utils.Slice(staffTranscriptFile.Attachment())
+		}
+
 		msg, err := cmd.Worker().CreateMessageComplex(*archiveChannelId, data)
 		if err != nil {
 			sentry.ErrorWithContext(err, errorContext)
@@ -359,6 +398,10 @@ func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorConte
 			Components: closeComponents,
 		}
 
+		if transcriptFile != nil {
+			data.Attachments = utils.Slice(transcriptFile.Attachment())
+		}
+
 		if _, err := cmd.Worker().CreateMessageComplex(dmChannel, data); err != nil {
 			sentry.ErrorWithContext(err, errorContext)
 			return err
@@ -368,6 +411,58 @@ func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorConte
 	return nil
 }
 
+// adoptForClose returns false if the channel cannot be adopted, in which case a response has already been sent
+func adoptForClose(ctx context.Context, cmd registry.CommandContext, bypassPermissionCheck bool) (database.Ticket, bool, error) {
+	ch, err := cmd.Worker().GetChannel(cmd.ChannelId())
+	if err != nil {
+		return database.Ticket{}, false, err
+	}
+
+	adoptable, err := IsAdoptableChannel(ctx, cmd.GuildId(), ch)
+	if err != nil {
+		return database.Ticket{}, false, err
+	}
+
+	if !adoptable {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return database.Ticket{}, false, nil
+	}
+
+	// We don't know who opened the channel, so only staff can close it
+	if !bypassPermissionCheck {
+		permLevel, err := cmd.UserPermissionLevel(ctx)
+		if err != nil {
+			return database.Ticket{}, false, err
+		}
+
+		if permLevel < permission.Support {
+			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+			return database.Ticket{}, false, nil
+		}
+	}
+
+	openerId, found, err := FindChannelOpener(cmd.Worker(), ch.Id)
+	if err != nil {
+		return database.Ticket{}, false, err
+	}
+
+	if !found {
+		openerId = cmd.UserId()
+	}
+
+	ticket, err := AdoptChannel(ctx, AdoptOptions{
+		GuildId:   cmd.GuildId(),
+		Channel:   ch,
+		OpenerId:  openerId,
+		AdoptedBy: cmd.UserId(),
+	})
+	if err != nil {
+		return database.Ticket{}, false, err
+	}
+
+	return ticket, true, nil
+}
+
 func getDmChannel(ctx registry.CommandContext, userId uint64) (uint64, bool) {
 	// Hack for autoclose
 	if ctx.Worker().BotId == userId {
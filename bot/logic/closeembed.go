@@ -27,8 +27,10 @@ func NoopElement() CloseEmbedElement {
 	}
 }
 
+// TranscriptLinkElement links to the dashboard's transcript viewer, which reads transcripts from the archiver. When
+// transcripts are stored by a sink instead, the archiver never receives them, so no link is shown.
 func TranscriptLinkElement(condition bool) CloseEmbedElement {
-	if !condition {
+	if !condition || utils.TranscriptSink != nil {
 		return NoopElement()
 	}
 
@@ -179,6 +181,15 @@ func BuildCloseEmbed(
 		closeEmbed = closeEmbed.AddField(formatTitle("Rating", customisation.EmojiRating, worker.IsWhitelabel), fmt.Sprintf("%d ⭐", *rating), true)
 	}
 
+	labels, err := GetFormattedTicketLabels(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		sentry.Error(err)
+	}
+
+	if labels != "" {
+		closeEmbed = closeEmbed.AddField(formatTitle("Labels", customisation.EmojiSubject, worker.IsWhitelabel), utils.StringMax(labels, 1021, "..."), false)
+	}
+
 	closeEmbed = closeEmbed.AddField(formatTitle("Reason", customisation.EmojiReason, worker.IsWhitelabel), formattedReason, false)
 
 	var rows []component.Component
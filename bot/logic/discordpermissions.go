@@ -2,6 +2,7 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker/bot/command/registry"
@@ -175,6 +176,75 @@ func CreateOnCallRole(ctx context.Context, cmd registry.CommandContext, team *da
 	return role.Id, nil
 }
 
+// AssignOnCallRole gives the user the on call role of the team, or of the default team if team is nil. If the role
+// does not exist yet, or has been deleted, it is created.
+func AssignOnCallRole(ctx context.Context, cmd registry.CommandContext, userId uint64, roleId *uint64, team *database.SupportTeam) error {
+	return assignOnCallRole(ctx, cmd, userId, roleId, team, 0)
+}
+
+// Attempt counter to prevent infinite loop
+func assignOnCallRole(ctx context.Context, cmd registry.CommandContext, userId uint64, roleId *uint64, team *database.SupportTeam, attempt int) error {
+	if attempt >= 2 {
+		return errors.New("reached retry limit")
+	}
+
+	// Create role if it does not exist  yet
+	if roleId == nil {
+		tmp, err := CreateOnCallRole(ctx, cmd, team)
+		if err != nil {
+			return err
+		}
+
+		roleId = &tmp
+	}
+
+	if err := cmd.Worker().AddGuildMemberRole(cmd.GuildId(), userId, *roleId); err != nil {
+		// If role was deleted, recreate it
+		if err, ok := err.(request.RestError); ok && err.StatusCode == 404 && err.ApiError.Message == "Unknown Role" {
+			if team == nil {
+				if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, cmd.GuildId(), nil); err != nil {
+					return err
+				}
+			} else {
+				if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
+					return err
+				}
+			}
+
+			return assignOnCallRole(ctx, cmd, userId, nil, team, attempt+1)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveOnCallRole removes the on call role of the team, or of the default team if team is nil, from the user
+func RemoveOnCallRole(ctx context.Context, cmd registry.CommandContext, userId uint64, team *database.SupportTeam) error {
+	var roleId *uint64
+	if team == nil {
+		metadata, err := dbclient.Client.GuildMetadata.Get(ctx, cmd.GuildId())
+		if err != nil {
+			return err
+		}
+
+		roleId = metadata.OnCallRole
+	} else {
+		roleId = team.OnCallRole
+	}
+
+	if roleId == nil {
+		return nil
+	}
+
+	if err := cmd.Worker().RemoveGuildMemberRole(cmd.GuildId(), userId, *roleId); err != nil && !isUnknownRoleError(err) {
+		return err
+	}
+
+	return nil
+}
+
 func isUnknownRoleError(err error) bool {
 	if err, ok := err.(request.RestError); ok && err.ApiError.Message == "Unknown Role" {
 		return true
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"errors"
+
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/rxdn/gdl/rest/request"
+)
+
+// OpenDM returns the ID of the DM channel with the user, using the cached channel if there is one. Returns false if
+// the user does not accept DMs from the bot.
+func OpenDM(worker *worker.Context, userId uint64) (uint64, bool, error) {
+	cachedId, err := redis.GetDMChannel(userId, worker.BotId)
+	if err == nil {
+		if cachedId == nil {
+			return 0, false, nil
+		}
+
+		return *cachedId, true, nil
+	} else if !errors.Is(err, redis.ErrNotCached) {
+		return 0, false, err
+	}
+
+	ch, err := worker.CreateDM(userId)
+	if err != nil {
+		var restError request.RestError
+		if errors.As(err, &restError) && restError.StatusCode == 403 {
+			return 0, false, redis.StoreNullDMChannel(userId, worker.BotId)
+		}
+
+		return 0, false, err
+	}
+
+	if err := redis.StoreDMChannel(userId, ch.Id, worker.BotId); err != nil {
+		return 0, false, err
+	}
+
+	return ch.Id, true, nil
+}
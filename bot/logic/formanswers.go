@@ -0,0 +1,21 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+)
+
+// storeFormAnswers stores the non-empty answers to the panel's form, so that the ticket can be found by them later
+func storeFormAnswers(ctx context.Context, guildId uint64, ticketId int, formData map[database.FormInput]string) error {
+	answers := make(map[string]string)
+	for input, answer := range formData {
+		if answer = strings.TrimSpace(answer); answer != "" {
+			answers[input.Label] = answer
+		}
+	}
+
+	return dbclient.Local.FormAnswers.Set(ctx, guildId, ticketId, answers)
+}
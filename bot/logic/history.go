@@ -0,0 +1,135 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/member"
+)
+
+const historyTicketLimit = 10
+
+// BuildUserHistoryEmbed lists the most recent tickets opened by the user, along with their overall stats
+func BuildUserHistoryEmbed(ctx context.Context, cmd registry.CommandContext, userId uint64) (*embed.Embed, error) {
+	stats, err := dbclient.Local.TicketQueries.GetUserStats(ctx, cmd.GuildId(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	blacklisted, err := isUserBlacklisted(ctx, cmd, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := dbclient.Local.TicketQueries.Search(ctx, cmd.GuildId(), localdb.TicketSearch{OpenerId: &userId}, historyTicketLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{
+		cmd.GetMessage(i18n.MessageHistoryStats, userId, stats.Total, stats.Open),
+		formatAverageRating(cmd, stats),
+	}
+
+	if blacklisted {
+		lines = append(lines, cmd.GetMessage(i18n.MessageHistoryBlacklisted))
+	} else {
+		lines = append(lines, cmd.GetMessage(i18n.MessageHistoryNotBlacklisted))
+	}
+
+	content := strings.Join(lines, "\n")
+	if len(results) == 0 {
+		content += "\n\n" + cmd.GetMessage(i18n.MessageHistoryNoTickets)
+	}
+
+	for _, result := range results {
+		content += "\n\n" + formatSearchResult(cmd, result)
+	}
+
+	if stats.Total > len(results) {
+		content += "\n\n" + cmd.GetMessage(i18n.MessageHistoryMore, stats.Total-len(results), userId)
+	}
+
+	return embed.NewEmbed().
+		SetColor(cmd.GetColour(customisation.Green)).
+		SetTitle(cmd.GetMessage(i18n.TitleHistory)).
+		SetDescription(content), nil
+}
+
+// postHistorySummary posts a compact summary of a returning user's previous tickets in their new ticket, if the guild
+// has enabled it
+func postHistorySummary(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) error {
+	if ticket.ChannelId == nil {
+		return nil
+	}
+
+	settings, err := dbclient.Local.HistorySettings.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	if !settings.PostOnOpen {
+		return nil
+	}
+
+	// The new ticket is the most recent, so the ticket before it is the user's previous ticket
+	results, err := dbclient.Local.TicketQueries.Search(ctx, ticket.GuildId, localdb.TicketSearch{OpenerId: &ticket.UserId}, 2, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(results) < 2 {
+		return nil
+	}
+
+	stats, err := dbclient.Local.TicketQueries.GetUserStats(ctx, ticket.GuildId, ticket.UserId)
+	if err != nil {
+		return err
+	}
+
+	previous := results[1]
+	content := cmd.GetMessage(i18n.MessageHistoryReturning, ticket.UserId, stats.Total-1, previous.Id, previous.OpenTime.Unix()) +
+		"\n" + formatAverageRating(cmd, stats)
+
+	e := embed.NewEmbed().
+		SetColor(cmd.GetColour(customisation.Blue)).
+		SetTitle(cmd.GetMessage(i18n.TitleHistory)).
+		SetDescription(content)
+
+	_, err = cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, e)
+	return err
+}
+
+func formatAverageRating(cmd registry.CommandContext, stats localdb.UserTicketStats) string {
+	if stats.AverageRating == nil {
+		return cmd.GetMessage(i18n.MessageHistoryNoRatings)
+	}
+
+	return cmd.GetMessage(i18n.MessageHistoryAverageRating, fmt.Sprintf("%.1f", *stats.AverageRating), stats.Ratings)
+}
+
+// isUserBlacklisted also checks role blacklists if the user is still in the guild
+func isUserBlacklisted(ctx context.Context, cmd registry.CommandContext, userId uint64) (bool, error) {
+	m, err := cmd.Worker().GetGuildMember(cmd.GuildId(), userId)
+	if err != nil {
+		// The user may have left the guild, in which case only their user blacklist applies
+		return utils.IsBlacklisted(ctx, cmd.GuildId(), userId, member.Member{}, permission.Everyone)
+	}
+
+	permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(cmd.Worker()), m, cmd.GuildId())
+	if err != nil {
+		return false, err
+	}
+
+	return utils.IsBlacklisted(ctx, cmd.GuildId(), userId, m, permLevel)
+}
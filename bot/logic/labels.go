@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+)
+
+// GuildLabelLimit is also the maximum number of autocomplete choices
+const GuildLabelLimit = 25
+
+func FormatLabel(label localdb.Label) string {
+	if label.Emoji == nil {
+		return utils.EscapeMarkdown(label.Name)
+	}
+
+	return *label.Emoji + " " + utils.EscapeMarkdown(label.Name)
+}
+
+// FormatLabels returns an empty string if there are no labels
+func FormatLabels(labels []localdb.Label) string {
+	formatted := make([]string, len(labels))
+	for i, label := range labels {
+		formatted[i] = FormatLabel(label)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// GetFormattedTicketLabels returns an empty string if the ticket has no labels
+func GetFormattedTicketLabels(ctx context.Context, guildId uint64, ticketId int) (string, error) {
+	labels, err := dbclient.Local.TicketLabels.GetByTicket(ctx, guildId, ticketId)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatLabels(labels), nil
+}
+
+// PublishTicketLabels sends the ticket's current labels to the dashboard, and should be called whenever they change
+func PublishTicketLabels(ctx context.Context, guildId uint64, ticketId int) error {
+	labels, err := dbclient.Local.TicketLabels.GetByTicket(ctx, guildId, ticketId)
+	if err != nil {
+		return err
+	}
+
+	update := redis.TicketLabelsUpdate{
+		GuildId:  guildId,
+		TicketId: ticketId,
+		Labels:   make([]redis.TicketLabel, len(labels)),
+	}
+
+	for i, label := range labels {
+		update.Labels[i] = redis.TicketLabel{
+			Id:     label.Id,
+			Name:   label.Name,
+			Colour: label.Colour,
+			Emoji:  label.Emoji,
+		}
+	}
+
+	return redis.PublishTicketLabels(ctx, update)
+}
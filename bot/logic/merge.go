@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot/common/collections"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/transcript"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+)
+
+const mergeCloseReason = "Merged into #%d"
+
+const (
+	// mergeRecentMessages is how many of the latest messages of the source ticket are quoted in the target ticket
+	mergeRecentMessages = 5
+	// mergeQuoteLength is the length each quoted message is truncated to
+	mergeQuoteLength = 150
+)
+
+// MergeTickets moves the members, participants and form answers of the source ticket into the target ticket, posts a
+// summary of the source ticket's history in the target ticket, and then closes the source ticket. cmd must be running
+// in the source ticket's channel. The caller is responsible for checking that the user has permission for both tickets.
+func MergeTickets(ctx context.Context, cmd registry.CommandContext, source, target database.Ticket) error {
+	span := sentry.StartSpan(ctx, "Merge tickets")
+	defer span.Finish()
+
+	history, err := collectTranscript(ctx, cmd, source)
+	if err != nil {
+		return err
+	}
+
+	if err := moveTicketMembers(ctx, cmd, source, target); err != nil {
+		return err
+	}
+
+	participants, err := dbclient.Client.Participants.GetParticipants(ctx, source.GuildId, source.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(participants) > 0 {
+		if err := dbclient.Client.Participants.SetBulk(ctx, target.GuildId, target.Id, participants); err != nil {
+			return err
+		}
+	}
+
+	if err := dbclient.Local.FormAnswers.Move(ctx, source.GuildId, source.Id, target.Id); err != nil {
+		return err
+	}
+
+	if err := dbclient.Local.TicketLinks.Link(ctx, source.GuildId, source.Id, target.Id, localdb.RelationMergedInto); err != nil {
+		return err
+	}
+
+	if err := postMergeSummary(ctx, cmd, source, target, history); err != nil {
+		return err
+	}
+
+	// Sent before closing, so that the source transcript shows where the ticket went
+	cmd.ReplyPermanent(customisation.Green, i18n.TitleMerge, i18n.MessageMergeSuccess, target.Id, *target.ChannelId)
+
+	reason := fmt.Sprintf(mergeCloseReason, target.Id)
+	return CloseTicket(ctx, cmd, &reason, true)
+}
+
+// moveTicketMembers gives the opener and the members of the source ticket access to the target ticket
+func moveTicketMembers(ctx context.Context, cmd registry.CommandContext, source, target database.Ticket) error {
+	members, err := dbclient.Client.TicketMembers.Get(ctx, source.GuildId, source.Id)
+	if err != nil {
+		return err
+	}
+
+	users := collections.NewSet[uint64]()
+	users.Add(source.UserId)
+	for _, userId := range members {
+		users.Add(userId)
+	}
+
+	users.Remove(target.UserId)
+
+	additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, target.GuildId)
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range users.Collect() {
+		if err := addTicketMember(ctx, cmd, target, userId, additionalPermissions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTicketMember records the user as a member of the ticket and gives them access to the ticket channel
+func addTicketMember(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, userId uint64, additionalPermissions database.TicketPermissions) error {
+	if err := dbclient.Client.TicketMembers.Add(ctx, ticket.GuildId, ticket.Id, userId); err != nil {
+		return err
+	}
+
+	if ticket.IsThread {
+		return cmd.Worker().AddThreadMember(*ticket.ChannelId, userId)
+	} else {
+		return cmd.Worker().EditChannelPermissions(*ticket.ChannelId, BuildUserOverwrite(userId, additionalPermissions))
+	}
+}
+
+// postMergeSummary posts the form answers and a summary of the source ticket's history in the target ticket, with a
+// link to the source ticket's transcript
+func postMergeSummary(ctx context.Context, cmd registry.CommandContext, source, target database.Ticket, history transcript.Transcript) error {
+	authors := collections.NewSet[uint64]()
+	var messageCount int
+	var recent []string
+	var formAnswers []embed.EmbedField
+
+	for i := len(history.Messages) - 1; i >= 0; i-- {
+		msg := history.Messages[i]
+
+		// The form answers are the fields of the embeds following the welcome embed
+		if source.WelcomeMessageId != nil && msg.Id == *source.WelcomeMessageId && len(msg.Embeds) > 1 {
+			for _, e := range msg.Embeds[1:] {
+				for _, field := range e.Fields {
+					formAnswers = append(formAnswers, *field)
+				}
+			}
+		}
+
+		if msg.Author.Bot {
+			continue
+		}
+
+		messageCount++
+		authors.Add(msg.Author.Id)
+
+		if len(recent) < mergeRecentMessages && msg.Content != "" {
+			content := utils.StringMax(msg.Content, mergeQuoteLength, "...")
+			recent = append(recent, fmt.Sprintf("<@%d>: %s", msg.Author.Id, utils.EscapeMarkdown(strings.ReplaceAll(content, "\n", " "))))
+		}
+	}
+
+	fields := formAnswers
+	if len(recent) > 0 {
+		// Reverse, so that the recent messages are shown in chronological order
+		for i, j := 0, len(recent)-1; i < j; i, j = i+1, j-1 {
+			recent[i], recent[j] = recent[j], recent[i]
+		}
+
+		fields = append(fields, embed.EmbedField{
+			Name:  cmd.GetMessage(i18n.MessageMergeRecentMessages),
+			Value: strings.Join(recent, "\n"),
+		})
+	}
+
+	// Embeds are limited to 25 fields
+	if len(fields) > 25 {
+		fields = fields[len(fields)-25:]
+	}
+
+	settings, err := cmd.Settings()
+	if err != nil {
+		return err
+	}
+
+	data := rest.CreateMessageData{
+		Embeds: utils.Embeds(utils.BuildEmbed(cmd, customisation.Blue, i18n.TitleMerge, i18n.MessageMergeSummary, fields,
+			source.Id, source.UserId, source.OpenTime.Unix(), messageCount, authors.Size())),
+	}
+
+	if link := TranscriptLinkElement(settings.StoreTranscripts)(cmd.Worker(), source); len(link) > 0 {
+		data.Components = utils.Slice(component.BuildActionRow(link...))
+	}
+
+	_, err = cmd.Worker().CreateMessageComplex(*target.ChannelId, data)
+	return err
+}
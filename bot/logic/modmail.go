@@ -0,0 +1,635 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/blacklist"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/rxdn/gdl/rest"
+	"github.com/rxdn/gdl/rest/request"
+)
+
+// ModmailSelectCustomIdPrefix is followed by the ID of the DM that prompted the guild selection, which is relayed to the
+// ticket once it has been opened
+const ModmailSelectCustomIdPrefix = "modmail_open_"
+
+const (
+	// Select menus are limited to 25 options
+	maxModmailOptions = 25
+	// Discord's upload limit applies to the total size of the files in a request, so attachments are uploaded again
+	// until this many bytes have been used, and the rest are linked to instead
+	maxForwardedAttachmentSize = 8 * 1024 * 1024
+	maxForwardedAttachments    = 10
+	// Relayed messages are split into parts of this length, which is Discord's limit for message content. Users with
+	// Nitro can send messages twice as long, before any attachment links are added.
+	maxRelayedContentLength = 2000
+)
+
+var attachmentClient = &http.Client{
+	Timeout: time.Second * 15,
+}
+
+// forwardedFile is an attachment that has been downloaded, so that it can be uploaded to another channel
+type forwardedFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// BuildModmailPrompt lists the guilds and panels that the user can open a ticket in by messaging the bot. Returns
+// false if modmail is not enabled in any of the guilds the user shares with the bot.
+func BuildModmailPrompt(ctx context.Context, worker *worker.Context, userId, messageId uint64) (rest.CreateMessageData, bool, error) {
+	guildIds, err := cache.GetMemberGuilds(ctx, userId)
+	if err != nil {
+		return rest.CreateMessageData{}, false, err
+	}
+
+	if len(guildIds) == 0 {
+		return rest.CreateMessageData{}, false, nil
+	}
+
+	guildIds, err = dbclient.Local.ModmailSettings.FilterEnabled(ctx, guildIds)
+	if err != nil {
+		return rest.CreateMessageData{}, false, err
+	}
+
+	var options []component.SelectOption
+	for _, guildId := range guildIds {
+		if len(options) >= maxModmailOptions {
+			break
+		}
+
+		guildOptions, err := buildModmailGuildOptions(ctx, worker, guildId)
+		if err != nil {
+			return rest.CreateMessageData{}, false, err
+		}
+
+		options = append(options, guildOptions...)
+	}
+
+	if len(options) == 0 {
+		return rest.CreateMessageData{}, false, nil
+	}
+
+	if len(options) > maxModmailOptions {
+		options = options[:maxModmailOptions]
+	}
+
+	e := utils.BuildEmbedRaw(
+		customisation.GetDefaultColour(customisation.Green),
+		i18n.GetMessage(i18n.LocaleEnglish, i18n.TitleModmail),
+		i18n.GetMessage(i18n.LocaleEnglish, i18n.MessageModmailPrompt),
+		nil,
+		premium.None,
+	)
+
+	return rest.CreateMessageData{
+		Embeds: utils.Slice(e),
+		Components: utils.Slice(component.BuildActionRow(component.BuildSelectMenu(component.SelectMenu{
+			CustomId:    fmt.Sprintf("%s%d", ModmailSelectCustomIdPrefix, messageId),
+			Options:     options,
+			Placeholder: i18n.GetMessage(i18n.LocaleEnglish, i18n.MessageModmailPromptPlaceholder),
+			MinValues:   utils.Ptr(1),
+			MaxValues:   utils.Ptr(1),
+		}))),
+	}, true, nil
+}
+
+// buildModmailGuildOptions offers each panel that can be used from DMs, or the guild itself if it has none. Panels
+// with forms are not offered, as forms cannot be filled in from a select menu.
+func buildModmailGuildOptions(ctx context.Context, worker *worker.Context, guildId uint64) ([]component.SelectOption, error) {
+	if blacklist.IsGuildBlacklisted(guildId) {
+		return nil, nil
+	}
+
+	ok, err := isWorkerGuild(ctx, worker, guildId)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	guild, err := worker.GetGuild(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	var options []component.SelectOption
+	for _, panel := range panels {
+		if panel.Disabled || panel.ForceDisabled || panel.FormId != nil {
+			continue
+		}
+
+		options = append(options, component.SelectOption{
+			Label:       utils.StringMax(panel.Title, 100, "..."),
+			Value:       fmt.Sprintf("%d_%d", guildId, panel.PanelId),
+			Description: utils.StringMax(guild.Name, 100, "..."),
+		})
+	}
+
+	if len(options) > 0 {
+		return options, nil
+	}
+
+	// Tickets opened without a panel have no channel to create a thread in
+	settings, err := dbclient.Client.Settings.Get(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.UseThreads {
+		return nil, nil
+	}
+
+	return utils.Slice(component.SelectOption{
+		Label: utils.StringMax(guild.Name, 100, "..."),
+		Value: fmt.Sprintf("%d_0", guildId),
+	}), nil
+}
+
+// ParseModmailSelection parses the value of a modmail select menu option, returning a panel ID of 0 if the ticket
+// should be opened without a panel
+func ParseModmailSelection(value string) (guildId uint64, panelId int, ok bool) {
+	guildRaw, panelRaw, found := strings.Cut(value, "_")
+	if !found {
+		return 0, 0, false
+	}
+
+	guildId, err := strconv.ParseUint(guildRaw, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	panelId, err = strconv.Atoi(panelRaw)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return guildId, panelId, true
+}
+
+// isWorkerGuild returns whether the guild is served by this worker's bot, as the public bot and whitelabel bots may
+// share members
+func isWorkerGuild(ctx context.Context, worker *worker.Context, guildId uint64) (bool, error) {
+	botId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
+	if err != nil {
+		return false, err
+	}
+
+	if isWhitelabel {
+		return botId == worker.BotId, nil
+	}
+
+	return !worker.IsWhitelabel, nil
+}
+
+// OpenModmailTicket opens a ticket for a user who selected a guild from their DMs, then relays the DM that prompted
+// the selection to it
+func OpenModmailTicket(ctx context.Context, cmd registry.InteractionContext, panel *database.Panel, dmChannelId, messageId uint64) {
+	session, ok, err := dbclient.Local.ModmailSessions.Get(ctx, cmd.Worker().BotId, cmd.UserId())
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if ok {
+		ticket, err := dbclient.Client.Tickets.Get(ctx, session.TicketId, session.GuildId)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if ticket.Open {
+			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageModmailAlreadyOpen)
+			return
+		}
+	}
+
+	initial, err := cmd.Worker().GetChannelMessage(dmChannelId, messageId)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	var subject string
+	if panel != nil {
+		subject = panel.Title
+	} else {
+		subject = utils.StringMax(initial.Content, 100, "...")
+	}
+
+	ticket, err := OpenTicket(ctx, cmd, panel, subject, nil)
+	if err != nil || ticket.Id == 0 {
+		// OpenTicket has already told the user why the ticket could not be opened
+		return
+	}
+
+	session = localdb.ModmailSession{
+		BotId:    cmd.Worker().BotId,
+		UserId:   cmd.UserId(),
+		GuildId:  ticket.GuildId,
+		TicketId: ticket.Id,
+	}
+
+	if err := dbclient.Local.ModmailSessions.Set(ctx, session); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	cmd.Reply(customisation.Green, i18n.TitleModmail, i18n.MessageModmailOpened, utils.EscapeMarkdown(guild.Name))
+
+	// The ticket has been opened, and the user is told if the message itself could not be delivered
+	if _, err := RelayModmailMessage(ctx, cmd.Worker(), session, initial); err != nil {
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+}
+
+// RelayModmailMessage forwards a message that the user sent in their DMs to the ticket. Returns false, and ends the
+// session, if the ticket has since been closed.
+func RelayModmailMessage(ctx context.Context, worker *worker.Context, session localdb.ModmailSession, msg message.Message) (bool, error) {
+	ticket, err := dbclient.Client.Tickets.Get(ctx, session.TicketId, session.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	if !ticket.Open || ticket.ChannelId == nil {
+		return false, dbclient.Local.ModmailSessions.Delete(ctx, session.BotId, session.UserId)
+	}
+
+	files, links := fetchAttachments(ctx, msg.Attachments)
+
+	content := msg.Content
+	for _, link := range links {
+		content += "\n" + link
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" && len(files) == 0 {
+		return true, nil
+	}
+
+	relayed, err := relayToTicketChannel(ctx, worker, ticket, msg.Author, content, files)
+	if err != nil {
+		// The message did not reach staff, so let the user know rather than leaving it without a reaction
+		reference := &message.MessageReference{MessageId: msg.Id, ChannelId: msg.ChannelId}
+		if _, dmErr := worker.CreateMessageReply(msg.ChannelId, i18n.GetMessageFromGuild(ticket.GuildId, i18n.MessageModmailRelayFailed), reference); dmErr != nil {
+			return true, errors.Join(err, dmErr)
+		}
+
+		return true, err
+	}
+
+	if err := dbclient.Client.Participants.Set(ctx, ticket.GuildId, ticket.Id, msg.Author.Id); err != nil {
+		return true, err
+	}
+
+	if err := dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, relayed.Id, msg.Author.Id, false); err != nil {
+		return true, err
+	}
+
+	if err := dbclient.Local.TicketSla.SetAwaiting(ctx, ticket.GuildId, ticket.Id); err != nil {
+		return true, err
+	}
+
+	if err := CancelScheduledCloseOnReply(ctx, worker, ticket); err != nil {
+		return true, err
+	}
+
+	// Let the user know that the message was delivered
+	if err := worker.CreateReaction(msg.ChannelId, msg.Id, "✅"); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// relayToTicketChannel sends the message through the ticket's webhook, so that it appears to have been sent by the
+// user. Tickets without a webhook, such as threads, receive the message from the bot instead. Content that is too long
+// for a single message is split over several, with the files attached to the last. The last message is returned.
+func relayToTicketChannel(ctx context.Context, worker *worker.Context, ticket database.Ticket, author user.User, content string, files []forwardedFile) (message.Message, error) {
+	webhook, err := dbclient.Client.Webhooks.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return message.Message{}, err
+	}
+
+	parts := splitContent(content, maxRelayedContentLength)
+
+	var msg message.Message
+	for i, part := range parts {
+		var partFiles []forwardedFile
+		if i == len(parts)-1 {
+			partFiles = files
+		}
+
+		msg, err = relayPart(ctx, worker, ticket, &webhook, author, part, partFiles)
+		if err != nil {
+			return message.Message{}, err
+		}
+	}
+
+	return msg, nil
+}
+
+// relayPart sends a single message to the ticket channel. If the webhook has been deleted, it is cleared, so that any
+// remaining parts are sent by the bot.
+func relayPart(ctx context.Context, worker *worker.Context, ticket database.Ticket, webhook *database.Webhook, author user.User, content string, files []forwardedFile) (message.Message, error) {
+	if webhook.Id != 0 {
+		msg, err := worker.ExecuteWebhook(webhook.Id, webhook.Token, true, rest.WebhookBody{
+			Content:     content,
+			Username:    author.EffectiveName(),
+			AvatarUrl:   author.AvatarUrl(256),
+			Attachments: buildForwardedAttachments(files),
+		})
+
+		if err == nil {
+			return *msg, nil
+		}
+
+		// The webhook has been deleted, so fall back to sending the message from the bot
+		var restError request.RestError
+		if !errors.As(err, &restError) || restError.StatusCode != 404 {
+			return message.Message{}, err
+		}
+
+		if err := dbclient.Client.Webhooks.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
+			return message.Message{}, err
+		}
+
+		*webhook = database.Webhook{}
+	}
+
+	e := embed.NewEmbed().
+		SetColor(customisation.GetColourOrDefault(ctx, ticket.GuildId, customisation.Blue)).
+		SetAuthor(author.EffectiveName(), "", author.AvatarUrl(256)).
+		SetDescription(content)
+
+	return worker.CreateMessageComplex(*ticket.ChannelId, rest.CreateMessageData{
+		Embeds:      utils.Slice(e),
+		Attachments: buildForwardedAttachments(files),
+	})
+}
+
+// splitContent splits the content into parts of at most max characters, breaking at the last new line or space
+// within the limit where possible. Content that already fits is returned as a single part, even if it is empty.
+func splitContent(content string, max int) []string {
+	runes := []rune(content)
+
+	var parts []string
+	for len(runes) > max {
+		cut := max
+		if i := lastIndexOfRune(runes[:max], '\n'); i > 0 {
+			cut = i
+		} else if i := lastIndexOfRune(runes[:max], ' '); i > 0 {
+			cut = i
+		}
+
+		parts = append(parts, string(runes[:cut]))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " \n"))
+	}
+
+	return append(parts, string(runes))
+}
+
+func lastIndexOfRune(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// SendModmailReply sends a staff member's reply to the DMs of the user who opened the ticket. Anonymous replies are
+// sent in the name of the guild, but the staff member who sent them is still shown in the ticket.
+func SendModmailReply(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, content string, attachment *channel.Attachment, anonymous bool) {
+	_, ok, err := dbclient.Local.ModmailSessions.GetByTicket(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if !ok {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageModmailNotModmailTicket)
+		return
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	staff, err := cmd.User()
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	var files []forwardedFile
+	var links []string
+	if attachment != nil {
+		files, links = fetchAttachments(ctx, utils.Slice(*attachment))
+	}
+
+	description := content
+	for _, link := range links {
+		description += "\n" + link
+	}
+
+	dmEmbed := embed.NewEmbed().
+		SetColor(cmd.GetColour(customisation.Green)).
+		SetDescription(description).
+		SetFooter(guild.Name, guild.IconUrl()).
+		SetTimestamp(time.Now())
+
+	if anonymous {
+		dmEmbed.SetAuthor(cmd.GetMessage(i18n.MessageModmailAnonymousAuthor, guild.Name), "", guild.IconUrl())
+	} else {
+		dmEmbed.SetAuthor(staff.EffectiveName(), "", staff.AvatarUrl(256))
+	}
+
+	dmChannelId, ok, err := OpenDM(cmd.Worker(), ticket.UserId)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if !ok {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageModmailDMsDisabled)
+		return
+	}
+
+	if _, err := cmd.Worker().CreateMessageComplex(dmChannelId, rest.CreateMessageData{
+		Embeds:      utils.Slice(dmEmbed),
+		Attachments: buildForwardedAttachments(files),
+	}); err != nil {
+		var restError request.RestError
+		if errors.As(err, &restError) && restError.StatusCode == 403 {
+			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageModmailDMsDisabled)
+		} else {
+			cmd.HandleError(err)
+		}
+
+		return
+	}
+
+	// Show the reply in the ticket, so that other staff members and the transcript can see what was sent
+	ticketEmbed := embed.NewEmbed().
+		SetColor(cmd.GetColour(customisation.Green)).
+		SetAuthor(staff.EffectiveName(), "", staff.AvatarUrl(256)).
+		SetDescription(description).
+		SetTimestamp(time.Now())
+
+	if attachment != nil {
+		ticketEmbed.AddField(cmd.GetMessage(i18n.MessageModmailAttachment), fmt.Sprintf("[%s](%s)", utils.EscapeMarkdown(attachment.Filename), attachment.Url), false)
+	}
+
+	if anonymous {
+		ticketEmbed.SetFooter(cmd.GetMessage(i18n.MessageModmailSentAnonymously), "")
+	}
+
+	msg, err := cmd.ReplyWith(command.NewEmbedMessageResponse(ticketEmbed))
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if err := dbclient.Client.FirstResponseTime.Set(ctx, ticket.GuildId, cmd.UserId(), ticket.Id, time.Now().Sub(ticket.OpenTime)); err != nil {
+		cmd.HandleError(err)
+	}
+
+	if err := dbclient.Local.TicketSla.SetResponded(ctx, ticket.GuildId, ticket.Id); err != nil {
+		cmd.HandleError(err)
+	}
+
+	// Interaction responses do not return the message that was created
+	if msg.Id != 0 {
+		if err := dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, cmd.UserId(), true); err != nil {
+			cmd.HandleError(err)
+		}
+	}
+}
+
+// fetchAttachments downloads the attachments so that they can be uploaded again, returning links to any that would
+// take the message over the upload limit or could not be downloaded
+func fetchAttachments(ctx context.Context, attachments []channel.Attachment) ([]forwardedFile, []string) {
+	var files []forwardedFile
+	var links []string
+	var totalSize int
+	for _, attachment := range attachments {
+		if len(files) >= maxForwardedAttachments || totalSize+attachment.Size > maxForwardedAttachmentSize {
+			links = append(links, attachment.Url)
+			continue
+		}
+
+		file, err := fetchAttachment(ctx, attachment)
+		if err != nil || totalSize+len(file.Data) > maxForwardedAttachmentSize {
+			links = append(links, attachment.Url)
+			continue
+		}
+
+		files = append(files, file)
+		totalSize += len(file.Data)
+	}
+
+	return files, links
+}
+
+func fetchAttachment(ctx context.Context, attachment channel.Attachment) (forwardedFile, error) {
+	// Only fetch from Discord's CDN, as the URL is sent to us by the client
+	parsed, err := url.Parse(attachment.Url)
+	if err != nil {
+		return forwardedFile{}, err
+	}
+
+	if parsed.Scheme != "https" || (parsed.Host != "cdn.discordapp.com" && parsed.Host != "media.discordapp.net") {
+		return forwardedFile{}, fmt.Errorf("attachment is not hosted by discord: %s", parsed.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.Url, nil)
+	if err != nil {
+		return forwardedFile{}, err
+	}
+
+	res, err := attachmentClient.Do(req)
+	if err != nil {
+		return forwardedFile{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return forwardedFile{}, fmt.Errorf("failed to fetch attachment: status code %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxForwardedAttachmentSize+1))
+	if err != nil {
+		return forwardedFile{}, err
+	}
+
+	if len(data) > maxForwardedAttachmentSize {
+		return forwardedFile{}, errors.New("attachment is too large")
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return forwardedFile{
+		Name:        attachment.Filename,
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// buildForwardedAttachments returns new attachments on each call, as the readers can only be consumed once
+func buildForwardedAttachments(files []forwardedFile) []request.Attachment {
+	attachments := make([]request.Attachment, len(files))
+	for i, file := range files {
+		attachments[i] = request.Attachment{
+			Id:       i,
+			FileName: file.Name,
+			File: request.File{
+				ContentType: file.ContentType,
+				Reader:      bytes.NewReader(file.Data),
+			},
+		}
+	}
+
+	return attachments
+}
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitContentFits(t *testing.T) {
+	require.Equal(t, []string{""}, splitContent("", 10))
+	require.Equal(t, []string{"hello"}, splitContent("hello", 10))
+	require.Equal(t, []string{"0123456789"}, splitContent("0123456789", 10))
+}
+
+func TestSplitContentBreaks(t *testing.T) {
+	require.Equal(t, []string{"first", "second line"}, splitContent("first\nsecond line", 12))
+	require.Equal(t, []string{"one two", "three"}, splitContent("one two three", 10))
+
+	// No new line or space within the limit, so the content is cut at the limit
+	require.Equal(t, []string{"0123456789", "abc"}, splitContent("0123456789abc", 10))
+}
+
+func TestSplitContentLimit(t *testing.T) {
+	content := strings.Repeat("é word\n", 1000)
+
+	parts := splitContent(content, maxRelayedContentLength)
+	require.Greater(t, len(parts), 1)
+
+	for _, part := range parts {
+		require.LessOrEqual(t, utf8.RuneCountInString(part), maxRelayedContentLength)
+	}
+
+	require.Equal(t, strings.Fields(content), strings.Fields(strings.Join(parts, "\n")))
+}
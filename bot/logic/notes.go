@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/transcript"
+)
+
+// MaxNoteLength leaves room for several notes in the /note list embed
+const MaxNoteLength = 1000
+
+// collectStaffNotes returns the ticket's notes, with their edit history, for inclusion in the staff transcript
+func collectStaffNotes(ctx context.Context, ticket database.Ticket) ([]transcript.Note, error) {
+	notes, err := dbclient.Local.TicketNotes.GetByTicket(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notes) == 0 {
+		return nil, nil
+	}
+
+	revisions, err := dbclient.Local.TicketNotes.GetRevisions(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	collected := make([]transcript.Note, len(notes))
+	for i, note := range notes {
+		history := make([]string, len(revisions[note.Id]))
+		for j, revision := range revisions[note.Id] {
+			history[j] = revision.Content
+		}
+
+		collected[i] = transcript.Note{
+			AuthorId:  note.AuthorId,
+			Content:   note.Content,
+			CreatedAt: note.CreatedAt,
+			EditedAt:  note.EditedAt,
+			History:   history,
+		}
+	}
+
+	return collected, nil
+}
@@ -0,0 +1,157 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+)
+
+// Handover messages only list the oldest unclaimed tickets, to stay within the embed description limit
+const handoverTicketLimit = 20
+
+// StartOnCallShift puts the staff member on call for the shift's team, and sends them a handover message listing the
+// team's open unclaimed tickets
+func StartOnCallShift(ctx context.Context, cmd registry.CommandContext, shift localdb.OnCallShift) error {
+	team, roleId, ok, err := getShiftTeam(ctx, shift)
+	if err != nil || !ok {
+		return err
+	}
+
+	// Staff members who are away miss the shift entirely, even if they return part way through it
+	_, away, err := dbclient.Local.StaffAway.Get(ctx, shift.GuildId, shift.UserId)
+	if err != nil || away {
+		return err
+	}
+
+	onCall, err := dbclient.Client.OnCall.IsOnCall(ctx, shift.GuildId, shift.UserId)
+	if err != nil {
+		return err
+	}
+
+	if !onCall {
+		if _, err := dbclient.Client.OnCall.Toggle(ctx, shift.GuildId, shift.UserId); err != nil {
+			return err
+		}
+	}
+
+	if err := AssignOnCallRole(ctx, cmd, shift.UserId, roleId, team); err != nil {
+		return err
+	}
+
+	return sendHandover(ctx, cmd, shift)
+}
+
+// EndOnCallShift takes the staff member off call for the shift's team. Other shifts that are active for the same staff
+// member keep their roles, and keep them on call.
+func EndOnCallShift(ctx context.Context, cmd registry.CommandContext, shift localdb.OnCallShift, active []localdb.OnCallShift) error {
+	var coversTeam, coversUser bool
+	for _, other := range active {
+		if other.Id == shift.Id || other.GuildId != shift.GuildId || other.UserId != shift.UserId {
+			continue
+		}
+
+		coversUser = true
+		if isSameTeam(other.TeamId, shift.TeamId) {
+			coversTeam = true
+		}
+	}
+
+	if !coversTeam {
+		team, _, ok, err := getShiftTeam(ctx, shift)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			if err := RemoveOnCallRole(ctx, cmd, shift.UserId, team); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !coversUser {
+		return dbclient.Client.OnCall.Remove(ctx, shift.GuildId, shift.UserId)
+	}
+
+	return nil
+}
+
+// getShiftTeam returns the team that the shift is for, or nil for the default team, along with its on call role.
+// Returns false if the team no longer exists.
+func getShiftTeam(ctx context.Context, shift localdb.OnCallShift) (*database.SupportTeam, *uint64, bool, error) {
+	if shift.TeamId == nil {
+		metadata, err := dbclient.Client.GuildMetadata.Get(ctx, shift.GuildId)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		return nil, metadata.OnCallRole, true, nil
+	}
+
+	team, ok, err := dbclient.Client.SupportTeam.GetById(ctx, shift.GuildId, *shift.TeamId)
+	if err != nil || !ok {
+		return nil, nil, false, err
+	}
+
+	return &team, team.OnCallRole, true, nil
+}
+
+func isSameTeam(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// sendHandover DMs the incoming staff member the open tickets that nobody has claimed. Staff members who do not accept
+// DMs are skipped.
+func sendHandover(ctx context.Context, cmd registry.CommandContext, shift localdb.OnCallShift) error {
+	tickets, err := dbclient.Local.OnCallShifts.GetUnclaimedTickets(ctx, shift.GuildId, shift.TeamId)
+	if err != nil {
+		return err
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		return err
+	}
+
+	var msg i18n.MessageId
+	var format []interface{}
+	if len(tickets) == 0 {
+		msg = i18n.MessageOnCallHandoverEmpty
+		format = []interface{}{guild.Name}
+	} else {
+		var joined string
+		for i, ticket := range tickets {
+			if i == handoverTicketLimit {
+				joined += cmd.GetMessage(i18n.MessageOnCallHandoverMore, len(tickets)-handoverTicketLimit)
+				break
+			}
+
+			joined += fmt.Sprintf("• <#%d> <t:%d:R>\n", ticket.ChannelId, ticket.OpenTime.Unix())
+		}
+		joined = strings.TrimSuffix(joined, "\n")
+
+		msg = i18n.MessageOnCallHandover
+		format = []interface{}{guild.Name, len(tickets), joined}
+	}
+
+	ch, err := cmd.Worker().CreateDM(shift.UserId)
+	if err != nil {
+		return err
+	}
+
+	// The staff member may not accept DMs from the bot
+	_, _ = cmd.Worker().CreateMessageEmbed(ch.Id, utils.BuildEmbed(cmd, customisation.Blue, i18n.TitleOnCall, msg, nil, format...))
+	return nil
+}
@@ -80,7 +80,12 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 	span = sentry.StartSpan(rootSpan.Context(), "Ticket ratelimit")
 
-	ok, err := redis.TakeTicketRateLimitToken(redis.Client, cmd.GuildId())
+	var panelId *int
+	if panel != nil {
+		panelId = &panel.PanelId
+	}
+
+	ok, retryAfter, err := takeOpenRateLimitToken(ctx, cmd.GuildId(), cmd.UserId(), panelId, cmd.PremiumTier())
 	if err != nil {
 		cmd.HandleError(err)
 		return database.Ticket{}, err
@@ -89,7 +94,8 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 	span.Finish()
 
 	if !ok {
-		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageOpenRatelimited)
+		retryAt := time.Now().Add(retryAfter)
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageOpenRatelimitedRetry, retryAt.Unix())
 		return database.Ticket{}, nil
 	}
 
@@ -252,11 +258,6 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		category = newCategoryId
 	}
 
-	var panelId *int
-	if panel != nil {
-		panelId = &panel.PanelId
-	}
-
 	// Create channel
 	span = sentry.StartSpan(rootSpan.Context(), "Create ticket in database")
 	ticketId, err := dbclient.Client.Tickets.Create(ctx, cmd.GuildId(), cmd.UserId(), isThread, panelId)
@@ -272,6 +273,28 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		return database.Ticket{}, err
 	}
 
+	span = sentry.StartSpan(rootSpan.Context(), "Set ticket priority")
+	panelPriority, err := setInitialPriority(ctx, cmd.GuildId(), ticketId, panel)
+	if err != nil {
+		// Not fatal, the ticket will have the normal priority
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+	span.Finish()
+
+	span = sentry.StartSpan(rootSpan.Context(), "Store form answers")
+	if err := storeFormAnswers(ctx, cmd.GuildId(), ticketId, formData); err != nil {
+		// Not fatal, the ticket will not be searchable by its form answers
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+	span.Finish()
+
+	span = sentry.StartSpan(rootSpan.Context(), "Start SLA tracking")
+	if err := startSlaTracking(ctx, cmd.GuildId(), ticketId, panel); err != nil {
+		// Not fatal, the ticket will not be tracked
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+	span.Finish()
+
 	span = sentry.StartSpan(rootSpan.Context(), "Generate channel name")
 	name, err := GenerateChannelName(ctx, cmd, panel, ticketId, cmd.UserId(), nil)
 	if err != nil {
@@ -432,6 +455,15 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 			return err
 		}
 
+		if panelPriority.UserSelectable {
+			span = sentry.StartSpan(rootSpan.Context(), "Send priority select")
+			if err := sendPrioritySelect(cmd, ticket, panelPriority.Priority); err != nil {
+				// Not fatal, staff can still set the priority
+				sentry.ErrorWithContext(err, cmd.ToErrorContext())
+			}
+			span.Finish()
+		}
+
 		// Update message IDs in DB
 		span = sentry.StartSpan(rootSpan.Context(), "Update ticket properties in database")
 		defer span.Finish()
@@ -561,6 +593,20 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		return database.Ticket{}, err
 	}
 
+	span = sentry.StartSpan(rootSpan.Context(), "Auto assign ticket")
+	if err := autoAssignTicket(ctx, cmd, ticket, panel); err != nil {
+		// Not fatal, the ticket can still be claimed manually
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+	span.Finish()
+
+	span = sentry.StartSpan(rootSpan.Context(), "Post history summary")
+	if err := postHistorySummary(ctx, cmd, ticket); err != nil {
+		// Not fatal, staff can still look up the user's history with /history
+		sentry.ErrorWithContext(err, cmd.ToErrorContext())
+	}
+	span.Finish()
+
 	span = sentry.StartSpan(rootSpan.Context(), "Increment statsd counters")
 	statsd.Client.IncrementKey(statsd.KeyTickets)
 	if panel == nil {
@@ -757,6 +803,13 @@ func createWebhook(ctx context.Context, c registry.CommandContext, ticketId int,
 }
 
 func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, userId uint64, panel *database.Panel, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
+	canManageWebhooks := permission.HasPermissionRaw(cmd.InteractionMetadata().AppPermissions, permission.ManageWebhooks)
+	return BuildOverwrites(ctx, cmd, canManageWebhooks, userId, panel, otherUsers...)
+}
+
+// BuildOverwrites is CreateOverwrites for contexts that did not originate from an interaction, and so do not know the
+// permissions the bot has been granted in the guild.
+func BuildOverwrites(ctx context.Context, cmd registry.CommandContext, canManageWebhooks bool, userId uint64, panel *database.Panel, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
 	overwrites := []channel.PermissionOverwrite{ // @everyone
 		{
 			Id:    cmd.GuildId(),
@@ -781,7 +834,7 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 	selfAllow := make([]permission.Permission, len(StandardPermissions), len(StandardPermissions)+1)
 	copy(selfAllow, StandardPermissions[:]) // Do not append to StandardPermissions
 
-	if permission.HasPermissionRaw(cmd.InteractionMetadata().AppPermissions, permission.ManageWebhooks) {
+	if canManageWebhooks {
 		selfAllow = append(selfAllow, permission.ManageWebhooks)
 	}
 
@@ -998,6 +1051,15 @@ func GenerateChannelName(ctx context.Context, cmd registry.CommandContext, panel
 		}
 	}
 
+	prefix, err := priorityChannelPrefix(ctx, cmd.GuildId(), ticketId)
+	if err != nil {
+		return "", err
+	}
+
+	if prefix != "" {
+		name = fmt.Sprintf("%s-%s", prefix, name)
+	}
+
 	// Cap length after substitutions
 	if len(name) > 100 {
 		name = name[:100]
@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+)
+
+// The guild bucket protects the Discord API, so only premium guilds may raise it above the default
+const premiumGuildOpenLimit = 30
+
+// MaxGuildOpenLimit returns the most tickets a guild may allow to be opened in MinGuildOpenWindow
+func MaxGuildOpenLimit(tier premium.PremiumTier) int {
+	if tier > premium.None {
+		return premiumGuildOpenLimit
+	}
+
+	return redis.DefaultOpenRateLimits.Guild.Limit
+}
+
+// MinGuildOpenWindow is the shortest window a guild may configure for its guild bucket
+var MinGuildOpenWindow = redis.DefaultOpenRateLimits.Guild.Window
+
+// GetOpenRateLimits returns the guild's configured open rate limits, with the guild bucket clamped to what the
+// guild's premium tier allows, in case the guild's premium has lapsed since it was configured.
+func GetOpenRateLimits(ctx context.Context, guildId uint64, tier premium.PremiumTier) (redis.OpenRateLimits, error) {
+	configured, err := dbclient.Local.OpenRateLimits.GetAll(ctx, guildId)
+	if err != nil {
+		return redis.OpenRateLimits{}, err
+	}
+
+	limits := redis.DefaultOpenRateLimits
+	if limit, ok := configured[localdb.RateLimitScopeGuild]; ok {
+		limits.Guild = redis.RateLimit{
+			Limit:  min(limit.Limit, MaxGuildOpenLimit(tier)),
+			Window: max(limit.Window, MinGuildOpenWindow),
+		}
+
+		// The guild bucket can never be disabled
+		if limits.Guild.Limit <= 0 {
+			limits.Guild = redis.DefaultOpenRateLimits.Guild
+		}
+	}
+
+	if limit, ok := configured[localdb.RateLimitScopeUser]; ok {
+		limits.User = redis.RateLimit(limit)
+	}
+
+	if limit, ok := configured[localdb.RateLimitScopePanel]; ok {
+		limits.Panel = redis.RateLimit(limit)
+	}
+
+	return limits, nil
+}
+
+func takeOpenRateLimitToken(ctx context.Context, guildId, userId uint64, panelId *int, tier premium.PremiumTier) (bool, time.Duration, error) {
+	limits, err := GetOpenRateLimits(ctx, guildId, tier)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return redis.TakeTicketRateLimitToken(ctx, guildId, userId, panelId, limits)
+}
@@ -0,0 +1,161 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+)
+
+// SetPriority changes the priority of the ticket, and updates the channel name if the guild uses priority prefixes.
+// Returns false if the channel could not be renamed due to the rename ratelimit, in which case the new prefix will be
+// applied the next time the channel is renamed.
+func SetPriority(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, priority localdb.Priority) (bool, error) {
+	previous, err := dbclient.Local.TicketPriorities.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return false, err
+	}
+
+	userId := cmd.UserId()
+	if err := dbclient.Local.TicketPriorities.Set(ctx, ticket.GuildId, ticket.Id, priority, &userId); err != nil {
+		return false, err
+	}
+
+	if ticket.ChannelId == nil || previous.ChannelPrefix() == priority.ChannelPrefix() {
+		return true, nil
+	}
+
+	settings, err := dbclient.Local.PrioritySettings.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	if !settings.ChannelPrefix {
+		return true, nil
+	}
+
+	allowed, err := redis.TakeRenameRatelimit(ctx, *ticket.ChannelId)
+	if err != nil {
+		return false, err
+	}
+
+	if !allowed {
+		return false, nil
+	}
+
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		if err != nil {
+			return false, err
+		}
+
+		if tmp.GuildId != 0 {
+			panel = &tmp
+		}
+	}
+
+	claimer, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return false, err
+	}
+
+	var claimerPtr *uint64
+	if claimer != 0 {
+		claimerPtr = &claimer
+	}
+
+	name, err := GenerateChannelName(ctx, cmd, panel, ticket.Id, ticket.UserId, claimerPtr)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := cmd.Worker().ModifyChannel(*ticket.ChannelId, rest.ModifyChannelData{Name: name}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PrioritySelectCustomId is the custom ID of the select menu that lets openers choose the priority of their ticket
+const PrioritySelectCustomId = "ticket_priority_select"
+
+// setInitialPriority stores the default priority of the panel that the ticket was opened from, and returns the
+// panel's priority settings so that the caller can offer the opener a choice of priority if the panel allows it.
+func setInitialPriority(ctx context.Context, guildId uint64, ticketId int, panel *database.Panel) (localdb.PanelPriority, error) {
+	// Tickets without a row already have the normal priority
+	if panel == nil {
+		return localdb.PanelPriority{Priority: localdb.PriorityNormal}, nil
+	}
+
+	settings, err := dbclient.Local.PanelPriorities.Get(ctx, panel.PanelId)
+	if err != nil {
+		return localdb.PanelPriority{}, err
+	}
+
+	if settings.Priority == localdb.PriorityNormal {
+		return settings, nil
+	}
+
+	if err := dbclient.Local.TicketPriorities.Set(ctx, guildId, ticketId, settings.Priority, nil); err != nil {
+		return localdb.PanelPriority{}, err
+	}
+
+	return settings, nil
+}
+
+// sendPrioritySelect posts a select menu in the ticket channel which the opener can use to choose the priority of their
+// ticket, with the panel's default priority preselected.
+func sendPrioritySelect(cmd registry.CommandContext, ticket database.Ticket, current localdb.Priority) error {
+	if ticket.ChannelId == nil {
+		return nil
+	}
+
+	menu := component.SelectMenu{
+		CustomId:    PrioritySelectCustomId,
+		Options:     make([]component.SelectOption, len(localdb.Priorities)),
+		Placeholder: cmd.GetMessage(i18n.MessagePrioritySelectPlaceholder),
+		MinValues:   utils.Ptr(1),
+		MaxValues:   utils.Ptr(1),
+	}
+
+	for i, priority := range localdb.Priorities {
+		menu.Options[i] = component.SelectOption{
+			Label:   string(priority),
+			Value:   string(priority),
+			Default: priority == current,
+		}
+	}
+
+	_, err := cmd.Worker().CreateMessageComplex(*ticket.ChannelId, rest.CreateMessageData{
+		Embeds:     utils.Embeds(utils.BuildEmbed(cmd, customisation.Blue, i18n.TitlePriority, i18n.MessagePrioritySelectPrompt, nil)),
+		Components: utils.Slice(component.BuildActionRow(component.BuildSelectMenu(menu))),
+	})
+	return err
+}
+
+func priorityChannelPrefix(ctx context.Context, guildId uint64, ticketId int) (string, error) {
+	settings, err := dbclient.Local.PrioritySettings.Get(ctx, guildId)
+	if err != nil {
+		return "", err
+	}
+
+	if !settings.ChannelPrefix {
+		return "", nil
+	}
+
+	priority, err := dbclient.Local.TicketPriorities.Get(ctx, guildId, ticketId)
+	if err != nil {
+		return "", err
+	}
+
+	return priority.ChannelPrefix(), nil
+}
@@ -0,0 +1,107 @@
+package logic
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+)
+
+const (
+	MinScheduledCloseDelay = time.Minute
+	MaxScheduledCloseDelay = time.Hour * 24 * 30
+
+	ScheduledCloseCancelCustomId = "scheduled_close_cancel"
+)
+
+// ScheduleClose schedules the ticket to be closed after delay, and posts a countdown message with a button to cancel
+// the close in the ticket. The close is performed by messagequeue.ListenScheduledClose.
+func ScheduleClose(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, reason *string, delay time.Duration) (time.Time, error) {
+	closeAt := time.Now().Add(delay)
+
+	scheduled := localdb.ScheduledClose{
+		GuildId:   ticket.GuildId,
+		TicketId:  ticket.Id,
+		ChannelId: *ticket.ChannelId,
+		UserId:    cmd.UserId(),
+		Reason:    reason,
+		CloseAt:   closeAt,
+	}
+
+	if err := dbclient.Local.ScheduledCloses.Set(ctx, scheduled); err != nil {
+		return time.Time{}, err
+	}
+
+	var messageId i18n.MessageId
+	var format []interface{}
+	if reason == nil {
+		messageId = i18n.MessageScheduledCloseNoReason
+		format = []interface{}{cmd.UserId(), closeAt.Unix()}
+	} else {
+		messageId = i18n.MessageScheduledCloseWithReason
+		format = []interface{}{cmd.UserId(), closeAt.Unix(), strings.ReplaceAll(*reason, "`", "\\`")}
+	}
+
+	data := rest.CreateMessageData{
+		Embeds: utils.Embeds(utils.BuildEmbed(cmd, customisation.Orange, i18n.TitleScheduledClose, messageId, nil, format...)),
+		Components: []component.Component{
+			component.BuildActionRow(component.BuildButton(component.Button{
+				Label:    cmd.GetMessage(i18n.MessageScheduledCloseCancel),
+				CustomId: ScheduledCloseCancelCustomId,
+				Style:    component.ButtonStyleSecondary,
+				Emoji:    utils.BuildEmoji("❌"),
+			})),
+		},
+	}
+
+	msg, err := cmd.Worker().CreateMessageComplex(*ticket.ChannelId, data)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := dbclient.Local.ScheduledCloses.SetMessageId(ctx, ticket.GuildId, ticket.Id, msg.Id); err != nil {
+		return time.Time{}, err
+	}
+
+	return closeAt, nil
+}
+
+// CancelScheduledCloseOnReply cancels the scheduled close of the ticket when the opener sends a message, as they
+// evidently still need help, and updates the countdown message.
+func CancelScheduledCloseOnReply(ctx context.Context, worker *worker.Context, ticket database.Ticket) error {
+	scheduled, ok, err := dbclient.Local.ScheduledCloses.Delete(ctx, ticket.GuildId, ticket.Id)
+	if err != nil || !ok || scheduled.MessageId == nil {
+		return err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, customisation.Red, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	title := i18n.GetMessageFromGuild(ticket.GuildId, i18n.TitleScheduledClose)
+	content := i18n.GetMessageFromGuild(ticket.GuildId, i18n.MessageScheduledCloseCancelledReply, ticket.UserId)
+
+	data := rest.EditMessageData{
+		Embeds:     utils.Embeds(utils.BuildEmbedRaw(colour, title, content, nil, premiumTier)),
+		Components: []component.Component{},
+	}
+
+	_, err = worker.EditMessage(scheduled.ChannelId, *scheduled.MessageId, data)
+	return err
+}
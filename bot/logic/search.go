@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/config"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/google/uuid"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction/component"
+)
+
+// TicketSearchCustomIdPrefix is followed by the ID of the stored search and the page number, separated by an underscore
+const TicketSearchCustomIdPrefix = "ticket_search_"
+
+const searchResultsPerPage = 8
+
+// StoreTicketSearch stores the search, so that its other pages can be fetched, returning its ID
+func StoreTicketSearch(ctx context.Context, search localdb.TicketSearch) (string, error) {
+	data, err := json.Marshal(search)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.New().String()
+	if err := redis.SetTicketSearch(ctx, id, data); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetTicketSearch returns false if the search has expired
+func GetTicketSearch(ctx context.Context, id string) (localdb.TicketSearch, bool, error) {
+	data, err := redis.GetTicketSearch(ctx, id)
+	if err != nil {
+		if errors.Is(err, redis.ErrTicketSearchNotFound) {
+			return localdb.TicketSearch{}, false, nil
+		}
+
+		return localdb.TicketSearch{}, false, err
+	}
+
+	var search localdb.TicketSearch
+	if err := json.Unmarshal(data, &search); err != nil {
+		return localdb.TicketSearch{}, false, err
+	}
+
+	return search, true, nil
+}
+
+// BuildTicketSearchMessage builds a page of the search results, with buttons to move between pages
+func BuildTicketSearchMessage(ctx context.Context, cmd registry.CommandContext, searchId string, search localdb.TicketSearch, page int) (command.MessageResponse, error) {
+	// Fetch one more than a page, to know whether there is a next page
+	results, err := dbclient.Local.TicketQueries.Search(ctx, cmd.GuildId(), search, searchResultsPerPage+1, page*searchResultsPerPage)
+	if err != nil {
+		return command.MessageResponse{}, err
+	}
+
+	if len(results) == 0 && page == 0 {
+		return command.MessageResponse{
+			Embeds: utils.Slice(utils.BuildEmbed(cmd, customisation.Red, i18n.TitleSearch, i18n.MessageSearchNoResults, nil)),
+		}, nil
+	}
+
+	hasNext := len(results) > searchResultsPerPage
+	if hasNext {
+		results = results[:searchResultsPerPage]
+	}
+
+	var content string
+	for _, result := range results {
+		content += formatSearchResult(cmd, result) + "\n\n"
+	}
+	content = strings.TrimSuffix(content, "\n\n")
+
+	self, _ := cmd.Worker().Self()
+	e := embed.NewEmbed().
+		SetColor(cmd.GetColour(customisation.Green)).
+		SetTitle(cmd.GetMessage(i18n.TitleSearch)).
+		SetDescription(content).
+		SetFooter(fmt.Sprintf("Page %d", page+1), self.AvatarUrl(256))
+
+	return command.MessageResponse{
+		Embeds: []*embed.Embed{e},
+		Components: []component.Component{
+			component.BuildActionRow(
+				component.BuildButton(component.Button{
+					CustomId: fmt.Sprintf("%s%s_%d", TicketSearchCustomIdPrefix, searchId, page-1),
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "◀️",
+					},
+					Disabled: page <= 0,
+				}),
+				component.BuildButton(component.Button{
+					CustomId: fmt.Sprintf("%s%s_%d", TicketSearchCustomIdPrefix, searchId, page+1),
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "▶️",
+					},
+					Disabled: !hasNext,
+				}),
+			),
+		},
+	}, nil
+}
+
+// formatSearchResult links to the channel of open tickets, and to the transcript of closed tickets
+func formatSearchResult(cmd registry.CommandContext, result localdb.TicketSearchResult) string {
+	var summary string
+	if result.Open && result.ChannelId != nil {
+		summary = cmd.GetMessage(i18n.MessageSearchResultOpen, result.Id, *result.ChannelId, result.UserId, result.OpenTime.Unix())
+	} else if result.HasTranscript {
+		transcriptLink := fmt.Sprintf("%s/manage/%d/transcripts/view/%d", config.Conf.Bot.DashboardUrl, cmd.GuildId(), result.Id)
+		summary = cmd.GetMessage(i18n.MessageSearchResultClosed, result.Id, transcriptLink, result.UserId, result.OpenTime.Unix())
+	} else {
+		summary = cmd.GetMessage(i18n.MessageSearchResultNoTranscript, result.Id, result.UserId, result.OpenTime.Unix())
+	}
+
+	var details []string
+	if result.PanelTitle != nil {
+		details = append(details, cmd.GetMessage(i18n.MessageSearchPanel, utils.EscapeMarkdown(*result.PanelTitle)))
+	}
+
+	if result.ClaimedBy != nil {
+		details = append(details, cmd.GetMessage(i18n.MessageSearchClaimedBy, *result.ClaimedBy))
+	}
+
+	if !result.Open && result.CloseTime != nil {
+		details = append(details, cmd.GetMessage(i18n.MessageSearchClosedAt, result.CloseTime.Unix()))
+	}
+
+	if result.Rating != nil {
+		details = append(details, cmd.GetMessage(i18n.MessageSearchRating, *result.Rating))
+	}
+
+	if result.CloseReason != nil {
+		details = append(details, cmd.GetMessage(i18n.MessageSearchCloseReason, utils.EscapeMarkdown(utils.StringMax(*result.CloseReason, 100, "..."))))
+	}
+
+	if len(details) == 0 {
+		return summary
+	}
+
+	return summary + "\n" + strings.Join(details, " • ")
+}
@@ -0,0 +1,22 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+)
+
+// startSlaTracking starts the SLA clocks of a newly opened ticket, if its panel has an SLA policy
+func startSlaTracking(ctx context.Context, guildId uint64, ticketId int, panel *database.Panel) error {
+	if panel == nil {
+		return nil
+	}
+
+	_, ok, err := dbclient.Local.SlaPolicies.Get(ctx, panel.PanelId)
+	if err != nil || !ok {
+		return err
+	}
+
+	return dbclient.Local.TicketSla.Create(ctx, guildId, ticketId)
+}
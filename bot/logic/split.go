@@ -0,0 +1,285 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/rxdn/gdl/rest"
+)
+
+// The custom IDs of the split components are followed by the ID of the first message to split out. The panel select
+// menu and confirm button are also followed by the ID of the user chosen to open the new ticket.
+const (
+	SplitTicketCustomIdPrefix  = "split_ticket_"
+	SplitConfirmCustomIdPrefix = "split_confirm_"
+	SplitOpenerCustomIdPrefix  = "split_opener_"
+)
+
+// Select menus are limited to 25 options
+const splitMaxOptions = 25
+
+// Messages are limited to 10 embeds, containing at most 6000 characters between them
+const (
+	splitEmbedsPerMessage     = 10
+	splitEmbedCharsPerMessage = 6000
+)
+
+// SplitTicket opens a new ticket containing the given message and every later message in the source ticket from the
+// same author. The ticket is opened by the user that cmd acts on behalf of, who is normally the author. If a staff
+// member chose someone else, the author is added to the new ticket as well. The messages are quoted into the new
+// ticket, and both tickets are linked. The original messages are left in place, so that they remain in the source
+// ticket's transcript.
+func SplitTicket(ctx context.Context, cmd registry.InteractionContext, source database.Ticket, panel *database.Panel, messageId uint64) error {
+	span := sentry.StartSpan(ctx, "Split ticket")
+	defer span.Finish()
+
+	first, err := cmd.Worker().GetChannelMessage(*source.ChannelId, messageId)
+	if err != nil {
+		return err
+	}
+
+	later, err := fetchMessageRange(cmd, *source.ChannelId, 0, messageId)
+	if err != nil {
+		return err
+	}
+
+	moved := []message.Message{first}
+	for _, msg := range later {
+		if msg.Author.Id == first.Author.Id {
+			moved = append(moved, msg)
+		}
+	}
+
+	ticket, err := OpenTicket(ctx, cmd, panel, first.Content, nil)
+	if err != nil {
+		return nil // Already handled
+	}
+
+	if ticket.ChannelId == nil {
+		return nil
+	}
+
+	if first.Author.Id != ticket.UserId {
+		additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ticket.GuildId)
+		if err != nil {
+			return err
+		}
+
+		if err := addTicketMember(ctx, cmd, ticket, first.Author.Id, additionalPermissions); err != nil {
+			return err
+		}
+	}
+
+	if err := dbclient.Local.TicketLinks.Link(ctx, source.GuildId, source.Id, ticket.Id, localdb.RelationSplitInto); err != nil {
+		return err
+	}
+
+	if err := postSplitMessages(cmd, source, ticket, moved); err != nil {
+		return err
+	}
+
+	notice := utils.BuildEmbed(cmd, customisation.Green, i18n.TitleSplit, i18n.MessageSplitSource, nil, len(moved), first.Author.Id, ticket.Id, *ticket.ChannelId)
+	_, err = cmd.Worker().CreateMessageEmbed(*source.ChannelId, notice)
+	return err
+}
+
+// BuildSplitResponse builds the menus shown to the staff member splitting the ticket: a choice of opener, which
+// defaults to the author of the message, and either a choice of panel or a button to confirm the split.
+func BuildSplitResponse(ctx context.Context, cmd registry.CommandContext, source database.Ticket, messageId, openerId uint64) (command.MessageResponse, error) {
+	first, err := cmd.Worker().GetChannelMessage(*source.ChannelId, messageId)
+	if err != nil {
+		return command.MessageResponse{}, err
+	}
+
+	if openerId == 0 {
+		openerId = first.Author.Id
+	}
+
+	// Offer the author, followed by everyone else who has recently sent a message in the ticket
+	recent, err := cmd.Worker().GetChannelMessages(*source.ChannelId, rest.GetChannelMessagesData{Limit: 100})
+	if err != nil {
+		return command.MessageResponse{}, err
+	}
+
+	candidates := []user.User{first.Author}
+	seen := map[uint64]bool{first.Author.Id: true}
+	for _, msg := range recent {
+		if len(candidates) == splitMaxOptions {
+			break
+		}
+
+		if msg.Author.Bot || seen[msg.Author.Id] {
+			continue
+		}
+
+		candidates = append(candidates, msg.Author)
+		seen[msg.Author.Id] = true
+	}
+
+	openerMenu := component.SelectMenu{
+		CustomId:    fmt.Sprintf("%s%d", SplitOpenerCustomIdPrefix, messageId),
+		Options:     make([]component.SelectOption, len(candidates)),
+		Placeholder: cmd.GetMessage(i18n.MessageSplitSelectOpener),
+	}
+
+	for i, candidate := range candidates {
+		openerMenu.Options[i] = component.SelectOption{
+			Label:       utils.StringMax(candidate.EffectiveName(), 100),
+			Value:       strconv.FormatUint(candidate.Id, 10),
+			Description: utils.StringMax("@"+candidate.Username, 100),
+			Default:     candidate.Id == openerId,
+		}
+	}
+
+	panels, err := dbclient.Client.Panel.GetByGuild(ctx, cmd.GuildId())
+	if err != nil {
+		return command.MessageResponse{}, err
+	}
+
+	// Without any panels to choose from, the new ticket is opened once the split is confirmed
+	if len(panels) == 0 {
+		confirm := component.BuildButton(component.Button{
+			Label:    cmd.GetMessage(i18n.MessageSplitConfirm),
+			CustomId: fmt.Sprintf("%s%d_%d", SplitConfirmCustomIdPrefix, messageId, openerId),
+			Style:    component.ButtonStylePrimary,
+		})
+
+		return command.NewEphemeralEmbedMessageResponseWithComponents(
+			utils.BuildEmbed(cmd, customisation.Green, i18n.TitleSplit, i18n.MessageSplitChooseOpener, nil),
+			[]component.Component{
+				component.BuildActionRow(component.BuildSelectMenu(openerMenu)),
+				component.BuildActionRow(confirm),
+			},
+		), nil
+	}
+
+	if len(panels) > splitMaxOptions {
+		panels = panels[:splitMaxOptions]
+	}
+
+	panelMenu := component.SelectMenu{
+		CustomId:    fmt.Sprintf("%s%d_%d", SplitTicketCustomIdPrefix, messageId, openerId),
+		Options:     make([]component.SelectOption, len(panels)),
+		Placeholder: cmd.GetMessage(i18n.MessageSplitSelectPanel),
+	}
+
+	for i, panel := range panels {
+		panelMenu.Options[i] = component.SelectOption{
+			Label: panel.Title,
+			Value: strconv.Itoa(panel.PanelId),
+		}
+	}
+
+	return command.NewEphemeralEmbedMessageResponseWithComponents(
+		utils.BuildEmbed(cmd, customisation.Green, i18n.TitleSplit, i18n.MessageSplitChoosePanel, nil),
+		[]component.Component{
+			component.BuildActionRow(component.BuildSelectMenu(openerMenu)),
+			component.BuildActionRow(component.BuildSelectMenu(panelMenu)),
+		},
+	), nil
+}
+
+// ParseSplitCustomId returns the IDs of the first message to split out, and of the chosen opener, from the custom ID of
+// the panel select menu or confirm button
+func ParseSplitCustomId(customId, prefix string) (messageId, openerId uint64, ok bool) {
+	before, after, found := strings.Cut(strings.TrimPrefix(customId, prefix), "_")
+	if !found {
+		return 0, 0, false
+	}
+
+	messageId, err := strconv.ParseUint(before, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	openerId, err = strconv.ParseUint(after, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return messageId, openerId, true
+}
+
+// postSplitMessages quotes the moved messages into the new ticket, one embed per message
+func postSplitMessages(cmd registry.CommandContext, source, ticket database.Ticket, moved []message.Message) error {
+	embeds := utils.Embeds(utils.BuildEmbed(cmd, customisation.Blue, i18n.TitleSplit, i18n.MessageSplitTarget, nil,
+		len(moved), moved[0].Author.Id, source.Id, *source.ChannelId))
+
+	for _, msg := range moved {
+		content := utils.StringMax(msg.Content, 3900, "...")
+
+		var attachments []string
+		for _, attachment := range msg.Attachments {
+			attachments = append(attachments, fmt.Sprintf("[%s](%s)", attachment.Filename, attachment.Url))
+		}
+
+		// Embed descriptions are limited to 4096 characters
+		if len(attachments) > 0 {
+			content = utils.StringMax(strings.TrimSpace(content+"\n"+strings.Join(attachments, "\n")), 4096)
+		}
+
+		quote := embed.NewEmbed().
+			SetColor(cmd.GetColour(customisation.Blue)).
+			SetAuthor(msg.Author.EffectiveName(), "", msg.Author.AvatarUrl(64)).
+			SetDescription(content).
+			SetTimestamp(msg.Timestamp)
+
+		embeds = append(embeds, quote)
+	}
+
+	for len(embeds) > 0 {
+		// Each embed is well within the limit by itself, so at least one is always sent
+		n, length := 0, 0
+		for n < len(embeds) && n < splitEmbedsPerMessage {
+			length += embedLength(embeds[n])
+			if n > 0 && length > splitEmbedCharsPerMessage {
+				break
+			}
+
+			n++
+		}
+
+		if _, err := cmd.Worker().CreateMessageComplex(*ticket.ChannelId, rest.CreateMessageData{Embeds: embeds[:n]}); err != nil {
+			return err
+		}
+
+		embeds = embeds[n:]
+	}
+
+	return nil
+}
+
+// embedLength counts the characters in the embed that count towards Discord's limit per message
+func embedLength(e *embed.Embed) int {
+	length := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+
+	if e.Author != nil {
+		length += utf8.RuneCountInString(e.Author.Name)
+	}
+
+	if e.Footer != nil {
+		length += utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	for _, field := range e.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+
+	return length
+}
@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/user"
+)
+
+// SendStaffMessage sends a message into the ticket on behalf of a staff member who is not in the channel, such as one
+// replying from the dashboard. The message listener ignores messages sent by webhooks and the bot, so the staff
+// member's response is recorded here instead.
+func SendStaffMessage(ctx context.Context, worker *worker.Context, ticket database.Ticket, author user.User, content string) (message.Message, error) {
+	if ticket.ChannelId == nil {
+		return message.Message{}, errors.New("channel ID is nil")
+	}
+
+	msg, err := relayToTicketChannel(ctx, worker, ticket, author, content, nil)
+	if err != nil {
+		return message.Message{}, err
+	}
+
+	if err := dbclient.Client.Participants.Set(ctx, ticket.GuildId, ticket.Id, author.Id); err != nil {
+		return msg, err
+	}
+
+	if err := dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, author.Id, true); err != nil {
+		return msg, err
+	}
+
+	// Only the first response is stored
+	if err := dbclient.Client.FirstResponseTime.Set(ctx, ticket.GuildId, author.Id, ticket.Id, time.Since(ticket.OpenTime)); err != nil {
+		return msg, err
+	}
+
+	if err := dbclient.Local.TicketSla.SetResponded(ctx, ticket.GuildId, ticket.Id); err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
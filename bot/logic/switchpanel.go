@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/permission"
+	"github.com/rxdn/gdl/rest"
+)
+
+// SwitchTicketPanel moves the ticket to the panel, renaming the channel and moving it into the panel's category. The
+// permissions of thread tickets cannot be bulk updated, so only the name and topic of threads are changed.
+func SwitchTicketPanel(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, panel *database.Panel) error {
+	if err := dbclient.Client.Tickets.SetPanelId(ctx, ticket.GuildId, ticket.Id, panel.PanelId); err != nil {
+		return err
+	}
+
+	ticket.PanelId = &panel.PanelId
+
+	claimer, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	channelName, err := GenerateChannelName(ctx, cmd, panel, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
+	if err != nil {
+		return err
+	}
+
+	channelTopic := fmt.Sprintf("Panel: %s | Ticket ID: %d", panel.Title, ticket.Id)
+
+	if ticket.IsThread {
+		data := rest.ModifyChannelData{
+			Name:  channelName,
+			Topic: channelTopic,
+		}
+
+		_, err := cmd.Worker().ModifyChannel(*ticket.ChannelId, data)
+		return err
+	}
+
+	var overwrites []channel.PermissionOverwrite
+	if claimer != 0 {
+		overwrites, err = GenerateClaimedOverwrites(ctx, cmd.Worker(), ticket, claimer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Unclaimed, or support representatives can still view and type in the ticket
+	if overwrites == nil {
+		members, err := dbclient.Client.TicketMembers.Get(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			return err
+		}
+
+		// The bot's permissions are only known when the switch was made from an interaction
+		var canManageWebhooks bool
+		if interactionCtx, ok := cmd.(registry.InteractionContext); ok {
+			canManageWebhooks = permission.HasPermissionRaw(interactionCtx.InteractionMetadata().AppPermissions, permission.ManageWebhooks)
+		}
+
+		overwrites, err = BuildOverwrites(ctx, cmd, canManageWebhooks, ticket.UserId, panel, members...)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := rest.ModifyChannelData{
+		Name:                 channelName,
+		Topic:                channelTopic,
+		PermissionOverwrites: overwrites,
+		ParentId:             panel.TargetCategory,
+	}
+
+	_, err = cmd.Worker().ModifyChannel(*ticket.ChannelId, data)
+	return err
+}
@@ -0,0 +1,98 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/permission"
+)
+
+const (
+	MinTemporaryAccessDuration = time.Minute
+	MaxTemporaryAccessDuration = time.Hour * 24 * 30
+)
+
+// GrantTemporaryAccess schedules the user's access to the ticket to expire after duration. The user is removed by
+// messagequeue.ListenTemporaryAccess.
+func GrantTemporaryAccess(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, userId uint64, duration time.Duration) (time.Time, error) {
+	expiresAt := time.Now().Add(duration)
+
+	access := localdb.TemporaryAccess{
+		GuildId:   ticket.GuildId,
+		TicketId:  ticket.Id,
+		UserId:    userId,
+		AddedBy:   cmd.UserId(),
+		ExpiresAt: expiresAt,
+	}
+
+	if err := dbclient.Local.TemporaryAccess.Set(ctx, access); err != nil {
+		return time.Time{}, err
+	}
+
+	return expiresAt, nil
+}
+
+// AddTicketMember grants the user access to the ticket. The user's access is permanent unless GrantTemporaryAccess is
+// called afterwards, so the caller must remove any pending expiry if they are adding the user permanently.
+func AddTicketMember(ctx context.Context, worker *worker.Context, ticket database.Ticket, userId uint64) error {
+	if err := dbclient.Client.TicketMembers.Add(ctx, ticket.GuildId, ticket.Id, userId); err != nil {
+		return err
+	}
+
+	if ticket.IsThread {
+		return worker.AddThreadMember(*ticket.ChannelId, userId)
+	}
+
+	additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	return worker.EditChannelPermissions(*ticket.ChannelId, BuildUserOverwrite(userId, additionalPermissions))
+}
+
+// RemoveTicketMember revokes the user's access to the ticket, including any pending expiry of temporary access. The
+// expiry is only removed once the user has been removed, so that a failed removal of temporary access is retried.
+func RemoveTicketMember(ctx context.Context, worker *worker.Context, ticket database.Ticket, userId uint64) error {
+	if err := dbclient.Client.TicketMembers.Delete(ctx, ticket.GuildId, ticket.Id, userId); err != nil {
+		return err
+	}
+
+	if ticket.IsThread {
+		if err := worker.RemoveThreadMember(*ticket.ChannelId, userId); err != nil {
+			return err
+		}
+	} else {
+		data := channel.PermissionOverwrite{
+			Id:    userId,
+			Type:  channel.PermissionTypeMember,
+			Allow: 0,
+			Deny:  permission.BuildPermissions(StandardPermissions[:]...),
+		}
+
+		if err := worker.EditChannelPermissions(*ticket.ChannelId, data); err != nil {
+			return err
+		}
+	}
+
+	return dbclient.Local.TemporaryAccess.Delete(ctx, ticket.GuildId, ticket.Id, userId)
+}
+
+// ExpireTemporaryAccess removes the user from the ticket, and posts a notice in the ticket
+func ExpireTemporaryAccess(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, userId uint64) error {
+	if err := RemoveTicketMember(ctx, cmd.Worker(), ticket, userId); err != nil {
+		return err
+	}
+
+	_, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, utils.BuildEmbed(cmd, customisation.Orange, i18n.TitleRemove, i18n.MessageAddAccessExpired, nil, userId))
+	return err
+}
@@ -0,0 +1,252 @@
+package logic
+
+import (
+	"context"
+	"sort"
+
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/TicketsBot/worker/bot/transcript"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/rxdn/gdl/rest"
+)
+
+// linkRelationNames describe linked tickets in transcripts, which are not localised
+var linkRelationNames = map[localdb.LinkRelation]string{
+	localdb.RelationMergedInto: "Merged into",
+	localdb.RelationMergedFrom: "Merged from",
+	localdb.RelationSplitInto:  "Split into",
+	localdb.RelationSplitFrom:  "Split from",
+}
+
+// collectTranscript builds the ticket history from the transcript journal. Only the messages that the journal is
+// missing are fetched from Discord: those sent before the journal started recording the ticket, such as for tickets
+// opened before the journal existed, and those sent after the last journaled message, if the gateway is lagging behind.
+func collectTranscript(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) (transcript.Transcript, error) {
+	span := sentry.StartSpan(ctx, "Collect transcript")
+	defer span.Finish()
+
+	entries, err := dbclient.Local.TranscriptJournal.GetAll(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return transcript.Transcript{}, err
+	}
+
+	channelId := cmd.ChannelId()
+
+	var fetched []message.Message
+	var ranges []messageRange
+	if len(entries) == 0 {
+		// Nothing was journaled, so fall back to fetching the whole history
+		fetched, err = fetchMessageRange(cmd, channelId, 0, 0)
+		if err != nil {
+			return transcript.Transcript{}, err
+		}
+
+		ranges = append(ranges, messageRange{})
+	} else {
+		// Entries are ordered by message ID
+		first, last := entries[0].MessageId, entries[len(entries)-1].MessageId
+
+		// The welcome message is the first message in the ticket, so if it was journaled, the journal has seen the start
+		if ticket.WelcomeMessageId == nil || first > *ticket.WelcomeMessageId {
+			earlier, err := fetchMessageRange(cmd, channelId, first, 0)
+			if err != nil {
+				return transcript.Transcript{}, err
+			}
+
+			fetched = append(fetched, earlier...)
+			ranges = append(ranges, messageRange{before: first})
+		}
+
+		later, err := fetchMessageRange(cmd, channelId, 0, last)
+		if err != nil {
+			return transcript.Transcript{}, err
+		}
+
+		fetched = append(fetched, later...)
+		ranges = append(ranges, messageRange{after: last})
+	}
+
+	history := reconcileTranscript(entries, fetched, ranges)
+	history.Ticket = ticket
+
+	// Loaded here rather than when rendering, so that the archiver's copy of the transcript also references them
+	links, err := dbclient.Local.TicketLinks.GetLinks(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return transcript.Transcript{}, err
+	}
+
+	for _, link := range links {
+		history.Links = append(history.Links, transcript.Link{
+			Relation: linkRelationNames[link.Relation],
+			TicketId: link.LinkedTicketId,
+		})
+	}
+
+	return history, nil
+}
+
+// messageRange is a range of message IDs that was fetched from Discord, excluding the bounds. A bound of 0 means
+// that the range is unbounded in that direction.
+type messageRange struct {
+	after, before uint64
+}
+
+func (r messageRange) contains(id uint64) bool {
+	return (r.after == 0 || id > r.after) && (r.before == 0 || id < r.before)
+}
+
+// reconcileTranscript merges the journal entries, which are ordered by message ID and then revision, with the messages
+// fetched from Discord within the given ranges. Only the fetched ranges are compared against the journal, so a
+// journaled message is only considered deleted if it falls within a range that was fetched and was not returned.
+func reconcileTranscript(entries []localdb.JournalEntry, fetched []message.Message, ranges []messageRange) transcript.Transcript {
+	history := transcript.Transcript{
+		Messages:    make([]message.Message, 0, len(entries)+len(fetched)),
+		EditHistory: make(map[uint64][]message.Message),
+		Deleted:     make(map[uint64]bool),
+	}
+
+	// The last entry for each message is the latest version that the journal has seen
+	journaled := make(map[uint64]message.Message)
+	for i, entry := range entries {
+		if entry.DeletedAt != nil {
+			history.Deleted[entry.MessageId] = true
+		}
+
+		if i == len(entries)-1 || entries[i+1].MessageId != entry.MessageId {
+			journaled[entry.MessageId] = entry.Message
+		} else {
+			history.EditHistory[entry.MessageId] = append(history.EditHistory[entry.MessageId], entry.Message)
+		}
+	}
+
+	for _, msg := range fetched {
+		// If the journal missed the latest edit, the version it has is kept as a previous revision
+		if latest, ok := journaled[msg.Id]; ok {
+			if msg.EditedTimestamp != nil && (latest.EditedTimestamp == nil || msg.EditedTimestamp.After(*latest.EditedTimestamp)) {
+				history.EditHistory[msg.Id] = append(history.EditHistory[msg.Id], latest)
+			}
+
+			delete(journaled, msg.Id)
+		}
+
+		history.Messages = append(history.Messages, msg)
+	}
+
+	for id, msg := range journaled {
+		// Discord was asked for this message, but did not return it, so it was deleted even if the journal missed it
+		for _, r := range ranges {
+			if r.contains(id) {
+				history.Deleted[id] = true
+				break
+			}
+		}
+
+		history.Messages = append(history.Messages, msg)
+	}
+
+	sort.Slice(history.Messages, func(i, j int) bool {
+		return history.Messages[i].Id < history.Messages[j].Id
+	})
+
+	return history
+}
+
+// fetchMessageRange pages through the channel history before or after the given message, or through the whole
+// channel if neither is set. Messages are returned in chronological order.
+func fetchMessageRange(cmd registry.CommandContext, channelId, before, after uint64) ([]message.Message, error) {
+	const limit = 100
+
+	msgs := make([]message.Message, 0, limit)
+	for {
+		chunk, err := cmd.Worker().GetChannelMessages(channelId, rest.GetChannelMessagesData{
+			Before: before,
+			After:  after,
+			Limit:  limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, chunk...)
+
+		if len(chunk) < limit {
+			break
+		}
+
+		// Continue from the newest message when paging forwards, or the oldest when paging backwards
+		for _, msg := range chunk {
+			if after != 0 && msg.Id > after {
+				after = msg.Id
+			}
+
+			if after == 0 && (before == 0 || msg.Id < before) {
+				before = msg.Id
+			}
+		}
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Id < msgs[j].Id
+	})
+
+	return msgs, nil
+}
+
+// renderTranscript renders the ticket history with the guild's chosen exporter
+func renderTranscript(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	history transcript.Transcript,
+	participants []uint64,
+	format string,
+) (transcript.File, error) {
+	span := sentry.StartSpan(ctx, "Render transcript")
+	defer span.Finish()
+
+	exporter, ok := transcript.GetExporter(transcript.Format(format))
+	if !ok {
+		exporter, _ = transcript.GetExporter(localdb.DefaultTranscriptFormat)
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		return transcript.File{}, err
+	}
+
+	users := make(map[uint64]user.User)
+	for _, msg := range history.Messages {
+		users[msg.Author.Id] = msg.Author
+
+		for _, mentioned := range msg.Mentions {
+			users[mentioned.Id] = mentioned.User
+		}
+	}
+
+	// Participants who were mentioned, but whose messages have since been deleted, and the authors of staff notes
+	for _, note := range history.Notes {
+		participants = append(participants, note.AuthorId)
+	}
+
+	for _, userId := range participants {
+		if _, ok := users[userId]; ok {
+			continue
+		}
+
+		u, err := cmd.Worker().GetUser(userId)
+		if err != nil {
+			continue // Best effort, the mention is left unresolved
+		}
+
+		users[userId] = u
+	}
+
+	history.GuildName = guild.Name
+	history.Users = users
+
+	return transcript.Render(exporter, history)
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"github.com/TicketsBot/worker/bot/localdb"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func journalEntry(id uint64, revision int, content string, deleted bool) localdb.JournalEntry {
+	entry := localdb.JournalEntry{
+		MessageId: id,
+		Revision:  revision,
+		Message:   message.Message{Id: id, Content: content},
+	}
+
+	if deleted {
+		entry.DeletedAt = &time.Time{}
+	}
+
+	return entry
+}
+
+func messageIds(msgs []message.Message) []uint64 {
+	ids := make([]uint64, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.Id
+	}
+
+	return ids
+}
+
+func TestReconcileBackfillsGaps(t *testing.T) {
+	entries := []localdb.JournalEntry{
+		journalEntry(2, 0, "two", false),
+		journalEntry(5, 0, "five", false),
+	}
+
+	// Only the messages before the first and after the last journaled message are fetched
+	fetched := []message.Message{{Id: 1}, {Id: 6}}
+	ranges := []messageRange{{before: 2}, {after: 5}}
+
+	history := reconcileTranscript(entries, fetched, ranges)
+	require.Equal(t, []uint64{1, 2, 5, 6}, messageIds(history.Messages))
+	require.Empty(t, history.Deleted)
+	require.Empty(t, history.EditHistory)
+}
+
+func TestReconcileOnlyFetchedRanges(t *testing.T) {
+	entries := []localdb.JournalEntry{
+		journalEntry(2, 0, "two", false),
+		journalEntry(3, 0, "three", false),
+	}
+
+	// Message 3 was not fetched, so is not assumed to have been deleted
+	history := reconcileTranscript(entries, nil, []messageRange{{after: 3}})
+	require.Equal(t, []uint64{2, 3}, messageIds(history.Messages))
+	require.Empty(t, history.Deleted)
+}
+
+func TestReconcileKeepsDeletedMessages(t *testing.T) {
+	entries := []localdb.JournalEntry{
+		journalEntry(1, 0, "one", false),
+		journalEntry(2, 0, "two", true),
+		journalEntry(3, 0, "three", false),
+	}
+
+	// Message 3 was within the fetched range, but was not returned
+	fetched := []message.Message{{Id: 1, Content: "one"}}
+
+	history := reconcileTranscript(entries, fetched, []messageRange{{}})
+	require.Equal(t, []uint64{1, 2, 3}, messageIds(history.Messages))
+	require.Equal(t, map[uint64]bool{2: true, 3: true}, history.Deleted)
+	require.Equal(t, "two", history.Messages[1].Content)
+}
+
+func TestReconcileKeepsEditHistory(t *testing.T) {
+	edited := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []localdb.JournalEntry{
+		journalEntry(1, 0, "first", false),
+		journalEntry(1, 1, "second", false),
+		journalEntry(2, 0, "original", false),
+	}
+
+	entries[1].Message.EditedTimestamp = &edited
+
+	fetched := []message.Message{
+		{Id: 1, Content: "second", EditedTimestamp: &edited},
+		{Id: 2, Content: "missed edit", EditedTimestamp: &edited},
+	}
+
+	history := reconcileTranscript(entries, fetched, []messageRange{{}})
+	require.Equal(t, []uint64{1, 2}, messageIds(history.Messages))
+	require.Equal(t, "second", history.Messages[0].Content)
+	require.Equal(t, "missed edit", history.Messages[1].Content)
+
+	require.Len(t, history.EditHistory[1], 1)
+	require.Equal(t, "first", history.EditHistory[1][0].Content)
+
+	require.Len(t, history.EditHistory[2], 1)
+	require.Equal(t, "original", history.EditHistory[2][0].Content)
+}
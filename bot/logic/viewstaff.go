@@ -7,6 +7,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/rxdn/gdl/objects/channel/embed"
 	"strings"
 )
@@ -141,5 +142,36 @@ func BuildViewStaffMessage(ctx context.Context, cmd registry.CommandContext, pag
 		}
 	}
 
+	// Add field for staff who are away, only if there are any
+	{
+		statuses, err := dbclient.Local.StaffAway.GetByGuild(ctx, cmd.GuildId())
+		if err != nil {
+			sentry.ErrorWithContext(err, cmd.ToErrorContext())
+		}
+
+		lower := perField * page
+		upper := perField * (page + 1)
+
+		if lower < len(statuses) {
+			if upper >= len(statuses) {
+				upper = len(statuses)
+			}
+
+			var content string
+			for i := lower; i < upper; i++ {
+				status := statuses[i]
+				content += fmt.Sprintf("• <@%d> until <t:%d:D>", status.UserId, status.Until.Unix())
+				if status.Note != nil {
+					content += fmt.Sprintf(": %s", utils.StringMax(*status.Note, 64, "..."))
+				}
+				content += "\n"
+			}
+			content = strings.TrimSuffix(content, "\n")
+
+			embed.AddField("Away", content, false)
+			isBlank = false
+		}
+	}
+
 	return embed, isBlank
 }
@@ -84,6 +84,14 @@ func SendWelcomeMessage(
 		}),
 	}
 
+	// The claim button is removed once the ticket is claimed, so it must remain the last button
+	buttons = append(buttons, component.BuildButton(component.Button{
+		Label:    cmd.GetMessage(i18n.TitleWatch),
+		CustomId: "watch",
+		Style:    component.ButtonStyleSecondary,
+		Emoji:    &emoji.Emoji{Name: "👀"},
+	}))
+
 	if !settings.HideClaimButton && !ticket.IsThread {
 		buttons = append(buttons, component.BuildButton(component.Button{
 			Label:    cmd.GetMessage(i18n.TitleClaim),
@@ -460,6 +468,19 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 	"discord_account_age": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
 		return fmt.Sprintf("<t:%d:R>", utils.SnowflakeToTime(ticket.UserId).Unix())
 	},
+	"labels": func(ctx context.Context, _ *worker.Context, ticket database.Ticket) string {
+		labels, err := GetFormattedTicketLabels(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			sentry.Error(err)
+			return ""
+		}
+
+		if labels == "" {
+			return "None"
+		}
+
+		return labels
+	},
 }
 
 type GroupSubstitutionFunc func(context.Context, *worker.Context, database.Ticket) map[string]string
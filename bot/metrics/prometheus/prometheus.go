@@ -38,6 +38,9 @@ var (
 	KafkaMessages  = newHistogramVec("kafka_messages", "topic")
 
 	CategoryUpdates = newCounter("category_updates")
+
+	SlaWarnings = newCounterVec("sla_warnings", "kind")
+	SlaBreaches = newCounterVec("sla_breaches", "kind")
 )
 
 func newCounter(name string) prometheus.Counter {
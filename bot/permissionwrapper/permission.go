@@ -54,6 +54,11 @@ func HasPermissions(ctx *worker.Context, guildId, userId uint64, permissions ...
 	return hasPermission
 }
 
+// GetEffectivePermissions returns the user's guild-wide permissions, ignoring channel overwrites
+func GetEffectivePermissions(ctx *worker.Context, guildId, userId uint64) (uint64, error) {
+	return getEffectivePermissions(ctx, guildId, userId)
+}
+
 func getAllPermissionsChannel(ctx *worker.Context, guildId, userId, channelId uint64) []permission.Permission {
 	permissions := make([]permission.Permission, 0)
 
@@ -0,0 +1,15 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const awayNoticeBackoff = time.Hour
+
+// TakeAwayNoticeToken returns false if a notice that the staff member is away was posted in the channel recently
+func TakeAwayNoticeToken(ctx context.Context, channelId, userId uint64) (bool, error) {
+	key := fmt.Sprintf("tickets:awaynotice:%d:%d", channelId, userId)
+	return Client.SetNX(ctx, key, 1, awayNoticeBackoff).Result()
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BulkOperationExpiry is how long a bulk operation preview can be confirmed for
+const BulkOperationExpiry = time.Minute * 5
+
+var ErrBulkOperationNotFound = errors.New("bulk operation not found")
+
+func SetBulkOperation(ctx context.Context, id string, data []byte) error {
+	key := fmt.Sprintf("tickets:bulk:%s", id)
+	return Client.Set(ctx, key, data, BulkOperationExpiry).Err()
+}
+
+// TakeBulkOperation removes the operation as it is read, so that it can only be confirmed once
+func TakeBulkOperation(ctx context.Context, id string) ([]byte, error) {
+	key := fmt.Sprintf("tickets:bulk:%s", id)
+	res, err := Client.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, ErrNil) {
+			return nil, ErrBulkOperationNotFound
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func DeleteBulkOperation(ctx context.Context, id string) error {
+	key := fmt.Sprintf("tickets:bulk:%s", id)
+	return Client.Del(ctx, key).Err()
+}
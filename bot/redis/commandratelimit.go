@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	renameRatelimit       = RateLimit{Limit: 2, Window: time.Minute * 10}
+	closeRequestRatelimit = RateLimit{Limit: 3, Window: time.Minute * 10}
+	tagRatelimit          = RateLimit{Limit: 5, Window: time.Second * 10}
+)
+
+func TakeRenameRatelimit(ctx context.Context, channelId uint64) (bool, error) {
+	key := fmt.Sprintf("tickets:ratelimit:rename:%d", channelId)
+
+	allowed, _, err := TakeRateLimitToken(ctx, NewRateLimitBucket(key, renameRatelimit))
+	return allowed, err
+}
+
+func TakeCloseRequestRatelimit(ctx context.Context, channelId uint64) (bool, time.Duration, error) {
+	key := fmt.Sprintf("tickets:ratelimit:close_request:%d", channelId)
+	return TakeRateLimitToken(ctx, NewRateLimitBucket(key, closeRequestRatelimit))
+}
+
+func TakeTagRatelimit(ctx context.Context, channelId, userId uint64) (bool, time.Duration, error) {
+	key := fmt.Sprintf("tickets:ratelimit:tag:%d:%d", channelId, userId)
+	return TakeRateLimitToken(ctx, NewRateLimitBucket(key, tagRatelimit))
+}
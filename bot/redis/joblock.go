@@ -0,0 +1,13 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TakeJobLock is used by periodic jobs that every worker runs, so that only one worker performs each run. The lock
+// is not released, it expires after ttl, which should be slightly less than the interval of the job.
+func TakeJobLock(ctx context.Context, job string, ttl time.Duration) (bool, error) {
+	return Client.SetNX(ctx, fmt.Sprintf("tickets:joblock:%s", job), 1, ttl).Result()
+}
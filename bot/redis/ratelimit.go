@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// Sliding window log: each bucket is a sorted set of request IDs, scored by the time of the request in milliseconds.
+// A token is only taken from the buckets if every bucket has space, so that one exhausted bucket does not drain the
+// others. If the request is denied, the number of milliseconds until it would be allowed is returned.
+//
+// KEYS: the buckets
+// ARGV: now, request ID, then the limit and window (ms) of each bucket in the same order as KEYS
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local id = ARGV[2]
+
+local retryAfter = 0
+for i, key in ipairs(KEYS) do
+	local limit = tonumber(ARGV[1 + (i * 2)])
+	local window = tonumber(ARGV[2 + (i * 2)])
+
+	redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+
+	if redis.call("ZCARD", key) >= limit then
+		local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+		local wait = window
+		if oldest[2] then
+			wait = tonumber(oldest[2]) + window - now
+		end
+
+		if wait > retryAfter then
+			retryAfter = wait
+		end
+	end
+end
+
+if retryAfter > 0 then
+	return retryAfter
+end
+
+for i, key in ipairs(KEYS) do
+	redis.call("ZADD", key, now, id)
+	redis.call("PEXPIRE", key, tonumber(ARGV[2 + (i * 2)]))
+end
+
+return 0
+`)
+
+// RateLimit allows Limit requests in any period of length Window. A limit of 0 disables the rate limit.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+type RateLimitBucket struct {
+	Key string
+	RateLimit
+}
+
+func NewRateLimitBucket(key string, limit RateLimit) RateLimitBucket {
+	return RateLimitBucket{
+		Key:       key,
+		RateLimit: limit,
+	}
+}
+
+// TakeRateLimitToken takes a token from each of the buckets, if all of them have space. If the request is denied,
+// the duration until it would be allowed is returned. Disabled buckets are ignored.
+func TakeRateLimitToken(ctx context.Context, buckets ...RateLimitBucket) (bool, time.Duration, error) {
+	keys := make([]string, 0, len(buckets))
+	args := make([]interface{}, 0, 2+len(buckets)*2)
+	args = append(args, time.Now().UnixMilli(), uuid.NewString())
+
+	for _, bucket := range buckets {
+		if bucket.Limit <= 0 {
+			continue
+		}
+
+		keys = append(keys, bucket.Key)
+		args = append(args, bucket.Limit, bucket.Window.Milliseconds())
+	}
+
+	if len(keys) == 0 {
+		return true, 0, nil
+	}
+
+	res, err := slidingWindowScript.Run(ctx, Client, keys, args...).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	retryAfter, ok := res.(int64)
+	if !ok {
+		return false, 0, fmt.Errorf("ratelimit script returned %v, not an int64", res)
+	}
+
+	if retryAfter > 0 {
+		return false, time.Duration(retryAfter) * time.Millisecond, nil
+	}
+
+	return true, 0, nil
+}
@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ticketLabelsChannel is subscribed to by the dashboard, which shows each ticket's labels alongside its status
+const ticketLabelsChannel = "tickets:labelupdates"
+
+type TicketLabel struct {
+	Id     int     `json:"id"`
+	Name   string  `json:"name"`
+	Colour int     `json:"colour"`
+	Emoji  *string `json:"emoji,omitempty"`
+}
+
+type TicketLabelsUpdate struct {
+	GuildId  uint64        `json:"guild_id,string"`
+	TicketId int           `json:"ticket_id"`
+	Labels   []TicketLabel `json:"labels"`
+}
+
+func PublishTicketLabels(ctx context.Context, update TicketLabelsUpdate) error {
+	marshalled, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return Client.Publish(ctx, ticketLabelsChannel, string(marshalled)).Err()
+}
@@ -1,51 +1,34 @@
 package redis
 
 import (
+	"context"
 	"fmt"
-	"github.com/TicketsBot/common/utils"
-	"github.com/go-redis/redis/v8"
 	"time"
 )
 
-var script = redis.NewScript(`
-local current = redis.call("GET", KEYS[1])
-local notExists = (not current)
-
-if not current then
-	current = 0
-else
-	current = tonumber(current)
-end
-
-local success = 0
-if current < tonumber(ARGV[1]) then
-	redis.call("INCR", KEYS[1])
-
-	if notExists then
-		redis.call("EXPIRE", KEYS[1], ARGV[2])
-	end
-
-	success = 1
-end
-
-return success
-`)
-
-var TicketOpenLimit = 10
-var TicketOpenLimitInterval = time.Second * 30
+// OpenRateLimits are checked together when a ticket is opened. The guild bucket protects the Discord API, while the
+// user and panel buckets stop a single user or panel from exhausting the guild bucket for everyone else.
+type OpenRateLimits struct {
+	Guild RateLimit
+	User  RateLimit
+	Panel RateLimit
+}
 
-func TakeTicketRateLimitToken(client *redis.Client, guildId uint64) (bool, error) {
-	key := fmt.Sprintf("tickets:openratelimit:%d", guildId)
+var DefaultOpenRateLimits = OpenRateLimits{
+	Guild: RateLimit{Limit: 10, Window: time.Second * 30},
+	User:  RateLimit{Limit: 3, Window: time.Minute},
+	Panel: RateLimit{Limit: 0, Window: 0},
+}
 
-	res, err := script.Run(utils.DefaultContext(), client, []string{key}, TicketOpenLimit, TicketOpenLimitInterval.Seconds()).Result()
-	if err != nil {
-		return false, err
+func TakeTicketRateLimitToken(ctx context.Context, guildId, userId uint64, panelId *int, limits OpenRateLimits) (bool, time.Duration, error) {
+	buckets := []RateLimitBucket{
+		NewRateLimitBucket(fmt.Sprintf("tickets:openratelimit:guild:%d", guildId), limits.Guild),
+		NewRateLimitBucket(fmt.Sprintf("tickets:openratelimit:user:%d:%d", guildId, userId), limits.User),
 	}
 
-	i, ok := res.(int64)
-	if !ok {
-		return false, fmt.Errorf("ratelimit token returned %v, not an int64", res)
+	if panelId != nil {
+		buckets = append(buckets, NewRateLimitBucket(fmt.Sprintf("tickets:openratelimit:panel:%d", *panelId), limits.Panel))
 	}
 
-	return i == 1, nil
+	return TakeRateLimitToken(ctx, buckets...)
 }
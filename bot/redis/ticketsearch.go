@@ -0,0 +1,32 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TicketSearchExpiry is how long the pages of a search can be browsed for
+const TicketSearchExpiry = time.Minute * 15
+
+var ErrTicketSearchNotFound = errors.New("ticket search not found")
+
+func SetTicketSearch(ctx context.Context, id string, data []byte) error {
+	key := fmt.Sprintf("tickets:search:%s", id)
+	return Client.Set(ctx, key, data, TicketSearchExpiry).Err()
+}
+
+func GetTicketSearch(ctx context.Context, id string) ([]byte, error) {
+	key := fmt.Sprintf("tickets:search:%s", id)
+	res, err := Client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, ErrNil) {
+			return nil, ErrTicketSearchNotFound
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
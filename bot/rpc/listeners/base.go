@@ -3,8 +3,11 @@ package listeners
 import (
 	"context"
 	"errors"
+	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/rxdn/gdl/cache"
 	"time"
@@ -62,3 +65,34 @@ func (b *BaseListener) ContextForGuild(ctx context.Context, guildId uint64) (*wo
 		}, nil
 	}
 }
+
+// TicketContext builds a context for the actor to act on the ticket from the dashboard. Returns false if the ticket
+// does not exist, or is not open.
+func (b *BaseListener) TicketContext(
+	ctx context.Context,
+	guildId uint64,
+	ticketId int,
+	actorId uint64,
+) (cmdcontext.DashboardContext, database.Ticket, bool, error) {
+	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, guildId)
+	if err != nil {
+		return cmdcontext.DashboardContext{}, database.Ticket{}, false, err
+	}
+
+	if ticket.Id == 0 || ticket.GuildId != guildId || !ticket.Open || ticket.ChannelId == nil {
+		return cmdcontext.DashboardContext{}, database.Ticket{}, false, nil
+	}
+
+	worker, err := b.ContextForGuild(ctx, guildId)
+	if err != nil {
+		return cmdcontext.DashboardContext{}, database.Ticket{}, false, err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return cmdcontext.DashboardContext{}, database.Ticket{}, false, err
+	}
+
+	cmd := cmdcontext.NewDashboardContext(ctx, worker, guildId, *ticket.ChannelId, actorId, premiumTier)
+	return cmd, ticket, true, nil
+}
@@ -0,0 +1,94 @@
+package listeners
+
+import (
+	"fmt"
+
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/worker/config"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+)
+
+type registration struct {
+	name         string
+	defaultTopic string
+	config       config.RpcListener
+	build        func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener
+}
+
+func registrations() []registration {
+	return []registration{
+		{
+			name:         "category_update",
+			defaultTopic: "tickets.rpc.categoryupdate",
+			config:       config.Conf.Rpc.CategoryUpdate,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketStatusUpdater(cache, logger)
+			},
+		},
+		{
+			name:         "adopt",
+			defaultTopic: "tickets.rpc.adopt",
+			config:       config.Conf.Rpc.Adopt,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketAdopter(cache, logger)
+			},
+		},
+		{
+			name:         "close",
+			defaultTopic: "tickets.rpc.close",
+			config:       config.Conf.Rpc.Close,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketCloser(cache, logger)
+			},
+		},
+		{
+			name:         "send_message",
+			defaultTopic: "tickets.rpc.sendmessage",
+			config:       config.Conf.Rpc.SendMessage,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketMessenger(cache, logger)
+			},
+		},
+		{
+			name:         "claim",
+			defaultTopic: "tickets.rpc.claim",
+			config:       config.Conf.Rpc.Claim,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketClaimer(cache, logger)
+			},
+		},
+		{
+			name:         "add_member",
+			defaultTopic: "tickets.rpc.addmember",
+			config:       config.Conf.Rpc.AddMember,
+			build: func(cache *cache.PgCache, logger *zap.Logger) rpc.Listener {
+				return NewTicketMemberAdder(cache, logger)
+			},
+		},
+	}
+}
+
+// Build returns the listeners that are enabled in the config, keyed by the topic that each consumes from
+func Build(cache *cache.PgCache, logger *zap.Logger) (map[string]rpc.Listener, error) {
+	listeners := make(map[string]rpc.Listener)
+	for _, r := range registrations() {
+		if !r.config.Enabled {
+			logger.Info("RPC listener is disabled", zap.String("listener", r.name))
+			continue
+		}
+
+		topic := r.defaultTopic
+		if r.config.Topic != "" {
+			topic = r.config.Topic
+		}
+
+		if _, ok := listeners[topic]; ok {
+			return nil, fmt.Errorf("multiple RPC listeners consume from topic %s", topic)
+		}
+
+		listeners[topic] = r.build(cache, logger.With(zap.String("listener", r.name)))
+	}
+
+	return listeners, nil
+}
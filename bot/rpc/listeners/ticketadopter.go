@@ -0,0 +1,108 @@
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/database"
+	cmdcontext "github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+)
+
+// TicketAdopter handles requests from the dashboard to register an existing channel or thread as a ticket
+type TicketAdopter struct {
+	*BaseListener
+	logger *zap.Logger
+}
+
+type TicketAdoptRequest struct {
+	GuildId   uint64 `json:"guild_id"`
+	ChannelId uint64 `json:"channel_id"`
+	OpenerId  uint64 `json:"opener_id"`
+	PanelId   *int   `json:"panel_id"`
+	ActorId   uint64 `json:"actor_id"`
+}
+
+var _ rpc.Listener = (*TicketAdopter)(nil)
+
+func NewTicketAdopter(cache *cache.PgCache, logger *zap.Logger) *TicketAdopter {
+	return &TicketAdopter{
+		BaseListener: NewBaseListener(cache),
+		logger:       logger,
+	}
+}
+
+func (a *TicketAdopter) HandleMessage(ctx context.Context, message []byte) {
+	var req TicketAdoptRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		a.logger.Error("Failed to unmarshal event", zap.Error(err))
+		return
+	}
+
+	logger := a.logger.With(
+		zap.Uint64("guild_id", req.GuildId),
+		zap.Uint64("channel_id", req.ChannelId),
+		zap.Uint64("actor_id", req.ActorId),
+	)
+
+	worker, err := a.ContextForGuild(ctx, req.GuildId)
+	if err != nil {
+		logger.Error("Failed to get worker context", zap.Error(err))
+		return
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, req.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		logger.Error("Failed to get premium tier", zap.Error(err))
+		return
+	}
+
+	cmd := cmdcontext.NewDashboardContext(ctx, worker, req.GuildId, req.ChannelId, req.ActorId, premiumTier)
+
+	permLevel, err := cmd.UserPermissionLevel(ctx)
+	if err != nil {
+		logger.Error("Failed to get actor permission level", zap.Error(err))
+		return
+	}
+
+	if permLevel < permission.Support {
+		logger.Debug("Actor does not have permission to adopt tickets")
+		return
+	}
+
+	ch, err := worker.GetChannel(req.ChannelId)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return
+	}
+
+	var panel *database.Panel
+	if req.PanelId != nil {
+		p, err := dbclient.Client.Panel.GetById(ctx, *req.PanelId)
+		if err != nil {
+			logger.Error("Failed to get panel", zap.Error(err))
+			return
+		}
+
+		if p.PanelId == 0 || p.GuildId != req.GuildId {
+			logger.Debug("Panel does not belong to guild", zap.Int("panel_id", *req.PanelId))
+			return
+		}
+
+		panel = &p
+	}
+
+	// The dashboard has no interaction to tell us whether we were granted Manage Webhooks, so don't request it
+	ticket, err := logic.AdoptTicket(ctx, &cmd, ch, req.OpenerId, panel, false)
+	if err != nil {
+		logger.Error("Failed to adopt channel", zap.Error(err))
+		return
+	}
+
+	logger.Debug("Adopted channel as ticket", zap.Int("ticket_id", ticket.Id))
+}
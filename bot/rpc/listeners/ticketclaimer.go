@@ -0,0 +1,91 @@
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+)
+
+// TicketClaimer handles requests from the dashboard for a staff member to claim a ticket
+type TicketClaimer struct {
+	*BaseListener
+	logger *zap.Logger
+}
+
+type TicketClaimRequest struct {
+	GuildId  uint64 `json:"guild_id"`
+	TicketId int    `json:"ticket_id"`
+	ActorId  uint64 `json:"actor_id"`
+}
+
+var _ rpc.Listener = (*TicketClaimer)(nil)
+
+func NewTicketClaimer(cache *cache.PgCache, logger *zap.Logger) *TicketClaimer {
+	return &TicketClaimer{
+		BaseListener: NewBaseListener(cache),
+		logger:       logger,
+	}
+}
+
+func (c *TicketClaimer) HandleMessage(ctx context.Context, message []byte) {
+	var req TicketClaimRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		c.logger.Error("Failed to unmarshal event", zap.Error(err))
+		return
+	}
+
+	logger := c.logger.With(
+		zap.Uint64("guild_id", req.GuildId),
+		zap.Int("ticket_id", req.TicketId),
+		zap.Uint64("actor_id", req.ActorId),
+	)
+
+	cmd, ticket, ok, err := c.TicketContext(ctx, req.GuildId, req.TicketId, req.ActorId)
+	if err != nil {
+		logger.Error("Failed to build ticket context", zap.Error(err))
+		return
+	}
+
+	if !ok {
+		logger.Debug("Ticket does not exist or is not open")
+		return
+	}
+
+	permLevel, err := cmd.UserPermissionLevel(ctx)
+	if err != nil {
+		logger.Error("Failed to get actor permission level", zap.Error(err))
+		return
+	}
+
+	if permLevel < permission.Support {
+		logger.Debug("Actor does not have permission to claim tickets")
+		return
+	}
+
+	// Thread tickets cannot be claimed
+	if ticket.IsThread {
+		logger.Debug("Tried to claim a thread ticket")
+		return
+	}
+
+	if err := logic.ClaimTicket(ctx, &cmd, ticket, req.ActorId); err != nil {
+		logger.Error("Failed to claim ticket", zap.Error(err))
+		return
+	}
+
+	e := utils.BuildEmbed(&cmd, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", req.ActorId))
+	if _, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, e); err != nil {
+		logger.Error("Failed to send claim message", zap.Error(err))
+		return
+	}
+
+	logger.Debug("Claimed ticket")
+}
@@ -0,0 +1,65 @@
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+)
+
+// TicketCloser handles requests from the dashboard to close a ticket
+type TicketCloser struct {
+	*BaseListener
+	logger *zap.Logger
+}
+
+type TicketCloseRequest struct {
+	GuildId  uint64  `json:"guild_id"`
+	TicketId int     `json:"ticket_id"`
+	ActorId  uint64  `json:"actor_id"`
+	Reason   *string `json:"reason"`
+}
+
+var _ rpc.Listener = (*TicketCloser)(nil)
+
+func NewTicketCloser(cache *cache.PgCache, logger *zap.Logger) *TicketCloser {
+	return &TicketCloser{
+		BaseListener: NewBaseListener(cache),
+		logger:       logger,
+	}
+}
+
+func (c *TicketCloser) HandleMessage(ctx context.Context, message []byte) {
+	var req TicketCloseRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		c.logger.Error("Failed to unmarshal event", zap.Error(err))
+		return
+	}
+
+	logger := c.logger.With(
+		zap.Uint64("guild_id", req.GuildId),
+		zap.Int("ticket_id", req.TicketId),
+		zap.Uint64("actor_id", req.ActorId),
+	)
+
+	cmd, _, ok, err := c.TicketContext(ctx, req.GuildId, req.TicketId, req.ActorId)
+	if err != nil {
+		logger.Error("Failed to build ticket context", zap.Error(err))
+		return
+	}
+
+	if !ok {
+		logger.Debug("Ticket does not exist or is not open")
+		return
+	}
+
+	// CloseTicket checks that the actor is allowed to close the ticket
+	if err := logic.CloseTicket(ctx, &cmd, req.Reason, false); err != nil {
+		logger.Error("Failed to close ticket", zap.Error(err))
+		return
+	}
+
+	logger.Debug("Closed ticket")
+}
@@ -0,0 +1,94 @@
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+)
+
+// TicketMemberAdder handles requests from the dashboard to give a user access to a ticket
+type TicketMemberAdder struct {
+	*BaseListener
+	logger *zap.Logger
+}
+
+type TicketAddMemberRequest struct {
+	GuildId  uint64 `json:"guild_id"`
+	TicketId int    `json:"ticket_id"`
+	ActorId  uint64 `json:"actor_id"`
+	UserId   uint64 `json:"user_id"`
+}
+
+var _ rpc.Listener = (*TicketMemberAdder)(nil)
+
+func NewTicketMemberAdder(cache *cache.PgCache, logger *zap.Logger) *TicketMemberAdder {
+	return &TicketMemberAdder{
+		BaseListener: NewBaseListener(cache),
+		logger:       logger,
+	}
+}
+
+func (a *TicketMemberAdder) HandleMessage(ctx context.Context, message []byte) {
+	var req TicketAddMemberRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		a.logger.Error("Failed to unmarshal event", zap.Error(err))
+		return
+	}
+
+	logger := a.logger.With(
+		zap.Uint64("guild_id", req.GuildId),
+		zap.Int("ticket_id", req.TicketId),
+		zap.Uint64("actor_id", req.ActorId),
+		zap.Uint64("user_id", req.UserId),
+	)
+
+	cmd, ticket, ok, err := a.TicketContext(ctx, req.GuildId, req.TicketId, req.ActorId)
+	if err != nil {
+		logger.Error("Failed to build ticket context", zap.Error(err))
+		return
+	}
+
+	if !ok {
+		logger.Debug("Ticket does not exist or is not open")
+		return
+	}
+
+	permLevel, err := cmd.UserPermissionLevel(ctx)
+	if err != nil {
+		logger.Error("Failed to get actor permission level", zap.Error(err))
+		return
+	}
+
+	// As with /add, the opener can add users to their own ticket
+	if permLevel == permission.Everyone && ticket.UserId != req.ActorId {
+		logger.Debug("Actor does not have permission to add users to the ticket")
+		return
+	}
+
+	if err := logic.AddTicketMember(ctx, cmd.Worker(), ticket, req.UserId); err != nil {
+		logger.Error("Failed to add user to ticket", zap.Error(err))
+		return
+	}
+
+	// The dashboard only adds users permanently
+	if err := dbclient.Local.TemporaryAccess.Delete(ctx, ticket.GuildId, ticket.Id, req.UserId); err != nil {
+		logger.Error("Failed to remove temporary access", zap.Error(err))
+		return
+	}
+
+	e := utils.BuildEmbed(&cmd, customisation.Green, i18n.TitleAdd, i18n.MessageAddSuccess, nil, req.UserId, *ticket.ChannelId)
+	if _, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, e); err != nil {
+		logger.Error("Failed to send add message", zap.Error(err))
+		return
+	}
+
+	logger.Debug("Added user to ticket")
+}
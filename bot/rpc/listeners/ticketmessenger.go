@@ -0,0 +1,92 @@
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/rpc"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/rxdn/gdl/cache"
+	"go.uber.org/zap"
+	"unicode/utf8"
+)
+
+// TicketMessenger handles requests from the dashboard for a staff member to send a message into a ticket
+type TicketMessenger struct {
+	*BaseListener
+	logger *zap.Logger
+}
+
+type TicketSendMessageRequest struct {
+	GuildId  uint64 `json:"guild_id"`
+	TicketId int    `json:"ticket_id"`
+	ActorId  uint64 `json:"actor_id"`
+	Content  string `json:"content"`
+}
+
+// Messages are sent as the content of a webhook message, so must fit within Discord's message length limit
+const maxDashboardMessageLength = 2000
+
+var _ rpc.Listener = (*TicketMessenger)(nil)
+
+func NewTicketMessenger(cache *cache.PgCache, logger *zap.Logger) *TicketMessenger {
+	return &TicketMessenger{
+		BaseListener: NewBaseListener(cache),
+		logger:       logger,
+	}
+}
+
+func (m *TicketMessenger) HandleMessage(ctx context.Context, message []byte) {
+	var req TicketSendMessageRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		m.logger.Error("Failed to unmarshal event", zap.Error(err))
+		return
+	}
+
+	logger := m.logger.With(
+		zap.Uint64("guild_id", req.GuildId),
+		zap.Int("ticket_id", req.TicketId),
+		zap.Uint64("actor_id", req.ActorId),
+	)
+
+	if length := utf8.RuneCountInString(req.Content); length == 0 || length > maxDashboardMessageLength {
+		logger.Debug("Message content has invalid length", zap.Int("length", length))
+		return
+	}
+
+	cmd, ticket, ok, err := m.TicketContext(ctx, req.GuildId, req.TicketId, req.ActorId)
+	if err != nil {
+		logger.Error("Failed to build ticket context", zap.Error(err))
+		return
+	}
+
+	if !ok {
+		logger.Debug("Ticket does not exist or is not open")
+		return
+	}
+
+	permLevel, err := cmd.UserPermissionLevel(ctx)
+	if err != nil {
+		logger.Error("Failed to get actor permission level", zap.Error(err))
+		return
+	}
+
+	if permLevel < permission.Support {
+		logger.Debug("Actor does not have permission to send messages from the dashboard")
+		return
+	}
+
+	author, err := cmd.User()
+	if err != nil {
+		logger.Error("Failed to get actor", zap.Error(err))
+		return
+	}
+
+	msg, err := logic.SendStaffMessage(ctx, cmd.Worker(), ticket, author, req.Content)
+	if err != nil {
+		logger.Error("Failed to send message into ticket", zap.Error(err))
+		return
+	}
+
+	logger.Debug("Sent message into ticket", zap.Uint64("message_id", msg.Id))
+}
@@ -0,0 +1,69 @@
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	draining    atomic.Bool
+	drainingCh  = make(chan struct{})
+	drainOnce   sync.Once
+	wg          sync.WaitGroup
+	outstanding atomic.Int64
+)
+
+// Go runs f in a new goroutine, which the worker waits for before exiting
+func Go(f func()) {
+	wg.Add(1)
+	outstanding.Add(1)
+
+	go func() {
+		defer func() {
+			outstanding.Add(-1)
+			wg.Done()
+		}()
+
+		f()
+	}()
+}
+
+// BeginDrain marks the worker as unready and stops listeners from taking new work. It is safe to call more than once.
+func BeginDrain() {
+	drainOnce.Do(func() {
+		draining.Store(true)
+		close(drainingCh)
+	})
+}
+
+func IsDraining() bool {
+	return draining.Load()
+}
+
+// Draining returns a channel that is closed once the worker begins draining
+func Draining() <-chan struct{} {
+	return drainingCh
+}
+
+// Outstanding returns the number of goroutines started with Go that have not yet returned
+func Outstanding() int64 {
+	return outstanding.Load()
+}
+
+// Wait blocks until all goroutines started with Go have returned, or the context is cancelled. Returns whether all
+// goroutines returned.
+func Wait(ctx context.Context) bool {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		wg.Wait()
+	}()
+
+	select {
+	case <-ch:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
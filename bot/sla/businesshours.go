@@ -0,0 +1,150 @@
+package sla
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is the part of a day that falls within business hours, in minutes since midnight
+type Window struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func (w *Window) isOpen() bool {
+	return w != nil && w.End > w.Start
+}
+
+// BusinessHours is a weekly calendar. SLA clocks only run while inside one of the windows.
+type BusinessHours struct {
+	Timezone string `json:"timezone"`
+	// Days is indexed by time.Weekday. Days without a window are not business days.
+	Days [7]*Window `json:"days"`
+}
+
+var (
+	ErrInvalidDays  = errors.New("invalid days")
+	ErrInvalidClock = errors.New("invalid time of day")
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func (h BusinessHours) Location() (*time.Location, error) {
+	return time.LoadLocation(h.Timezone)
+}
+
+func (h BusinessHours) IsEmpty() bool {
+	for _, window := range h.Days {
+		if window.isOpen() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add returns the time at which d of business time will have passed since from
+func (h BusinessHours) Add(from time.Time, d time.Duration) (time.Time, error) {
+	if h.IsEmpty() {
+		return from.Add(d), nil
+	}
+
+	location, err := h.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t := from.In(location)
+	for {
+		year, month, day := t.Date()
+		nextDay := time.Date(year, month, day+1, 0, 0, 0, 0, location)
+
+		window := h.Days[t.Weekday()]
+		if !window.isOpen() {
+			t = nextDay
+			continue
+		}
+
+		// The window is in wall clock time, which may not be the time elapsed since midnight on days that the clocks
+		// change
+		start := time.Date(year, month, day, 0, window.Start, 0, 0, location)
+		end := time.Date(year, month, day, 0, window.End, 0, 0, location)
+
+		if !t.Before(end) {
+			t = nextDay
+			continue
+		}
+
+		if t.Before(start) {
+			t = start
+		}
+
+		if remaining := end.Sub(t); d <= remaining {
+			return t.Add(d), nil
+		} else {
+			d -= remaining
+			t = nextDay
+		}
+	}
+}
+
+// ParseDays parses a comma separated list of days or ranges of days, e.g. "mon-fri" or "mon,wed,sat-sun"
+func ParseDays(raw string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(strings.ToLower(raw), ",") {
+		part = strings.TrimSpace(part)
+
+		first, last, isRange := strings.Cut(part, "-")
+		from, ok := weekdays[strings.TrimSpace(first)]
+		if !ok {
+			return nil, ErrInvalidDays
+		}
+
+		to := from
+		if isRange {
+			if to, ok = weekdays[strings.TrimSpace(last)]; !ok {
+				return nil, ErrInvalidDays
+			}
+		}
+
+		// Ranges may wrap around the end of the week, e.g. fri-mon
+		for day := from; ; day = (day + 1) % 7 {
+			days = append(days, day)
+
+			if day == to {
+				break
+			}
+		}
+	}
+
+	return days, nil
+}
+
+// ParseClock parses a time of day in the 24-hour HH:MM format, returning the number of minutes since midnight.
+// 24:00 is accepted as the end of the day.
+func ParseClock(raw string) (int, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(strings.TrimSpace(raw), "%d:%d", &hours, &minutes); err != nil {
+		return 0, ErrInvalidClock
+	}
+
+	if hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > 24*60 {
+		return 0, ErrInvalidClock
+	}
+
+	return hours*60 + minutes, nil
+}
+
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,254 @@
+package sla
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func weekdayHours(timezone string, start, end int) BusinessHours {
+	hours := BusinessHours{Timezone: timezone}
+	for day := time.Monday; day <= time.Friday; day++ {
+		hours.Days[day] = &Window{Start: start, End: end}
+	}
+
+	return hours
+}
+
+func TestAddEmpty(t *testing.T) {
+	from := time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	result, err := BusinessHours{Timezone: "UTC"}.Add(from, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, from.Add(time.Hour), result)
+}
+
+func TestAdd(t *testing.T) {
+	hours := weekdayHours("UTC", 9*60, 17*60)
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		duration time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "within window",
+			from:     time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC), // Monday
+			duration: time.Hour * 2,
+			expected: time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before window",
+			from:     time.Date(2024, 1, 8, 6, 0, 0, 0, time.UTC),
+			duration: time.Hour,
+			expected: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "ends exactly at close",
+			from:     time.Date(2024, 1, 8, 16, 0, 0, 0, time.UTC),
+			duration: time.Hour,
+			expected: time.Date(2024, 1, 8, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "carries into next day",
+			from:     time.Date(2024, 1, 8, 16, 0, 0, 0, time.UTC),
+			duration: time.Hour * 2,
+			expected: time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "after window",
+			from:     time.Date(2024, 1, 8, 20, 0, 0, 0, time.UTC),
+			duration: time.Minute * 30,
+			expected: time.Date(2024, 1, 9, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "skips weekend",
+			from:     time.Date(2024, 1, 12, 16, 0, 0, 0, time.UTC), // Friday
+			duration: time.Hour * 2,
+			expected: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "starts on weekend",
+			from:     time.Date(2024, 1, 13, 12, 0, 0, 0, time.UTC), // Saturday
+			duration: time.Hour,
+			expected: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spans multiple days",
+			from:     time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+			duration: time.Hour * 20,
+			expected: time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := hours.Add(test.from, test.duration)
+			require.NoError(t, err)
+			require.True(t, test.expected.Equal(result), "expected %s, got %s", test.expected, result)
+		})
+	}
+}
+
+func TestAddDaylightSaving(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	hours := BusinessHours{Timezone: "Europe/London"}
+	for day := range hours.Days {
+		hours.Days[day] = &Window{Start: 9 * 60, End: 17 * 60}
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		duration time.Duration
+		expected time.Time
+	}{
+		{
+			// Clocks go forward at 01:00 on the 31st of March 2024
+			name:     "clocks go forward",
+			from:     time.Date(2024, 3, 31, 8, 0, 0, 0, london),
+			duration: time.Hour,
+			expected: time.Date(2024, 3, 31, 10, 0, 0, 0, london),
+		},
+		{
+			// Clocks go back at 02:00 on the 27th of October 2024
+			name:     "clocks go back",
+			from:     time.Date(2024, 10, 27, 7, 0, 0, 0, london),
+			duration: time.Minute * 30,
+			expected: time.Date(2024, 10, 27, 9, 30, 0, 0, london),
+		},
+		{
+			name:     "carries over clocks going forward",
+			from:     time.Date(2024, 3, 30, 16, 0, 0, 0, london),
+			duration: time.Hour * 2,
+			expected: time.Date(2024, 3, 31, 10, 0, 0, 0, london),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := hours.Add(test.from, test.duration)
+			require.NoError(t, err)
+			require.True(t, test.expected.Equal(result), "expected %s, got %s", test.expected, result.In(london))
+		})
+	}
+}
+
+func TestAddWindowEndingAtMidnight(t *testing.T) {
+	hours := BusinessHours{Timezone: "UTC"}
+	for day := range hours.Days {
+		hours.Days[day] = &Window{Start: 22 * 60, End: 24 * 60}
+	}
+
+	result, err := hours.Add(time.Date(2024, 1, 8, 23, 0, 0, 0, time.UTC), time.Minute*30)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 8, 23, 30, 0, 0, time.UTC), result)
+
+	result, err = hours.Add(time.Date(2024, 1, 8, 23, 0, 0, 0, time.UTC), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), result)
+
+	result, err = hours.Add(time.Date(2024, 1, 8, 23, 30, 0, 0, time.UTC), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 9, 22, 30, 0, 0, time.UTC), result)
+}
+
+func TestAddWeekWrapAround(t *testing.T) {
+	days, err := ParseDays("fri-mon")
+	require.NoError(t, err)
+
+	hours := BusinessHours{Timezone: "UTC"}
+	for _, day := range days {
+		hours.Days[day] = &Window{Start: 9 * 60, End: 17 * 60}
+	}
+
+	// Monday to Friday are skipped from Monday evening
+	result, err := hours.Add(time.Date(2024, 1, 8, 16, 0, 0, 0, time.UTC), time.Hour*2)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC), result)
+
+	// The weekend is included
+	result, err = hours.Add(time.Date(2024, 1, 12, 16, 0, 0, 0, time.UTC), time.Hour*2)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC), result)
+}
+
+func TestAddInvalidTimezone(t *testing.T) {
+	hours := weekdayHours("Not/AZone", 9*60, 17*60)
+
+	_, err := hours.Add(time.Now(), time.Hour)
+	require.Error(t, err)
+}
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []time.Weekday
+	}{
+		{"mon", []time.Weekday{time.Monday}},
+		{"mon-fri", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+		{"fri-mon", []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}},
+		{"sat-sun", []time.Weekday{time.Saturday, time.Sunday}},
+		{"mon,wed,sat-sun", []time.Weekday{time.Monday, time.Wednesday, time.Saturday, time.Sunday}},
+		{" Mon - Tue , THU ", []time.Weekday{time.Monday, time.Tuesday, time.Thursday}},
+		{"sun-sat", []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			days, err := ParseDays(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, days)
+		})
+	}
+}
+
+func TestParseDaysInvalid(t *testing.T) {
+	for _, input := range []string{"", "monday", "mon-", "-fri", "mon,,fri", "mon-xyz"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDays(input)
+			require.ErrorIs(t, err, ErrInvalidDays)
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"00:00", 0},
+		{"09:00", 9 * 60},
+		{"9:30", 9*60 + 30},
+		{"17:45", 17*60 + 45},
+		{"23:59", 23*60 + 59},
+		{"24:00", 24 * 60},
+		{" 08:15 ", 8*60 + 15},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			minutes, err := ParseClock(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, minutes)
+		})
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	require.Equal(t, "00:00", FormatClock(0))
+	require.Equal(t, "09:05", FormatClock(9*60+5))
+	require.Equal(t, "24:00", FormatClock(24*60))
+}
+
+func TestParseClockInvalid(t *testing.T) {
+	for _, input := range []string{"", "9", "abc", "24:01", "25:00", "12:60", "-1:00", "12:-5"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseClock(input)
+			require.ErrorIs(t, err, ErrInvalidClock)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sla
+
+import (
+	"time"
+)
+
+// Kind is the target which an SLA clock measures
+type Kind string
+
+const (
+	// KindFirstResponse runs from the ticket being opened until the first staff response
+	KindFirstResponse Kind = "first_response"
+	// KindNextResponse runs from a message by anyone other than staff, such as the opener or a user added to the ticket,
+	// until the next staff response, after the first response
+	KindNextResponse Kind = "next_response"
+	// KindResolution runs from the ticket being opened until it is closed
+	KindResolution Kind = "resolution"
+)
+
+var Kinds = []Kind{KindFirstResponse, KindNextResponse, KindResolution}
+
+// Stage is what has happened to an SLA clock
+type Stage string
+
+const (
+	StageWarning Stage = "warning"
+	StageBreach  Stage = "breach"
+)
+
+// DefaultWarningPercent is how far through the target a warning is posted, if the policy does not set its own
+const DefaultWarningPercent = 80
+
+// Deadlines returns when a warning should be posted and when the target is breached for a clock which started at
+// start. Business hours are only taken into account if hours is non-nil.
+func Deadlines(start time.Time, target time.Duration, warningPercent int, hours *BusinessHours) (warning, breach time.Time, err error) {
+	warningAfter := target * time.Duration(warningPercent) / 100
+
+	if hours == nil {
+		return start.Add(warningAfter), start.Add(target), nil
+	}
+
+	if warning, err = hours.Add(start, warningAfter); err != nil {
+		return
+	}
+
+	breach, err = hours.Add(start, target)
+	return
+}
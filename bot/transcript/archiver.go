@@ -0,0 +1,97 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+)
+
+// Embeds are limited to 25 fields, with values of up to 1024 characters
+const (
+	archiverMaxFields      = 25
+	archiverMaxFieldLength = 1024
+)
+
+// ArchiverMessages returns the messages to store with the archiver. The archiver only stores the content, embeds and
+// attachments of each message, so deleted messages and previous revisions of edited messages are marked with an
+// additional embed. Linked tickets are listed in an embed on the first message.
+func ArchiverMessages(transcript Transcript) []message.Message {
+	// The archiver has nowhere else to store the links, so a ticket without messages is given one to hold them
+	if len(transcript.Messages) == 0 && len(transcript.Links) > 0 {
+		return []message.Message{{Embeds: []embed.Embed{linksEmbed(transcript.Links)}}}
+	}
+
+	messages := make([]message.Message, len(transcript.Messages))
+	for i, msg := range transcript.Messages {
+		var annotations []embed.Embed
+		if i == 0 && len(transcript.Links) > 0 {
+			annotations = append(annotations, linksEmbed(transcript.Links))
+		}
+
+		if transcript.Deleted[msg.Id] {
+			annotations = append(annotations, embed.Embed{
+				Title:       "Deleted",
+				Description: "This message was deleted before the ticket was closed",
+			})
+		}
+
+		if revisions := transcript.EditHistory[msg.Id]; len(revisions) > 0 {
+			annotations = append(annotations, editHistoryEmbed(revisions))
+		}
+
+		if len(annotations) > 0 {
+			// Copy the embeds, so that the transcript is not modified
+			embeds := make([]embed.Embed, 0, len(msg.Embeds)+len(annotations))
+			msg.Embeds = append(append(embeds, msg.Embeds...), annotations...)
+		}
+
+		messages[i] = msg
+	}
+
+	return messages
+}
+
+// linksEmbed lists the tickets that the ticket was merged with or split from
+func linksEmbed(links []Link) embed.Embed {
+	lines := make([]string, len(links))
+	for i, link := range links {
+		lines[i] = fmt.Sprintf("%s ticket #%d", link.Relation, link.TicketId)
+	}
+
+	return embed.Embed{
+		Title:       "Linked tickets",
+		Description: strings.Join(lines, "\n"),
+	}
+}
+
+// editHistoryEmbed lists the previous revisions of a message, oldest first. If there are too many, the oldest are
+// left out.
+func editHistoryEmbed(revisions []message.Message) embed.Embed {
+	e := embed.Embed{
+		Title: "Edit history",
+	}
+
+	first := 0
+	if len(revisions) > archiverMaxFields {
+		first = len(revisions) - archiverMaxFields
+	}
+
+	for i := first; i < len(revisions); i++ {
+		content := revisions[i].Content
+		if content == "" {
+			content = "*No content*"
+		} else if utf8.RuneCountInString(content) > archiverMaxFieldLength {
+			content = string([]rune(content)[:archiverMaxFieldLength-3]) + "..."
+		}
+
+		e.Fields = append(e.Fields, &embed.EmbedField{
+			Name:  fmt.Sprintf("Revision %d", i+1),
+			Value: content,
+		})
+	}
+
+	return e
+}
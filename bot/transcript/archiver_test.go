@@ -0,0 +1,89 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiverMessagesUnchanged(t *testing.T) {
+	msgs := []message.Message{{Id: 1, Content: "hello"}}
+
+	archived := ArchiverMessages(Transcript{Messages: msgs})
+	require.Equal(t, msgs, archived)
+}
+
+func TestArchiverMessagesDeleted(t *testing.T) {
+	original := embed.Embed{Title: "original"}
+	msgs := []message.Message{{Id: 1, Content: "hello", Embeds: []embed.Embed{original}}}
+
+	archived := ArchiverMessages(Transcript{
+		Messages: msgs,
+		Deleted:  map[uint64]bool{1: true},
+	})
+
+	require.Len(t, archived[0].Embeds, 2)
+	require.Equal(t, original, archived[0].Embeds[0])
+	require.Equal(t, "Deleted", archived[0].Embeds[1].Title)
+
+	// The transcript itself must not be modified
+	require.Len(t, msgs[0].Embeds, 1)
+}
+
+func TestArchiverMessagesEditHistory(t *testing.T) {
+	archived := ArchiverMessages(Transcript{
+		Messages: []message.Message{{Id: 1, Content: "third"}},
+		EditHistory: map[uint64][]message.Message{
+			1: {{Id: 1, Content: "first"}, {Id: 1, Content: ""}},
+		},
+	})
+
+	require.Len(t, archived[0].Embeds, 1)
+
+	e := archived[0].Embeds[0]
+	require.Equal(t, "Edit history", e.Title)
+	require.Equal(t, []*embed.EmbedField{
+		{Name: "Revision 1", Value: "first"},
+		{Name: "Revision 2", Value: "*No content*"},
+	}, e.Fields)
+}
+
+func TestArchiverMessagesEditHistoryLimits(t *testing.T) {
+	revisions := make([]message.Message, 30)
+	for i := range revisions {
+		revisions[i] = message.Message{Id: 1, Content: strings.Repeat("é", 2000)}
+	}
+
+	archived := ArchiverMessages(Transcript{
+		Messages:    []message.Message{{Id: 1}},
+		EditHistory: map[uint64][]message.Message{1: revisions},
+	})
+
+	fields := archived[0].Embeds[0].Fields
+	require.Len(t, fields, 25)
+	require.Equal(t, "Revision 6", fields[0].Name)
+	require.Equal(t, "Revision 30", fields[24].Name)
+	require.Equal(t, 1024, len([]rune(fields[0].Value)))
+}
+
+func TestArchiverMessagesLinks(t *testing.T) {
+	links := []Link{{Relation: "Merged into", TicketId: 12}, {Relation: "Split into", TicketId: 14}}
+
+	archived := ArchiverMessages(Transcript{
+		Messages: []message.Message{{Id: 1, Content: "first"}, {Id: 2, Content: "second"}},
+		Links:    links,
+	})
+
+	require.Len(t, archived[0].Embeds, 1)
+	require.Equal(t, "Linked tickets", archived[0].Embeds[0].Title)
+	require.Equal(t, "Merged into ticket #12\nSplit into ticket #14", archived[0].Embeds[0].Description)
+	require.Empty(t, archived[1].Embeds)
+
+	// A ticket without messages still references its links
+	archived = ArchiverMessages(Transcript{Links: links})
+	require.Len(t, archived, 1)
+	require.Equal(t, "Linked tickets", archived[0].Embeds[0].Title)
+}
@@ -0,0 +1,142 @@
+package transcript
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot/database"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with -update to regenerate the golden files after an intended change to an exporter's output
+var update = flag.Bool("update", false, "update golden files")
+
+func TestExporters(t *testing.T) {
+	for _, format := range Formats() {
+		t.Run(string(format), func(t *testing.T) {
+			exporter, ok := GetExporter(format)
+			require.True(t, ok)
+
+			data, err := exporter.Export(exampleTranscript())
+			require.NoError(t, err)
+
+			path := filepath.Join("testdata", "transcript."+exporter.Extension()+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(path, data, 0644))
+			}
+
+			expected, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(data))
+		})
+	}
+}
+
+func TestRenderStaffCopy(t *testing.T) {
+	transcript := exampleTranscript()
+
+	file, err := Render(MarkdownExporter{}, transcript)
+	require.NoError(t, err)
+	require.True(t, file.Staff)
+	require.Equal(t, "transcript-7-staff.md", file.Name)
+
+	transcript.Notes = nil
+
+	file, err = Render(MarkdownExporter{}, transcript)
+	require.NoError(t, err)
+	require.False(t, file.Staff)
+	require.Equal(t, "transcript-7.md", file.Name)
+}
+
+func TestResolveMentions(t *testing.T) {
+	users := map[uint64]user.User{
+		1: {Id: 1, Username: "alice", GlobalName: ptr("Alice")},
+		2: {Id: 2, Username: "bob"},
+	}
+
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"hello", "hello"},
+		{"<@1>", "@Alice"},
+		{"<@!2> and <@1>", "@bob and @Alice"},
+		{"unknown <@3>", "unknown <@3>"},
+		{"role <@&1> and channel <#1>", "role <@&1> and channel <#1>"},
+		{"too large <@99999999999999999999>", "too large <@99999999999999999999>"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, resolveMentions(test.content, users), test.content)
+	}
+}
+
+// exampleTranscript covers each part of a transcript: mentions, characters that need escaping, edits, deletions,
+// embeds, attachments, links and staff notes
+func exampleTranscript() Transcript {
+	alice := user.User{Id: 1, Username: "alice", GlobalName: ptr("Alice")}
+	bob := user.User{Id: 2, Username: "bob"}
+
+	sent := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	edited := sent.Add(time.Minute)
+
+	return Transcript{
+		Ticket:    database.Ticket{Id: 7, GuildId: 10},
+		GuildName: "Example <Guild>",
+		Messages: []message.Message{
+			{
+				Id:              100,
+				Author:          alice,
+				Content:         "Hi <@2>\nsecond line & <b>",
+				Timestamp:       sent,
+				EditedTimestamp: &edited,
+			},
+			{
+				Id:        101,
+				Author:    bob,
+				Content:   "Thanks <@!1>, <@3>",
+				Timestamp: sent.Add(time.Hour),
+				Embeds: []embed.Embed{
+					{
+						Title:       "Form",
+						Description: "Asked by <@1>",
+						Color:       0x00ff00,
+						Fields:      []*embed.EmbedField{{Name: "Question", Value: "Answer"}},
+					},
+				},
+				Attachments: []channel.Attachment{
+					{Id: 5, Filename: "log.txt", Url: "https://cdn.discordapp.com/attachments/1/2/log.txt"},
+				},
+			},
+		},
+		EditHistory: map[uint64][]message.Message{
+			100: {{Id: 100, Author: alice, Content: "Hi", Timestamp: sent}},
+		},
+		Deleted: map[uint64]bool{101: true},
+		Users: map[uint64]user.User{
+			1: alice,
+			2: bob,
+		},
+		Links: []Link{{Relation: "Merged into", TicketId: 12}},
+		Notes: []Note{
+			{
+				AuthorId:  2,
+				Content:   "Check with <@1>",
+				CreatedAt: sent.Add(time.Hour * 2),
+				EditedAt:  &edited,
+				History:   []string{"Old note"},
+			},
+		},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
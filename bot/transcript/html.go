@@ -0,0 +1,140 @@
+package transcript
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+)
+
+// HtmlExporter renders a single self-contained page, with no external stylesheets or scripts. Avatars and attachments
+// are still loaded from the Discord CDN.
+type HtmlExporter struct{}
+
+var _ Exporter = HtmlExporter{}
+
+func (HtmlExporter) Format() Format {
+	return FormatHtml
+}
+
+func (HtmlExporter) Extension() string {
+	return "html"
+}
+
+func (HtmlExporter) ContentType() string {
+	return "text/html; charset=utf-8"
+}
+
+func (HtmlExporter) Export(transcript Transcript) ([]byte, error) {
+	funcs := template.FuncMap{
+		"content": func(content string) template.HTML {
+			escaped := template.HTMLEscapeString(resolveMentions(content, transcript.Users))
+			return template.HTML(strings.ReplaceAll(escaped, "\n", "<br>"))
+		},
+		"colour": func(e embed.Embed) string {
+			return fmt.Sprintf("#%06x", e.Color)
+		},
+		"timestamp": func(t time.Time) string {
+			return t.UTC().Format("2006-01-02 15:04:05 MST")
+		},
+		"avatar": func(m message.Message) string {
+			return m.Author.AvatarUrl(64)
+		},
+		"name": func(m message.Message) string {
+			return m.Author.EffectiveName()
+		},
+		"deleted": func(m message.Message) bool {
+			return transcript.Deleted[m.Id]
+		},
+		"history": func(m message.Message) []message.Message {
+			return transcript.EditHistory[m.Id]
+		},
+		"noteAuthor": func(n Note) string {
+			return authorName(n.AuthorId, transcript.Users)
+		},
+	}
+
+	tmpl, err := template.New("transcript").Funcs(funcs).Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, transcript); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.GuildName}} - Ticket #{{.Ticket.Id}}</title>
+<style>
+body { background: #313338; color: #dbdee1; font-family: sans-serif; margin: 0; padding: 16px; }
+header { border-bottom: 1px solid #4e5058; margin-bottom: 16px; padding-bottom: 8px; }
+.message { display: flex; gap: 12px; margin-bottom: 12px; }
+.avatar { border-radius: 50%; height: 40px; width: 40px; }
+.author { color: #f2f3f5; font-weight: bold; }
+.timestamp { color: #949ba4; font-size: 0.75em; margin-left: 6px; }
+.embed { background: #2b2d31; border-left: 4px solid; border-radius: 4px; margin-top: 4px; max-width: 520px; padding: 8px 12px; }
+.embed-title { font-weight: bold; }
+.field-name { font-weight: bold; margin-top: 6px; }
+.deleted { opacity: 0.6; }
+.revision { color: #949ba4; text-decoration: line-through; }
+.attachment img { border-radius: 4px; max-height: 300px; max-width: 400px; }
+.notes { border-top: 1px solid #4e5058; margin-top: 16px; padding-top: 8px; }
+.note { background: #2b2d31; border-left: 4px solid #f0b232; border-radius: 4px; margin-bottom: 8px; padding: 8px 12px; }
+a { color: #00a8fc; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.GuildName}}</h1>
+<p>Ticket #{{.Ticket.Id}} - {{len .Messages}} messages</p>
+{{range .Links}}<p>{{.Relation}} ticket #{{.TicketId}}</p>{{end}}
+</header>
+{{range .Messages}}
+<div class="message{{if deleted .}} deleted{{end}}" id="m{{.Id}}">
+<img class="avatar" src="{{avatar .}}" alt="">
+<div>
+<div><span class="author">{{name .}}</span><span class="timestamp">{{timestamp .Timestamp}}{{if .EditedTimestamp}} (edited){{end}}{{if deleted .}} (deleted){{end}}</span></div>
+{{range history .}}<div class="revision">{{content .Content}}</div>{{end}}
+{{if .Content}}<div class="content">{{content .Content}}</div>{{end}}
+{{range .Embeds}}
+<div class="embed" style="border-color: {{colour .}}">
+{{if .Author}}<div>{{.Author.Name}}</div>{{end}}
+{{if .Title}}<div class="embed-title">{{.Title}}</div>{{end}}
+{{if .Description}}<div>{{content .Description}}</div>{{end}}
+{{range .Fields}}<div class="field-name">{{.Name}}</div><div>{{content .Value}}</div>{{end}}
+{{if .Image}}<div class="attachment"><img src="{{.Image.Url}}" alt=""></div>{{end}}
+{{if .Footer}}<div class="timestamp">{{.Footer.Text}}</div>{{end}}
+</div>
+{{end}}
+{{range .Attachments}}
+<div class="attachment">{{if .Width}}<img src="{{.Url}}" alt="{{.Filename}}">{{else}}<a href="{{.Url}}">{{.Filename}}</a>{{end}}</div>
+{{end}}
+</div>
+</div>
+{{end}}
+{{if .Notes}}
+<section class="notes">
+<h2>Staff Notes</h2>
+{{range .Notes}}
+<div class="note">
+<div><span class="author">{{noteAuthor .}}</span><span class="timestamp">{{timestamp .CreatedAt}}{{if .EditedAt}} (edited){{end}}</span></div>
+{{range .History}}<div class="revision">{{content .}}</div>{{end}}
+<div class="content">{{content .Content}}</div>
+</div>
+{{end}}
+</section>
+{{end}}
+</body>
+</html>
+`
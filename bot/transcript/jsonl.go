@@ -0,0 +1,97 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/rxdn/gdl/objects/channel/message"
+)
+
+// JsonlExporter writes one Discord message object per line, followed by any linked tickets and staff notes, so that
+// transcripts can be re-rendered or imported into other tools later.
+type JsonlExporter struct{}
+
+// jsonlMessage is a raw Discord message object, with the journal history added alongside the Discord fields
+type jsonlMessage struct {
+	message.Message
+	EditHistory []message.Message `json:"edit_history,omitempty"`
+	Deleted     bool              `json:"deleted,omitempty"`
+}
+
+// jsonlLink is written after the messages, wrapped in an object with a single "linked_ticket" key, so that it cannot
+// be mistaken for a message
+type jsonlLink struct {
+	LinkedTicket struct {
+		Relation string `json:"relation"`
+		TicketId int    `json:"ticket_id"`
+	} `json:"linked_ticket"`
+}
+
+// jsonlNote is written after the messages, wrapped in an object with a single "staff_note" key, so that it cannot
+// be mistaken for a message
+type jsonlNote struct {
+	StaffNote struct {
+		AuthorId  uint64     `json:"author_id,string"`
+		Content   string     `json:"content"`
+		CreatedAt time.Time  `json:"created_at"`
+		EditedAt  *time.Time `json:"edited_at,omitempty"`
+		History   []string   `json:"history,omitempty"`
+	} `json:"staff_note"`
+}
+
+var _ Exporter = JsonlExporter{}
+
+func (JsonlExporter) Format() Format {
+	return FormatJsonl
+}
+
+func (JsonlExporter) Extension() string {
+	return "jsonl"
+}
+
+func (JsonlExporter) ContentType() string {
+	return "application/jsonl"
+}
+
+func (JsonlExporter) Export(transcript Transcript) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf) // Encode appends a newline after each value
+	for _, msg := range transcript.Messages {
+		line := jsonlMessage{
+			Message:     msg,
+			EditHistory: transcript.EditHistory[msg.Id],
+			Deleted:     transcript.Deleted[msg.Id],
+		}
+
+		if err := encoder.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, link := range transcript.Links {
+		var line jsonlLink
+		line.LinkedTicket.Relation = link.Relation
+		line.LinkedTicket.TicketId = link.TicketId
+
+		if err := encoder.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, note := range transcript.Notes {
+		var line jsonlNote
+		line.StaffNote.AuthorId = note.AuthorId
+		line.StaffNote.Content = note.Content
+		line.StaffNote.CreatedAt = note.CreatedAt
+		line.StaffNote.EditedAt = note.EditedAt
+		line.StaffNote.History = note.History
+
+		if err := encoder.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
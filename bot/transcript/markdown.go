@@ -0,0 +1,101 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MarkdownExporter struct{}
+
+var _ Exporter = MarkdownExporter{}
+
+func (MarkdownExporter) Format() Format {
+	return FormatMarkdown
+}
+
+func (MarkdownExporter) Extension() string {
+	return "md"
+}
+
+func (MarkdownExporter) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (MarkdownExporter) Export(transcript Transcript) ([]byte, error) {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "# %s - Ticket #%d\n\n", transcript.GuildName, transcript.Ticket.Id)
+
+	for _, link := range transcript.Links {
+		_, _ = fmt.Fprintf(&sb, "_%s ticket #%d_\n\n", link.Relation, link.TicketId)
+	}
+
+	for _, msg := range transcript.Messages {
+		_, _ = fmt.Fprintf(&sb, "**%s** _%s_", msg.Author.EffectiveName(), msg.Timestamp.UTC().Format("2006-01-02 15:04:05 MST"))
+		if msg.EditedTimestamp != nil {
+			sb.WriteString(" _(edited)_")
+		}
+
+		if transcript.Deleted[msg.Id] {
+			sb.WriteString(" _(deleted)_")
+		}
+
+		sb.WriteString("\n\n")
+
+		for _, revision := range transcript.EditHistory[msg.Id] {
+			if revision.Content != "" {
+				sb.WriteString(quote("~~" + resolveMentions(revision.Content, transcript.Users) + "~~"))
+			}
+		}
+
+		if msg.Content != "" {
+			sb.WriteString(quote(resolveMentions(msg.Content, transcript.Users)))
+		}
+
+		for _, e := range msg.Embeds {
+			if e.Title != "" {
+				sb.WriteString(quote("**" + e.Title + "**"))
+			}
+
+			if e.Description != "" {
+				sb.WriteString(quote(resolveMentions(e.Description, transcript.Users)))
+			}
+
+			for _, field := range e.Fields {
+				sb.WriteString(quote(fmt.Sprintf("**%s**\n%s", field.Name, resolveMentions(field.Value, transcript.Users))))
+			}
+		}
+
+		for _, attachment := range msg.Attachments {
+			_, _ = fmt.Fprintf(&sb, "> [%s](%s)\n", attachment.Filename, attachment.Url)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if len(transcript.Notes) > 0 {
+		sb.WriteString("## Staff Notes\n\n")
+	}
+
+	for _, note := range transcript.Notes {
+		_, _ = fmt.Fprintf(&sb, "**%s** _%s_", authorName(note.AuthorId, transcript.Users), note.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+		if note.EditedAt != nil {
+			sb.WriteString(" _(edited)_")
+		}
+
+		sb.WriteString("\n\n")
+
+		for _, revision := range note.History {
+			sb.WriteString(quote("~~" + resolveMentions(revision, transcript.Users) + "~~"))
+		}
+
+		sb.WriteString(quote(resolveMentions(note.Content, transcript.Users)))
+		sb.WriteString("\n")
+	}
+
+	return []byte(sb.String()), nil
+}
+
+func quote(s string) string {
+	return "> " + strings.ReplaceAll(s, "\n", "\n> ") + "\n"
+}
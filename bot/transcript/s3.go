@@ -0,0 +1,199 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Sink uploads transcripts to an S3-compatible object store, such as AWS S3, MinIO or Cloudflare R2. Requests are
+// signed with AWS Signature Version 4.
+type S3Sink struct {
+	Endpoint  *url.URL
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as part of the path rather than as a subdomain, which most self-hosted
+	// implementations require
+	PathStyle bool
+	client    *http.Client
+}
+
+var _ Sink = (*S3Sink)(nil)
+
+func NewS3Sink(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*S3Sink, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+
+	return &S3Sink{
+		Endpoint:  parsed,
+		Region:    region,
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		PathStyle: pathStyle,
+		client: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}, nil
+}
+
+func (s *S3Sink) Store(ctx context.Context, guildId uint64, ticketId int, file File) error {
+	target := *s.Endpoint
+	key := objectKey(guildId, ticketId, file)
+	if s.PathStyle {
+		target.Path = fmt.Sprintf("/%s/%s", s.Bucket, key)
+	} else {
+		target.Host = fmt.Sprintf("%s.%s", s.Bucket, s.Endpoint.Host)
+		target.Path = "/" + key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(file.Data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", file.ContentType)
+	s.sign(req, file.Data, time.Now().UTC())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("S3 upload failed with status %d: %s", res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// uploadSignedHeaders are the headers included in the signature of uploads
+var uploadSignedHeaders = []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+
+func (s *S3Sink) sign(req *http.Request, payload []byte, now time.Time) {
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signV4(req, payloadHash, now, s.Region, s.AccessKey, s.SecretKey, uploadSignedHeaders)
+}
+
+// signV4 sets the Authorization header of the request, signing the given lower-case header names, which must already
+// be set on the request. The host is taken from the URL rather than the headers, as Go does not store it there.
+func signV4(req *http.Request, payloadHash string, now time.Time, region, accessKey, secretKey string, headers []string) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	signedHeaders := make([]string, len(headers))
+	copy(signedHeaders, headers)
+	sort.Strings(signedHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+
+		canonicalHeaders.WriteString(fmt.Sprintf("%s:%s\n", name, strings.TrimSpace(value)))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSha256(signingKey(secretKey, date, region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, strings.Join(signedHeaders, ";"), signature,
+	))
+}
+
+func signingKey(secretKey, date, region, service string) []byte {
+	key := hmacSha256([]byte("AWS4"+secretKey), date)
+	key = hmacSha256(key, region)
+	key = hmacSha256(key, service)
+	return hmacSha256(key, "aws4_request")
+}
+
+// canonicalQuery sorts the query parameters by name and then value, encoding them as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes every byte other than the unreserved characters, and slashes unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			encoded.WriteByte(c)
+		} else {
+			encoded.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
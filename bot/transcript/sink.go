@@ -0,0 +1,45 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Sink stores rendered transcripts in place of the archiver
+type Sink interface {
+	Store(ctx context.Context, guildId uint64, ticketId int, file File) error
+}
+
+// objectKey is the path of a transcript inside a sink, relative to the sink's root. The staff copy is stored beside
+// the transcript, so that it can be served to staff only.
+func objectKey(guildId uint64, ticketId int, file File) string {
+	if file.Staff {
+		return fmt.Sprintf("%d/%d-staff%s", guildId, ticketId, filepath.Ext(file.Name))
+	}
+
+	return fmt.Sprintf("%d/%d%s", guildId, ticketId, filepath.Ext(file.Name))
+}
+
+// LocalSink writes transcripts to a directory on the local filesystem
+type LocalSink struct {
+	Directory string
+}
+
+var _ Sink = (*LocalSink)(nil)
+
+func NewLocalSink(directory string) *LocalSink {
+	return &LocalSink{
+		Directory: directory,
+	}
+}
+
+func (s *LocalSink) Store(_ context.Context, guildId uint64, ticketId int, file File) error {
+	path := filepath.Join(s.Directory, filepath.FromSlash(objectKey(guildId, ticketId, file)))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, file.Data, 0o644)
+}
@@ -0,0 +1,147 @@
+package transcript
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/TicketsBot/database"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/rxdn/gdl/rest/request"
+)
+
+type Format string
+
+const (
+	FormatHtml     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatJsonl    Format = "jsonl"
+)
+
+// Transcript is the input to an exporter. Messages must be in chronological order.
+type Transcript struct {
+	Ticket    database.Ticket
+	GuildName string
+	Messages  []message.Message
+	// EditHistory holds the previous versions of edited messages, oldest first
+	EditHistory map[uint64][]message.Message
+	// Deleted holds the IDs of messages that were deleted before the ticket was closed
+	Deleted map[uint64]bool
+	// Users are used to resolve user mentions in message content
+	Users map[uint64]user.User
+	// Links are the other tickets that this ticket was merged with or split from
+	Links []Link
+	// Notes are private staff notes, which are only included in the copy of the transcript sent to staff
+	Notes []Note
+}
+
+// Link references another ticket in the same guild, e.g. "Merged into" ticket #12
+type Link struct {
+	Relation string
+	TicketId int
+}
+
+// Note is a staff note left on the ticket
+type Note struct {
+	AuthorId  uint64
+	Content   string
+	CreatedAt time.Time
+	EditedAt  *time.Time
+	// History holds the previous contents of the note, oldest first
+	History []string
+}
+
+type Exporter interface {
+	Format() Format
+	Extension() string
+	ContentType() string
+	Export(transcript Transcript) ([]byte, error)
+}
+
+// File is a rendered transcript
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+	// Staff is set if the transcript contains staff notes, and so must only be shared with staff
+	Staff bool
+}
+
+var exporters = map[Format]Exporter{
+	FormatHtml:     HtmlExporter{},
+	FormatMarkdown: MarkdownExporter{},
+	FormatJsonl:    JsonlExporter{},
+}
+
+func GetExporter(format Format) (Exporter, bool) {
+	exporter, ok := exporters[format]
+	return exporter, ok
+}
+
+// Formats returns the built-in formats, in the order they should be presented to users
+func Formats() []Format {
+	return []Format{FormatHtml, FormatMarkdown, FormatJsonl}
+}
+
+func Render(exporter Exporter, transcript Transcript) (File, error) {
+	data, err := exporter.Export(transcript)
+	if err != nil {
+		return File{}, err
+	}
+
+	file := File{
+		Name:        fmt.Sprintf("transcript-%d.%s", transcript.Ticket.Id, exporter.Extension()),
+		ContentType: exporter.ContentType(),
+		Data:        data,
+		Staff:       len(transcript.Notes) > 0,
+	}
+
+	if file.Staff {
+		file.Name = fmt.Sprintf("transcript-%d-staff.%s", transcript.Ticket.Id, exporter.Extension())
+	}
+
+	return file, nil
+}
+
+// Attachment returns a new attachment on each call, as the reader can only be consumed once
+func (f File) Attachment() request.Attachment {
+	return request.Attachment{
+		FileName: f.Name,
+		File: request.File{
+			ContentType: f.ContentType,
+			Reader:      bytes.NewReader(f.Data),
+		},
+	}
+}
+
+// authorName returns the display name of the user, or their ID if they are not known
+func authorName(userId uint64, users map[uint64]user.User) string {
+	if u, ok := users[userId]; ok {
+		return u.EffectiveName()
+	}
+
+	return strconv.FormatUint(userId, 10)
+}
+
+var userMentionRegex = regexp.MustCompile(`<@!?(\d+)>`)
+
+// resolveMentions replaces user mentions with the display name of the user, if known
+func resolveMentions(content string, users map[uint64]user.User) string {
+	return userMentionRegex.ReplaceAllStringFunc(content, func(mention string) string {
+		match := userMentionRegex.FindStringSubmatch(mention)
+
+		id, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return mention
+		}
+
+		if u, ok := users[id]; ok {
+			return "@" + u.EffectiveName()
+		}
+
+		return mention
+	})
+}
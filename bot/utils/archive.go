@@ -1,5 +1,11 @@
 package utils
 
-import "github.com/TicketsBot/archiverclient"
+import (
+	"github.com/TicketsBot/archiverclient"
+	"github.com/TicketsBot/worker/bot/transcript"
+)
 
 var ArchiverClient *archiverclient.ArchiverClient
+
+// TranscriptSink replaces the archiver when set
+var TranscriptSink transcript.Sink
@@ -1,10 +1,24 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
+var ErrInvalidDuration = errors.New("invalid duration")
+
+var durationUnits = map[byte]time.Duration{
+	'w': time.Hour * 24 * 7,
+	'd': time.Hour * 24,
+	'h': time.Hour,
+	'm': time.Minute,
+	's': time.Second,
+}
+
 func FormatTime(interval time.Duration) string {
 	minutes := (interval.Milliseconds() / (1000 * 60)) % 60
 	hours := (interval.Milliseconds() / (1000 * 60 * 60)) % 24
@@ -24,3 +38,66 @@ func FormatNullableTime(duration *time.Duration) string {
 		return FormatTime(*duration)
 	}
 }
+
+// FormatDuration formats a duration in days, hours and minutes, such as "3d" or "1d 2h 30m", in the same format that
+// ParseDuration accepts. Seconds are truncated.
+func FormatDuration(duration time.Duration) string {
+	days := int64(duration / (time.Hour * 24))
+	hours := int64(duration/time.Hour) % 24
+	minutes := int64(duration/time.Minute) % 60
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseDuration parses a user provided duration such as "90m", "2h" or "1d12h". Unlike time.ParseDuration, days and
+// weeks are supported, and fractions are not. Durations that do not fit in a time.Duration are rejected.
+func ParseDuration(raw string) (time.Duration, error) {
+	raw = strings.ToLower(strings.ReplaceAll(raw, " ", ""))
+	if len(raw) == 0 {
+		return 0, ErrInvalidDuration
+	}
+
+	var total time.Duration
+	for len(raw) > 0 {
+		i := 0
+		for i < len(raw) && raw[i] >= '0' && raw[i] <= '9' {
+			i++
+		}
+
+		if i == 0 || i == len(raw) {
+			return 0, ErrInvalidDuration
+		}
+
+		value, err := strconv.Atoi(raw[:i])
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+
+		unit, ok := durationUnits[raw[i]]
+		if !ok {
+			return 0, ErrInvalidDuration
+		}
+
+		if time.Duration(value) > (math.MaxInt64-total)/unit {
+			return 0, ErrInvalidDuration
+		}
+
+		total += time.Duration(value) * unit
+		raw = raw[i+1:]
+	}
+
+	return total, nil
+}
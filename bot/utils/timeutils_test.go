@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"90m", time.Minute * 90},
+		{"2h", time.Hour * 2},
+		{"1d12h", time.Hour * 36},
+		{"1w", time.Hour * 24 * 7},
+		{"30s", time.Second * 30},
+		{"1D 2H", time.Hour * 26},
+		{"1h1h", time.Hour * 2},
+		{"0m", 0},
+		{"9223372036s", time.Second * 9223372036},
+		{"15250w", time.Hour * 24 * 7 * 15250},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			duration, err := ParseDuration(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, duration)
+		})
+	}
+}
+
+func TestParseDurationInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		" ",
+		"h",
+		"10",
+		"10x",
+		"1.5h",
+		"-1h",
+		"1h30",
+		"99999999999999999999s",
+		"15251w",
+		"9223372037s",
+		"100000d100000d",
+		"15250w1w",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDuration(input)
+			require.ErrorIs(t, err, ErrInvalidDuration)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{0, "0m"},
+		{time.Second * 59, "0m"},
+		{time.Minute * 45, "45m"},
+		{time.Minute * 90, "1h 30m"},
+		{time.Hour * 24, "1d"},
+		{time.Hour * 72, "3d"},
+		{time.Hour*26 + time.Minute*30, "1d 2h 30m"},
+		{time.Hour*24 + time.Minute, "1d 1m"},
+		{time.Hour * 24 * 14, "14d"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			formatted := FormatDuration(test.input)
+			require.Equal(t, test.expected, formatted)
+
+			parsed, err := ParseDuration(formatted)
+			require.NoError(t, err)
+			require.Equal(t, test.input.Truncate(time.Minute), parsed)
+		})
+	}
+}
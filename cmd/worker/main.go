@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
@@ -20,12 +22,15 @@ import (
 	"github.com/TicketsBot/worker/bot/blacklist"
 	"github.com/TicketsBot/worker/bot/cache"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/health"
 	"github.com/TicketsBot/worker/bot/integrations"
 	"github.com/TicketsBot/worker/bot/listeners/messagequeue"
 	"github.com/TicketsBot/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
 	"github.com/TicketsBot/worker/bot/redis"
 	"github.com/TicketsBot/worker/bot/rpc/listeners"
+	"github.com/TicketsBot/worker/bot/shutdown"
+	"github.com/TicketsBot/worker/bot/transcript"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/TicketsBot/worker/event"
@@ -34,6 +39,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const kafkaConsumerGroup = "worker"
+
 func main() {
 	go func() {
 		fmt.Println(http.ListenAndServe(":6060", nil))
@@ -85,10 +92,14 @@ func main() {
 	}
 
 	logger.Info("Connected to Redis")
+	health.Register("redis", func(ctx context.Context) error {
+		return redis.Client.Ping(ctx).Err()
+	})
 
 	logger.Info("Connecting to DB")
 	dbclient.Connect(logger.With(zap.String("service", "database")))
 	logger.Info("Connected to DB")
+	health.Register("postgres", dbclient.Ping)
 
 	logger.Info("Loading i18n files")
 	i18n.Init()
@@ -103,10 +114,12 @@ func main() {
 
 	cache.Client = &pgCache
 	logger.Info("Connected to cache")
+	health.Register("cache", pgCache.Ping)
 
 	logger.Info("Connecting to clickhouse")
 	dbclient.ConnectAnalytics(logger.With(zap.String("service", "clickhouse")))
 	logger.Info("Connected to clickhouse")
+	health.Register("clickhouse", dbclient.Analytics.Ping)
 
 	// Configure HTTP proxy
 	if config.Conf.Discord.ProxyUrl != "" {
@@ -130,6 +143,22 @@ func main() {
 		[]byte(config.Conf.Archiver.AesKey),
 	)
 
+	switch config.Conf.Transcripts.Sink {
+	case config.TranscriptSinkArchiver:
+	case config.TranscriptSinkLocal:
+		utils.TranscriptSink = transcript.NewLocalSink(config.Conf.Transcripts.LocalDirectory)
+	case config.TranscriptSinkS3:
+		s3Conf := config.Conf.Transcripts.S3
+		utils.TranscriptSink, err = transcript.NewS3Sink(s3Conf.Endpoint, s3Conf.Region, s3Conf.Bucket, s3Conf.AccessKey, s3Conf.SecretKey, s3Conf.PathStyle)
+		if err != nil {
+			logger.Fatal("Failed to configure S3 transcript sink", zap.Error(err))
+			return
+		}
+	default:
+		logger.Fatal("Unknown transcript sink", zap.String("sink", string(config.Conf.Transcripts.Sink)))
+		return
+	}
+
 	logger.Info("Starting Prometheus server")
 	prometheus.StartServer(config.Conf.Prometheus.Address)
 	logger.Info("Started Prometheus server")
@@ -151,45 +180,80 @@ func main() {
 	logger.Info("Initialising integrations")
 	integrations.InitIntegrations()
 
-	go messagequeue.ListenTicketClose()
-	go messagequeue.ListenAutoClose()
-	go messagequeue.ListenCloseRequestTimer()
+	// The listeners return once the worker begins draining
+	shutdown.Go(messagequeue.ListenTicketClose)
+	shutdown.Go(messagequeue.ListenAutoClose)
+	shutdown.Go(messagequeue.ListenCloseRequestTimer)
+	shutdown.Go(messagequeue.ListenPriorityEscalation)
+	shutdown.Go(messagequeue.ListenSlaEvaluator)
+	shutdown.Go(messagequeue.ListenScheduledClose)
+	shutdown.Go(messagequeue.ListenOnCallShifts)
+	shutdown.Go(messagequeue.ListenWatcherDigest)
+	shutdown.Go(messagequeue.ListenTemporaryAccess)
+	shutdown.Go(messagequeue.ListenJournalCleanup)
 
 	go blacklist.StartCacheRefreshLoop(logger.With(zap.String("service", "blacklist_refresh")))
 
 	if config.Conf.WorkerMode == config.WorkerModeInteractions {
 		logger.Info("Starting HTTP server", zap.String("mode", string(config.Conf.WorkerMode)))
 
-		event.HttpListen(redis.Client, &pgCache)
+		server := event.NewHttpServer(redis.Client, &pgCache)
+		go listenHttp(logger, server)
+
+		shutdownCh := make(chan os.Signal, 1)
+		signal.Notify(shutdownCh, syscall.SIGINT, syscall.SIGTERM)
+		<-shutdownCh
+
+		logger.Info("Received shutdown signal")
+		drain(logger, server)
 	} else if config.Conf.WorkerMode == config.WorkerModeGateway {
 		logger.Info("Starting event listeners", zap.String("mode", string(config.Conf.WorkerMode)))
 
-		go event.HttpListen(redis.Client, &pgCache)
+		server := event.NewHttpServer(redis.Client, &pgCache)
+		go listenHttp(logger, server)
 
 		var wg sync.WaitGroup
 
+		rpcListeners, err := listeners.Build(&pgCache, logger.With(zap.String("service", "rpc")))
+		if err != nil {
+			logger.Fatal("Failed to build RPC listeners", zap.Error(err))
+			return
+		}
+
+		if _, ok := rpcListeners[config.Conf.Kafka.EventsTopic]; ok {
+			logger.Fatal("An RPC listener consumes from the gateway events topic", zap.String("topic", config.Conf.Kafka.EventsTopic))
+			return
+		}
+
+		// Listen for gateway events over Kafka
+		rpcListeners[config.Conf.Kafka.EventsTopic] = event.NewKafkaListener(
+			logger.With(zap.String("service", "gateway-events-kafka")),
+			&pgCache,
+		)
+
 		rpcClient, err := rpc.NewClient(
 			logger.With(zap.String("service", "rpc")),
 			rpc.Config{
 				Brokers:             config.Conf.Kafka.Brokers,
-				ConsumerGroup:       "worker",
+				ConsumerGroup:       kafkaConsumerGroup,
 				ConsumerConcurrency: config.Conf.Kafka.GoroutineLimit,
 			},
-			map[string]rpc.Listener{
-				// Listen for gateway events over Kafka
-				config.Conf.Kafka.EventsTopic: event.NewKafkaListener(
-					logger.With(zap.String("service", "gateway-events-kafka")),
-					&pgCache,
-				),
-				// TODO: Don't hardcode
-				"tickets.rpc.categoryupdate": listeners.NewTicketStatusUpdater(&pgCache, logger),
-			})
+			rpcListeners,
+		)
 
 		if err != nil {
 			logger.Fatal("Failed to create RPC client", zap.Error(err))
 			return
 		}
 
+		lagCheck, kafkaAdmin, err := health.NewKafkaLagCheck(config.Conf.Kafka.Brokers, kafkaConsumerGroup, config.Conf.Kafka.MaxConsumerLag)
+		if err != nil {
+			logger.Error("Failed to create Kafka admin client", zap.Error(err))
+		} else {
+			defer kafkaAdmin.Close()
+			health.Register("kafka", lagCheck)
+		}
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -208,11 +272,42 @@ func main() {
 		} else {
 			logger.Warn("Graceful shutdown timed out, exiting now")
 		}
+
+		drain(logger, server)
 	} else {
 		logger.Fatal("Invalid worker mode", zap.String("mode", string(config.Conf.WorkerMode)))
 	}
 }
 
+func listenHttp(logger *zap.Logger, server *http.Server) {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal("HTTP server failed", zap.Error(err))
+	}
+}
+
+// drain stops the worker from taking new work, and waits for in-flight interactions and queued jobs, such as ticket
+// closes, to complete
+func drain(logger *zap.Logger, server *http.Server) {
+	shutdown.BeginDrain()
+
+	// Give the load balancer time to observe the failing readiness check before refusing connections
+	logger.Info("Draining", zap.Duration("readiness_delay", config.Conf.Shutdown.ReadinessDelay))
+	time.Sleep(config.Conf.Shutdown.ReadinessDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Conf.Shutdown.DrainTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to shut down HTTP server gracefully", zap.Error(err))
+	}
+
+	if shutdown.Wait(ctx) {
+		logger.Info("Drained gracefully")
+	} else {
+		logger.Warn("Drain timed out, exiting now", zap.Int64("outstanding", shutdown.Outstanding()))
+	}
+}
+
 func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
 	ch := make(chan struct{})
 	go func() {
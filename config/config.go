@@ -9,7 +9,8 @@ import (
 )
 
 type (
-	WorkerMode string
+	WorkerMode     string
+	TranscriptSink string
 
 	Config struct {
 		DebugMode   string        `env:"WORKER_DEBUG"`
@@ -22,6 +23,7 @@ type (
 		Discord struct {
 			Token            string        `env:"WORKER_PUBLIC_TOKEN"`
 			PublicBotId      uint64        `env:"WORKER_PUBLIC_ID"`
+			PublicKey        string        `env:"WORKER_PUBLIC_KEY"`
 			ProxyUrl         string        `env:"DISCORD_PROXY_URL"`
 			RequestTimeout   time.Duration `env:"DISCORD_REQUEST_TIMEOUT" envDefault:"15s"`
 			CallbackTimeout  time.Duration `env:"DISCORD_CALLBACK_TIMEOUT" envDefault:"2000ms"`
@@ -40,6 +42,13 @@ type (
 			Helpers             []uint64 `env:"WORKER_BOT_HELPERS"`
 		}
 
+		// Shutdown configures how long the worker waits on SIGTERM. Readiness fails for ReadinessDelay before the HTTP
+		// server stops accepting requests, then in-flight work has until DrainTimeout to complete.
+		Shutdown struct {
+			ReadinessDelay time.Duration `env:"READINESS_DELAY" envDefault:"5s"`
+			DrainTimeout   time.Duration `env:"DRAIN_TIMEOUT" envDefault:"20s"`
+		} `envPrefix:"WORKER_SHUTDOWN_"`
+
 		PremiumProxy struct {
 			Url string `env:"URL"`
 			Key string `env:"KEY"`
@@ -50,6 +59,20 @@ type (
 			AesKey string `env:"AES_KEY"`
 		} `envPrefix:"WORKER_ARCHIVER_"`
 
+		// Transcripts configures where transcripts are stored. The archiver is used unless another sink is selected.
+		Transcripts struct {
+			Sink           TranscriptSink `env:"SINK" envDefault:"archiver"`
+			LocalDirectory string         `env:"LOCAL_DIRECTORY" envDefault:"transcripts"`
+			S3             struct {
+				Endpoint  string `env:"ENDPOINT"`
+				Region    string `env:"REGION" envDefault:"us-east-1"`
+				Bucket    string `env:"BUCKET"`
+				AccessKey string `env:"ACCESS_KEY"`
+				SecretKey string `env:"SECRET_KEY"`
+				PathStyle bool   `env:"PATH_STYLE" envDefault:"false"`
+			} `envPrefix:"S3_"`
+		} `envPrefix:"WORKER_TRANSCRIPTS_"`
+
 		WebProxy struct {
 			Url             string `env:"URL"`
 			AuthHeaderName  string `env:"AUTH_HEADER_NAME"`
@@ -95,8 +118,18 @@ type (
 			Brokers        []string `env:"BROKERS"`
 			EventsTopic    string   `env:"EVENTS_TOPIC"`
 			GoroutineLimit int      `env:"GOROUTINE_LIMIT" envDefault:"1000"`
+			MaxConsumerLag int64    `env:"MAX_CONSUMER_LAG" envDefault:"10000"`
 		} `envPrefix:"KAFKA_"`
 
+		Rpc struct {
+			CategoryUpdate RpcListener `envPrefix:"CATEGORY_UPDATE_"`
+			Adopt          RpcListener `envPrefix:"ADOPT_"`
+			Close          RpcListener `envPrefix:"CLOSE_"`
+			SendMessage    RpcListener `envPrefix:"SEND_MESSAGE_"`
+			Claim          RpcListener `envPrefix:"CLAIM_"`
+			AddMember      RpcListener `envPrefix:"ADD_MEMBER_"`
+		} `envPrefix:"WORKER_RPC_"`
+
 		Prometheus struct {
 			Address string `env:"PROMETHEUS_SERVER_ADDR"`
 		}
@@ -120,6 +153,13 @@ type (
 
 		VoteSkuId uuid.UUID `env:"VOTE_SKU_ID"`
 	}
+
+	// RpcListener configures a listener for requests from the dashboard. If Topic is empty, the listener's default
+	// topic is used.
+	RpcListener struct {
+		Enabled bool   `env:"ENABLED" envDefault:"true"`
+		Topic   string `env:"TOPIC"`
+	}
 )
 
 var Conf Config
@@ -129,6 +169,12 @@ const (
 	WorkerModeInteractions WorkerMode = "INTERACTIONS"
 )
 
+const (
+	TranscriptSinkArchiver TranscriptSink = "archiver"
+	TranscriptSinkLocal    TranscriptSink = "local"
+	TranscriptSinkS3       TranscriptSink = "s3"
+)
+
 func Parse() {
 	if err := env.Parse(&Conf); err != nil {
 		panic(err)
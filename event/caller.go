@@ -16,6 +16,8 @@ import (
     "github.com/TicketsBot/worker/bot/command/impl/tags"
     "github.com/TicketsBot/worker/bot/command/registry"
     "github.com/pkg/errors"
+    "github.com/rxdn/gdl/objects"
+    "github.com/rxdn/gdl/objects/channel"
     "github.com/rxdn/gdl/objects/interaction"
     "strconv"
 )
@@ -296,16 +298,7 @@ func callCommand(
         }
 
         v.Execute(ctx, arg0)
-    case settings.AutoCloseCommand:
-
-        v.Execute(ctx)
-    case settings.AutoCloseConfigureCommand:
-
-        v.Execute(ctx)
-    case settings.AutoCloseExcludeCommand:
-
-        v.Execute(ctx)
-    case settings.BlacklistCommand:
+    case settings.AdoptCategoryAddCommand:
         var arg0 uint64
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -325,36 +318,13 @@ func callCommand(
         }
 
         v.Execute(ctx, arg0)
-    case settings.LanguageCommand:
-
-        v.Execute(ctx)
-    case settings.PanelCommand:
+    case settings.AdoptCategoryCommand:
 
         v.Execute(ctx)
-    case settings.PremiumCommand:
+    case settings.AdoptCategoryListCommand:
 
         v.Execute(ctx)
-    case settings.RemoveAdminCommand:
-        var arg0 uint64
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            return ErrArgumentNotFound
-        } else {
-            raw, ok := opt0.Value.(string)
-            if !ok {
-                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
-            }
-
-            argValue, err := strconv.ParseUint(raw, 10, 64)
-            if err != nil {
-                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
-            }
-            arg0 = argValue
-        }
-
-        v.Execute(ctx, arg0)
-    case settings.RemoveSupportCommand:
+    case settings.AdoptCategoryRemoveCommand:
         var arg0 uint64
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -374,13 +344,7 @@ func callCommand(
         }
 
         v.Execute(ctx, arg0)
-    case settings.ViewStaffCommand:
-
-        v.Execute(ctx)
-    case setup.AutoSetupCommand:
-
-        v.Execute(ctx)
-    case setup.LimitSetupCommand:
+    case settings.AutoAssignCommand:
         var arg0 int
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -393,72 +357,44 @@ func callCommand(
             }
             arg0 = int(argValue)
         }
+        var arg1 string
 
-        v.Execute(ctx, arg0)
-    case setup.SetupCommand:
-
-        v.Execute(ctx)
-    case setup.ThreadsSetupCommand:
-        var arg0 bool
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
             return ErrArgumentNotFound
         } else { 
-            argValue, ok := opt0.Value.(bool)
+            argValue, ok := opt1.Value.(string)
             if !ok {
-                return fmt.Errorf("option %s was not a bool", opt0.Name)
+                return fmt.Errorf("option %s was not a string", opt1.Name)
             }
-            arg0 = argValue
-
-            
+            arg1 = argValue
         }
-        var arg1 *uint64
+        var arg2 *bool
 
-        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
-        if !ok1 {
-            arg1 = nil
-        } else {
-            raw, ok := opt1.Value.(string)
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else { 
+            argValue, ok := opt2.Value.(bool)
             if !ok {
-                return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+                return fmt.Errorf("option %s was not a bool", opt2.Name)
             }
+            arg2 = &argValue
 
-            argValue, err := strconv.ParseUint(raw, 10, 64)
-            if err != nil {
-                return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
-            }
-            arg1 = &argValue
+            
         }
 
-        v.Execute(ctx, arg0, arg1)
-    case setup.TranscriptsSetupCommand:
-        var arg0 uint64
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            return ErrArgumentNotFound
-        } else {
-            raw, ok := opt0.Value.(string)
-            if !ok {
-                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
-            }
-
-            argValue, err := strconv.ParseUint(raw, 10, 64)
-            if err != nil {
-                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
-            }
-            arg0 = argValue
-        }
+        v.Execute(ctx, arg0, arg1, arg2)
+    case settings.AutoCloseCommand:
 
-        v.Execute(ctx, arg0)
-    case statistics.StatsCommand:
+        v.Execute(ctx)
+    case settings.AutoCloseConfigureCommand:
 
         v.Execute(ctx)
-    case statistics.StatsServerCommand:
+    case settings.AutoCloseExcludeCommand:
 
         v.Execute(ctx)
-    case statistics.StatsUserCommand:
+    case settings.BlacklistCommand:
         var arg0 uint64
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -478,7 +414,7 @@ func callCommand(
         }
 
         v.Execute(ctx, arg0)
-    case tags.ManageTagsAddCommand:
+    case settings.BusinessHoursCommand:
         var arg0 string
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -503,95 +439,64 @@ func callCommand(
             }
             arg1 = argValue
         }
+        var arg2 *string
 
-        v.Execute(ctx, arg0, arg1)
-    case tags.ManageTagsCommand:
-
-        v.Execute(ctx)
-    case tags.ManageTagsDeleteCommand:
-        var arg0 string
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            return ErrArgumentNotFound
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
         } else { 
-            argValue, ok := opt0.Value.(string)
+            argValue, ok := opt2.Value.(string)
             if !ok {
-                return fmt.Errorf("option %s was not a string", opt0.Name)
+                return fmt.Errorf("option %s was not a string", opt2.Name)
             }
-            arg0 = argValue
+            arg2 = &argValue
         }
+        var arg3 *string
 
-        v.Execute(ctx, arg0)
-    case tags.ManageTagsListCommand:
-
-        v.Execute(ctx)
-    case tags.TagCommand:
-        var arg0 string
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            return ErrArgumentNotFound
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
         } else { 
-            argValue, ok := opt0.Value.(string)
+            argValue, ok := opt3.Value.(string)
             if !ok {
-                return fmt.Errorf("option %s was not a string", opt0.Name)
+                return fmt.Errorf("option %s was not a string", opt3.Name)
             }
-            arg0 = argValue
+            arg3 = &argValue
         }
 
-        v.Execute(ctx, arg0)
-    case tickets.AddCommand:
-        var arg0 uint64
+        v.Execute(ctx, arg0, arg1, arg2, arg3)
+    case settings.HistoryCommand:
+        var arg0 bool
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
         if !ok0 {
             return ErrArgumentNotFound
-        } else {
-            raw, ok := opt0.Value.(string)
+        } else { 
+            argValue, ok := opt0.Value.(bool)
             if !ok {
-                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
-            }
-
-            argValue, err := strconv.ParseUint(raw, 10, 64)
-            if err != nil {
-                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+                return fmt.Errorf("option %s was not a bool", opt0.Name)
             }
             arg0 = argValue
+
+            
         }
 
         v.Execute(ctx, arg0)
-    case tickets.ClaimCommand:
+    case settings.LabelsCommand:
 
         v.Execute(ctx)
-    case tickets.CloseCommand:
-        var arg0 *string
+    case settings.LabelsCreateCommand:
+        var arg0 string
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
         if !ok0 {
-            arg0 = nil
+            return ErrArgumentNotFound
         } else { 
             argValue, ok := opt0.Value.(string)
             if !ok {
                 return fmt.Errorf("option %s was not a string", opt0.Name)
             }
-            arg0 = &argValue
-        }
-
-        v.Execute(ctx, arg0)
-    case tickets.CloseRequestCommand:
-        var arg0 *int
-
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            arg0 = nil
-        } else { 
-            argValue, ok := opt0.Value.(float64)
-            if !ok {
-                return fmt.Errorf("option %s was not a float64", opt0.Name)
-            }
-            tmp := int(argValue)
-            arg0 = &tmp
+            arg0 = argValue
         }
         var arg1 *string
 
@@ -605,50 +510,59 @@ func callCommand(
             }
             arg1 = &argValue
         }
+        var arg2 *string
 
-        v.Execute(ctx, arg0, arg1)
-    case tickets.NotesCommand:
-
-        v.Execute(ctx)
-    case tickets.OnCallCommand:
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else { 
+            argValue, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt2.Name)
+            }
+            arg2 = &argValue
+        }
 
-        v.Execute(ctx)
-    case tickets.OpenCommand:
-        var arg0 *string
+        v.Execute(ctx, arg0, arg1, arg2)
+    case settings.LabelsDeleteCommand:
+        var arg0 int
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
         if !ok0 {
-            arg0 = nil
+            return ErrArgumentNotFound
         } else { 
-            argValue, ok := opt0.Value.(string)
+            argValue, ok := opt0.Value.(float64)
             if !ok {
-                return fmt.Errorf("option %s was not a string", opt0.Name)
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
             }
-            arg0 = &argValue
+            arg0 = int(argValue)
         }
 
         v.Execute(ctx, arg0)
-    case tickets.RemoveCommand:
-        var arg0 uint64
+    case settings.LabelsListCommand:
+
+        v.Execute(ctx)
+    case settings.LanguageCommand:
+
+        v.Execute(ctx)
+    case settings.ModmailCommand:
+        var arg0 bool
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
         if !ok0 {
             return ErrArgumentNotFound
-        } else {
-            raw, ok := opt0.Value.(string)
+        } else { 
+            argValue, ok := opt0.Value.(bool)
             if !ok {
-                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
-            }
-
-            argValue, err := strconv.ParseUint(raw, 10, 64)
-            if err != nil {
-                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+                return fmt.Errorf("option %s was not a bool", opt0.Name)
             }
             arg0 = argValue
+
+            
         }
 
         v.Execute(ctx, arg0)
-    case tickets.RenameCommand:
+    case settings.OpenRateLimitCommand:
         var arg0 string
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -661,27 +575,1295 @@ func callCommand(
             }
             arg0 = argValue
         }
+        var arg1 *int
 
-        v.Execute(ctx, arg0)
-    case tickets.ReopenCommand:
-        var arg0 int
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *int
 
-        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-        if !ok0 {
-            return ErrArgumentNotFound
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
         } else { 
-            argValue, ok := opt0.Value.(float64)
+            argValue, ok := opt2.Value.(float64)
             if !ok {
-                return fmt.Errorf("option %s was not a float64", opt0.Name)
+                return fmt.Errorf("option %s was not a float64", opt2.Name)
             }
-            arg0 = int(argValue)
+            tmp := int(argValue)
+            arg2 = &tmp
         }
 
-        v.Execute(ctx, arg0)
-    case tickets.StartTicketCommand:
+        v.Execute(ctx, arg0, arg1, arg2)
+    case settings.PanelCommand:
+
+        v.Execute(ctx)
+    case settings.PremiumCommand:
+
+        v.Execute(ctx)
+    case settings.PriorityCommand:
+        var arg0 *uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = &argValue
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *bool
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else { 
+            argValue, ok := opt2.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt2.Name)
+            }
+            arg2 = &argValue
+
+            
+        }
+        var arg3 *bool
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt3.Name)
+            }
+            arg3 = &argValue
+
+            
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3)
+    case settings.RemoveAdminCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case settings.RemoveSupportCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case settings.SettingsCommand:
+
+        v.Execute(ctx)
+    case settings.SlaCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *int
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else { 
+            argValue, ok := opt2.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt2.Name)
+            }
+            tmp := int(argValue)
+            arg2 = &tmp
+        }
+        var arg3 *int
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt3.Name)
+            }
+            tmp := int(argValue)
+            arg3 = &tmp
+        }
+        var arg4 *int
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt4.Name)
+            }
+            tmp := int(argValue)
+            arg4 = &tmp
+        }
+        var arg5 *uint64
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else {
+            raw, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt5.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *bool
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt6.Name)
+            }
+            arg6 = &argValue
+
+            
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+    case settings.TranscriptCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *bool
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt1.Name)
+            }
+            arg1 = &argValue
+
+            
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case settings.ViewStaffCommand:
+
+        v.Execute(ctx)
+    case setup.AutoSetupCommand:
+
+        v.Execute(ctx)
+    case setup.LimitSetupCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case setup.SetupCommand:
+
+        v.Execute(ctx)
+    case setup.ThreadsSetupCommand:
+        var arg0 bool
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt0.Name)
+            }
+            arg0 = argValue
+
+            
+        }
+        var arg1 *uint64
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else {
+            raw, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case setup.TranscriptsSetupCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case statistics.StatsCommand:
+
+        v.Execute(ctx)
+    case statistics.StatsServerCommand:
+        var arg0 *int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            tmp := int(argValue)
+            arg0 = &tmp
+        }
+
+        v.Execute(ctx, arg0)
+    case statistics.StatsUserCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tags.ManageTagsAddCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tags.ManageTagsCommand:
+
+        v.Execute(ctx)
+    case tags.ManageTagsDeleteCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tags.ManageTagsListCommand:
+
+        v.Execute(ctx)
+    case tags.TagCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.AReplyCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *channel.Attachment
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            raw, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+            }
+
+            attachmentId, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+            }
+
+            // The option only contains the ID, the attachment itself is sent in the resolved data
+            argValue, ok := ctx.Interaction.Data.Resolved.Attachments[objects.Snowflake(attachmentId)]
+            if !ok {
+                return fmt.Errorf("option %s was not a resolved attachment", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.AddCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.AdoptCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2)
+    case tickets.AwayClearCommand:
+
+        v.Execute(ctx)
+    case tickets.AwayCommand:
+
+        v.Execute(ctx)
+    case tickets.AwaySetCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.ClaimCommand:
+
+        v.Execute(ctx)
+    case tickets.CloseCommand:
+        var arg0 *string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = &argValue
+        }
+        var arg1 *string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.CloseRequestCommand:
+        var arg0 *int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            tmp := int(argValue)
+            arg0 = &tmp
+        }
+        var arg1 *string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.HistoryCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.LabelAddCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.LabelCommand:
+
+        v.Execute(ctx)
+    case tickets.LabelRemoveCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.MergeCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.NoteAddCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.NoteCommand:
+
+        v.Execute(ctx)
+    case tickets.NoteDeleteCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.NoteEditCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+        var arg1 string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.NoteListCommand:
+
+        v.Execute(ctx)
+    case tickets.NotesCommand:
+
+        v.Execute(ctx)
+    case tickets.OnCallCommand:
+
+        v.Execute(ctx)
+    case tickets.OnCallScheduleAddCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = argValue
+        }
+        var arg2 string
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt2.Name)
+            }
+            arg2 = argValue
+        }
+        var arg3 string
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt3.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt3.Name)
+            }
+            arg3 = argValue
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *int
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else { 
+            argValue, ok := opt5.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt5.Name)
+            }
+            tmp := int(argValue)
+            arg5 = &tmp
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+    case tickets.OnCallScheduleCommand:
+
+        v.Execute(ctx)
+    case tickets.OnCallScheduleListCommand:
+
+        v.Execute(ctx)
+    case tickets.OnCallScheduleRemoveCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.OnCallToggleCommand:
+
+        v.Execute(ctx)
+    case tickets.OnCallWhoCommand:
+
+        v.Execute(ctx)
+    case tickets.OpenCommand:
+        var arg0 *string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = &argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.PriorityCommand:
+
+        v.Execute(ctx)
+    case tickets.PriorityPanelCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+        var arg1 string
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt1.Name)
+            }
+            arg1 = argValue
+        }
+        var arg2 *bool
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else { 
+            argValue, ok := opt2.Value.(bool)
+            if !ok {
+                return fmt.Errorf("option %s was not a bool", opt2.Name)
+            }
+            arg2 = &argValue
+
+            
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2)
+    case tickets.PrioritySetCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.RemoveCommand:
+        var arg0 uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.RenameCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.ReopenCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.ReplyCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *channel.Attachment
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            raw, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+            }
+
+            attachmentId, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+            }
+
+            // The option only contains the ID, the attachment itself is sent in the resolved data
+            argValue, ok := ctx.Interaction.Data.Resolved.Attachments[objects.Snowflake(attachmentId)]
+            if !ok {
+                return fmt.Errorf("option %s was not a resolved attachment", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1)
+    case tickets.SplitTicketCommand:
+
+        v.Execute(ctx)
+    case tickets.StartTicketCommand:
+
+        v.Execute(ctx)
+    case tickets.SwitchPanelCommand:
+        var arg0 int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            arg0 = int(argValue)
+        }
+
+        v.Execute(ctx, arg0)
+    case tickets.TicketsBulkClaimCommand:
+        var arg0 *uint64
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else {
+            raw, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+            }
+            arg0 = &argValue
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+        var arg3 *string
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt3.Name)
+            }
+            arg3 = &argValue
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *uint64
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else {
+            raw, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt5.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *string
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt6.Name)
+            }
+            arg6 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+    case tickets.TicketsBulkCloseCommand:
+        var arg0 *string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = &argValue
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+        var arg3 *string
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt3.Name)
+            }
+            arg3 = &argValue
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *uint64
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else {
+            raw, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt5.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *string
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt6.Name)
+            }
+            arg6 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+    case tickets.TicketsBulkCommand:
 
         v.Execute(ctx)
-    case tickets.SwitchPanelCommand:
+    case tickets.TicketsBulkMoveCommand:
         var arg0 int
 
         opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -694,8 +1876,316 @@ func callCommand(
             }
             arg0 = int(argValue)
         }
+        var arg1 *int
 
-        v.Execute(ctx, arg0)
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+        var arg3 *string
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt3.Name)
+            }
+            arg3 = &argValue
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *uint64
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else {
+            raw, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt5.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *string
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt6.Name)
+            }
+            arg6 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+    case tickets.TicketsBulkTagCommand:
+        var arg0 string
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            return ErrArgumentNotFound
+        } else { 
+            argValue, ok := opt0.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt0.Name)
+            }
+            arg0 = argValue
+        }
+        var arg1 *int
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else { 
+            argValue, ok := opt1.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt1.Name)
+            }
+            tmp := int(argValue)
+            arg1 = &tmp
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+        var arg3 *string
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt3.Name)
+            }
+            arg3 = &argValue
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *uint64
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else {
+            raw, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt5.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *string
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt6.Name)
+            }
+            arg6 = &argValue
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+    case tickets.TicketsCommand:
+
+        v.Execute(ctx)
+    case tickets.TicketsSearchCommand:
+        var arg0 *int
+
+        opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+        if !ok0 {
+            arg0 = nil
+        } else { 
+            argValue, ok := opt0.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt0.Name)
+            }
+            tmp := int(argValue)
+            arg0 = &tmp
+        }
+        var arg1 *uint64
+
+        opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+        if !ok1 {
+            arg1 = nil
+        } else {
+            raw, ok := opt1.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+            }
+            arg1 = &argValue
+        }
+        var arg2 *uint64
+
+        opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+        if !ok2 {
+            arg2 = nil
+        } else {
+            raw, ok := opt2.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a snowflake", opt2.Name)
+            }
+
+            argValue, err := strconv.ParseUint(raw, 10, 64)
+            if err != nil {
+                return fmt.Errorf("option %s was not a valid snowflake", opt2.Name)
+            }
+            arg2 = &argValue
+        }
+        var arg3 *int
+
+        opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+        if !ok3 {
+            arg3 = nil
+        } else { 
+            argValue, ok := opt3.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt3.Name)
+            }
+            tmp := int(argValue)
+            arg3 = &tmp
+        }
+        var arg4 *string
+
+        opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+        if !ok4 {
+            arg4 = nil
+        } else { 
+            argValue, ok := opt4.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt4.Name)
+            }
+            arg4 = &argValue
+        }
+        var arg5 *string
+
+        opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+        if !ok5 {
+            arg5 = nil
+        } else { 
+            argValue, ok := opt5.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt5.Name)
+            }
+            arg5 = &argValue
+        }
+        var arg6 *string
+
+        opt6, ok6 := findOption(cmd.Properties().Arguments[6], options)
+        if !ok6 {
+            arg6 = nil
+        } else { 
+            argValue, ok := opt6.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt6.Name)
+            }
+            arg6 = &argValue
+        }
+        var arg7 *string
+
+        opt7, ok7 := findOption(cmd.Properties().Arguments[7], options)
+        if !ok7 {
+            arg7 = nil
+        } else { 
+            argValue, ok := opt7.Value.(string)
+            if !ok {
+                return fmt.Errorf("option %s was not a string", opt7.Name)
+            }
+            arg7 = &argValue
+        }
+        var arg8 *int
+
+        opt8, ok8 := findOption(cmd.Properties().Arguments[8], options)
+        if !ok8 {
+            arg8 = nil
+        } else { 
+            argValue, ok := opt8.Value.(float64)
+            if !ok {
+                return fmt.Errorf("option %s was not a float64", opt8.Name)
+            }
+            tmp := int(argValue)
+            arg8 = &tmp
+        }
+
+        v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
     case tickets.TransferCommand:
         var arg0 uint64
 
@@ -719,6 +2209,15 @@ func callCommand(
     case tickets.UnclaimCommand:
 
         v.Execute(ctx)
+    case tickets.UnwatchCommand:
+
+        v.Execute(ctx)
+    case tickets.ViewHistoryCommand:
+
+        v.Execute(ctx)
+    case tickets.WatchCommand:
+
+        v.Execute(ctx)
 
     
     case tags.TagAliasCommand:
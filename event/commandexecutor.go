@@ -17,6 +17,7 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/TicketsBot/worker/i18n"
@@ -83,7 +84,7 @@ func executeCommand(
 
 	properties := cmd.Properties()
 
-	go func() {
+	shutdown.Go(func() {
 		defer func() {
 			if r := recover(); r != nil {
 				fmt.Printf("Recovering panicking goroutine while executing command %s: %v\n", properties.Name, r)
@@ -205,7 +206,7 @@ func executeCommand(
 				return
 			}
 		}
-	}()
+	})
 
 	return properties.DefaultEphemeral, nil
 }
@@ -0,0 +1,177 @@
+package event
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	btn_manager "github.com/TicketsBot/worker/bot/button/manager"
+	cmd_manager "github.com/TicketsBot/worker/bot/command/manager"
+	"github.com/TicketsBot/worker/config"
+	"github.com/gin-gonic/gin"
+	"github.com/rxdn/gdl/cache"
+	"github.com/rxdn/gdl/objects/interaction"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerSignature = "X-Signature-Ed25519"
+	headerTimestamp = "X-Signature-Timestamp"
+)
+
+const (
+	// Interaction payloads are far smaller than this, even with resolved data
+	maxInteractionBodySize = 1024 * 1024
+	// Requests signed longer ago than this are rejected, so that a captured request cannot be replayed later
+	maxSignatureAge = time.Minute * 5
+)
+
+// The endpoint is public, so errors returned to the caller do not describe what went wrong
+var (
+	errInvalidSignature = errors.New("invalid request signature")
+	errInvalidRequest   = errors.New("invalid request")
+	errInternal         = errors.New("internal server error")
+)
+
+// discordInteraction contains the fields needed to authenticate the interaction before it is parsed in full
+type discordInteraction struct {
+	Type          interaction.InteractionType `json:"type"`
+	ApplicationId uint64                      `json:"application_id,string"`
+}
+
+// discordInteractionHandler receives interactions sent by Discord to the interactions endpoint URL, rather than
+// forwarded by the relay. Requests are signed with the public key of the bot that received the interaction.
+func discordInteractionHandler(
+	cache *cache.PgCache,
+	commandManager *cmd_manager.CommandManager,
+	buttonManager *btn_manager.ComponentInteractionManager,
+) func(*gin.Context) {
+	var publicKey ed25519.PublicKey
+	if config.Conf.Discord.PublicKey != "" {
+		key, err := decodePublicKey(config.Conf.Discord.PublicKey)
+		if err != nil {
+			panic(err)
+		}
+
+		publicKey = key
+	}
+
+	return func(ctx *gin.Context) {
+		// Checked before the body is read or the database is queried, as the request has not been authenticated yet
+		signature, timestamp := ctx.GetHeader(headerSignature), ctx.GetHeader(headerTimestamp)
+		if signature == "" || !isRecentTimestamp(timestamp, time.Now()) {
+			ctx.JSON(401, newErrorResponse(errInvalidSignature))
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxInteractionBodySize)
+
+		body, err := ctx.GetRawData()
+		if err != nil {
+			ctx.JSON(400, newErrorResponse(errInvalidRequest))
+			return
+		}
+
+		var payload discordInteraction
+		if err := json.Unmarshal(body, &payload); err != nil {
+			ctx.JSON(400, newErrorResponse(errInvalidRequest))
+			return
+		}
+
+		var worker *worker.Context
+		var key ed25519.PublicKey
+		if payload.ApplicationId == config.Conf.Discord.PublicBotId {
+			worker, key = newPublicBotContext(cache), publicKey
+		} else {
+			bot, ok, err := getWhitelabelBot(ctx, payload.ApplicationId)
+			if err != nil {
+				sentry.Error(err)
+				ctx.JSON(500, newErrorResponse(errInternal))
+				return
+			}
+
+			// Unknown applications are indistinguishable from bad signatures, so that bot IDs cannot be enumerated
+			if !ok {
+				ctx.JSON(401, newErrorResponse(errInvalidSignature))
+				return
+			}
+
+			worker, key = newWhitelabelBotContext(cache, bot), bot.publicKey
+		}
+
+		if !verifySignature(key, signature, timestamp, body) {
+			ctx.JSON(401, newErrorResponse(errInvalidSignature))
+			return
+		}
+
+		if payload.Type == interaction.InteractionTypePing {
+			ctx.JSON(200, interaction.NewResponsePong())
+			return
+		}
+
+		handleInteraction(ctx, commandManager, buttonManager, worker, payload.Type, body)
+	}
+}
+
+func newPublicBotContext(cache *cache.PgCache) *worker.Context {
+	return &worker.Context{
+		Token:        config.Conf.Discord.Token,
+		BotId:        config.Conf.Discord.PublicBotId,
+		IsWhitelabel: false,
+		Cache:        cache,
+		RateLimiter:  nil, // Use http-proxy ratelimit functionality
+	}
+}
+
+func newWhitelabelBotContext(cache *cache.PgCache, bot cachedWhitelabelBot) *worker.Context {
+	return &worker.Context{
+		Token:        bot.token,
+		BotId:        bot.botId,
+		IsWhitelabel: true,
+		Cache:        cache,
+		RateLimiter:  nil, // Use http-proxy ratelimit functionality
+	}
+}
+
+// isRecentTimestamp checks that the X-Signature-Timestamp header, which is in Unix seconds, is within
+// maxSignatureAge of now. Discord sends interactions as they happen, so allowance is only needed for clock skew.
+func isRecentTimestamp(timestamp string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+	return age <= maxSignatureAge && age >= -maxSignatureAge
+}
+
+func decodePublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("public key has invalid length")
+	}
+
+	return key, nil
+}
+
+// verifySignature checks that the body was signed by the bot, as described at
+// https://discord.com/developers/docs/interactions/overview#setting-up-an-endpoint-validating-security-request-headers
+func verifySignature(key ed25519.PublicKey, signature, timestamp string, body []byte) bool {
+	if key == nil || signature == "" || timestamp == "" {
+		return false
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return false
+	}
+
+	return ed25519.Verify(key, append([]byte(timestamp), body...), decoded)
+}
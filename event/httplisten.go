@@ -10,7 +10,9 @@ import (
 	btn_manager "github.com/TicketsBot/worker/bot/button/manager"
 	"github.com/TicketsBot/worker/bot/command"
 	cmd_manager "github.com/TicketsBot/worker/bot/command/manager"
+	"github.com/TicketsBot/worker/bot/health"
 	"github.com/TicketsBot/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot/worker/bot/shutdown"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/gin-gonic/gin"
@@ -20,6 +22,7 @@ import (
 	"github.com/rxdn/gdl/objects/interaction"
 	"github.com/rxdn/gdl/rest"
 	"github.com/sirupsen/logrus"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -46,7 +49,16 @@ var successResponse = response{
 	Success: true,
 }
 
-func HttpListen(redis *redis.Client, cache *cache.PgCache) {
+// NewHttpServer builds the server that receives events and interactions. The caller is responsible for starting it,
+// and for shutting it down once the worker has begun draining.
+func NewHttpServer(redis *redis.Client, cache *cache.PgCache) *http.Server {
+	commandManager := new(cmd_manager.CommandManager)
+	commandManager.RegisterCommands()
+	commandManager.RunSetupFuncs()
+
+	buttonManager := btn_manager.NewButtonManager()
+	buttonManager.RegisterCommands()
+
 	router := gin.New()
 
 	// Middleware
@@ -59,10 +71,55 @@ func HttpListen(redis *redis.Client, cache *cache.PgCache) {
 
 	// Routes
 	router.POST("/event", eventHandler(cache))
-	router.POST("/interaction", interactionHandler(redis, cache))
+	router.POST("/interaction", interactionHandler(redis, cache, commandManager, buttonManager))
+	router.POST("/discord/interactions", discordInteractionHandler(cache, commandManager, buttonManager))
+	router.GET("/healthz", livenessHandler)
+	router.GET("/readyz", readinessHandler)
+
+	return &http.Server{
+		Addr:    config.Conf.Bot.HttpAddress,
+		Handler: router,
+	}
+}
+
+type readinessResponse struct {
+	response
+	Draining     bool                               `json:"draining"`
+	Dependencies map[string]health.DependencyStatus `json:"dependencies,omitempty"`
+}
+
+const readinessTimeout = time.Second * 3
+
+// livenessHandler only checks that the process is able to serve requests
+func livenessHandler(ctx *gin.Context) {
+	ctx.JSON(200, successResponse)
+}
+
+// readinessHandler fails once the worker begins draining, so that no new interactions are routed to it, or if any
+// dependency is unhealthy
+func readinessHandler(ctx *gin.Context) {
+	if shutdown.IsDraining() {
+		ctx.JSON(503, readinessResponse{
+			response: response{Success: false},
+			Draining: true,
+		})
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	statuses, healthy := health.Run(checkCtx)
+
+	res := readinessResponse{
+		response:     response{Success: healthy},
+		Dependencies: statuses,
+	}
 
-	if err := router.Run(config.Conf.Bot.HttpAddress); err != nil {
-		panic(err)
+	if healthy {
+		ctx.JSON(200, res)
+	} else {
+		ctx.JSON(503, res)
 	}
 }
 
@@ -98,14 +155,12 @@ func eventHandler(cache *cache.PgCache) func(*gin.Context) {
 	}
 }
 
-func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Context) {
-	commandManager := new(cmd_manager.CommandManager)
-	commandManager.RegisterCommands()
-	commandManager.RunSetupFuncs()
-
-	buttonManager := btn_manager.NewButtonManager()
-	buttonManager.RegisterCommands()
-
+func interactionHandler(
+	redis *redis.Client,
+	cache *cache.PgCache,
+	commandManager *cmd_manager.CommandManager,
+	buttonManager *btn_manager.ComponentInteractionManager,
+) func(*gin.Context) {
 	return func(ctx *gin.Context) {
 		var payload eventforwarding.Interaction
 		if err := ctx.BindJSON(&payload); err != nil {
@@ -121,133 +176,151 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 			RateLimiter:  nil, // Use http-proxy ratelimit functionality
 		}
 
-		switch payload.InteractionType {
-		case interaction.InteractionTypeApplicationCommand:
-			var interactionData interaction.ApplicationCommandInteraction
-			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
-				return
-			}
-
-			responseCh := make(chan interaction.ApplicationCommandCallbackData, 1)
-
-			deferDefault, err := executeCommand(ctx, worker, commandManager.GetCommands(), interactionData, responseCh)
-			if err != nil {
-				marshalled, _ := json.Marshal(payload)
-				logrus.Warnf("error executing payload: %v (payload: %s)", err, string(marshalled))
-				return
-			}
+		handleInteraction(ctx, commandManager, buttonManager, worker, payload.InteractionType, payload.Event)
+	}
+}
 
-			var flags uint
-			if deferDefault {
-				flags = message.SumFlags(message.FlagEphemeral)
-			}
+// handleInteraction responds to the interaction, regardless of whether it was forwarded by the relay or received
+// directly from Discord
+func handleInteraction(
+	ctx *gin.Context,
+	commandManager *cmd_manager.CommandManager,
+	buttonManager *btn_manager.ComponentInteractionManager,
+	worker *worker.Context,
+	interactionType interaction.InteractionType,
+	event []byte,
+) {
+	switch interactionType {
+	case interaction.InteractionTypeApplicationCommand:
+		var interactionData interaction.ApplicationCommandInteraction
+		if err := json.Unmarshal(event, &interactionData); err != nil {
+			logrus.Warnf("error parsing application payload data: %v", err)
+			return
+		}
 
-			res := interaction.NewResponseAckWithSource(flags)
-			ctx.JSON(200, res)
-			ctx.Writer.Flush()
+		responseCh := make(chan interaction.ApplicationCommandCallbackData, 1)
 
-			go handleApplicationCommandResponseAfterDefer(interactionData, worker, responseCh)
+		deferDefault, err := executeCommand(ctx, worker, commandManager.GetCommands(), interactionData, responseCh)
+		if err != nil {
+			logrus.Warnf("error executing payload: %v (payload: %s)", err, string(event))
+			return
+		}
 
-			prometheus.InteractionTimeToReceive.Observe(calculateTimeToReceive(interactionData.Id).Seconds())
-		case interaction.InteractionTypeMessageComponent:
-			var interactionData interaction.MessageComponentInteraction
-			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
-				return
-			}
+		var flags uint
+		if deferDefault {
+			flags = message.SumFlags(message.FlagEphemeral)
+		}
 
-			timeToDefer := calculateTimeToDefer(interactionData.Id)
+		res := interaction.NewResponseAckWithSource(flags)
+		ctx.JSON(200, res)
+		ctx.Writer.Flush()
 
-			responseCh := make(chan button.Response, 1) // Buffer > 0 is important, or it could hang!
-			btn_manager.HandleInteraction(ctx, buttonManager, worker, interactionData, responseCh)
+		shutdown.Go(func() {
+			handleApplicationCommandResponseAfterDefer(interactionData, worker, responseCh)
+		})
 
-			select {
-			case <-time.After(timeToDefer):
-				res := interaction.NewResponseDeferredMessageUpdate()
-				ctx.JSON(200, res)
-				ctx.Writer.Flush()
-			case data := <-responseCh:
-				ctx.JSON(200, data.Build())
-				ctx.Writer.Flush()
-			}
+		prometheus.InteractionTimeToReceive.Observe(calculateTimeToReceive(interactionData.Id).Seconds())
+	case interaction.InteractionTypeMessageComponent:
+		var interactionData interaction.MessageComponentInteraction
+		if err := json.Unmarshal(event, &interactionData); err != nil {
+			logrus.Warnf("error parsing application payload data: %v", err)
+			return
+		}
 
-			go handleButtonResponseAfterDefer(interactionData.InteractionMetadata, worker, time.Now(), responseCh)
+		timeToDefer := calculateTimeToDefer(interactionData.Id)
 
-			prometheus.InteractionTimeToReceive.Observe(calculateTimeToReceive(interactionData.Id).Seconds())
-			prometheus.InteractionTimeToDefer.Observe(timeToDefer.Seconds())
-		case interaction.InteractionTypeApplicationCommandAutoComplete:
-			var interactionData interaction.ApplicationCommandAutoCompleteInteraction
-			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
-				return
-			}
+		responseCh := make(chan button.Response, 1) // Buffer > 0 is important, or it could hang!
+		btn_manager.HandleInteraction(ctx, buttonManager, worker, interactionData, responseCh)
 
-			cmd, ok := commandManager.GetCommands()[interactionData.Data.Name]
-			if !ok {
-				logrus.Warnf("autocomplete for invalid command: %s", interactionData.Data.Name)
-				return
-			}
+		select {
+		case <-time.After(timeToDefer):
+			res := interaction.NewResponseDeferredMessageUpdate()
+			ctx.JSON(200, res)
+			ctx.Writer.Flush()
+		case data := <-responseCh:
+			ctx.JSON(200, data.Build())
+			ctx.Writer.Flush()
+		}
 
-			options := interactionData.Data.Options
-			for len(options) > 0 && options[0].Value == nil { // Value and Options are mutually exclusive, value is never present on subcommands
-				subCommand := options[0]
-
-				var found bool
-				for _, child := range cmd.Properties().Children {
-					if child.Properties().Name == subCommand.Name {
-						cmd = child
-						found = true
-						break
-					}
-				}
+		shutdown.Go(func() {
+			handleButtonResponseAfterDefer(interactionData.InteractionMetadata, worker, time.Now(), responseCh)
+		})
 
-				if !found {
-					logrus.Warnf("subcommand %s does not exist for command %s", subCommand.Name, cmd.Properties().Name)
-					return
-				}
+		prometheus.InteractionTimeToReceive.Observe(calculateTimeToReceive(interactionData.Id).Seconds())
+		prometheus.InteractionTimeToDefer.Observe(timeToDefer.Seconds())
+	case interaction.InteractionTypeApplicationCommandAutoComplete:
+		var interactionData interaction.ApplicationCommandAutoCompleteInteraction
+		if err := json.Unmarshal(event, &interactionData); err != nil {
+			logrus.Warnf("error parsing application payload data: %v", err)
+			return
+		}
 
-				options = subCommand.Options
-			}
+		cmd, ok := commandManager.GetCommands()[interactionData.Data.Name]
+		if !ok {
+			logrus.Warnf("autocomplete for invalid command: %s", interactionData.Data.Name)
+			return
+		}
 
-			focused := findFocusedOption(interactionData.Data.Options)
-			if focused == nil {
-				logrus.Warnf("focused option not found")
-				return
-			}
+		options := interactionData.Data.Options
+		for len(options) > 0 && options[0].Value == nil { // Value and Options are mutually exclusive, value is never present on subcommands
+			subCommand := options[0]
 
-			var handler command.AutoCompleteHandler
-			for _, arg := range cmd.Properties().Arguments {
-				if strings.ToLower(arg.Name) == strings.ToLower(focused.Name) {
-					handler = arg.AutoCompleteHandler
+			var found bool
+			for _, child := range cmd.Properties().Children {
+				if child.Properties().Name == subCommand.Name {
+					cmd = child
+					found = true
+					break
 				}
 			}
 
-			if handler == nil {
-				logrus.Warnf("autocomplete for argument without handler: %s", focused.Name)
+			if !found {
+				logrus.Warnf("subcommand %s does not exist for command %s", subCommand.Name, cmd.Properties().Name)
 				return
 			}
 
-			choices := handler(interactionData, fmt.Sprintf("%v", focused.Value))
-			res := interaction.NewApplicationCommandAutoCompleteResultResponse(choices)
-			ctx.JSON(200, res)
-			ctx.Writer.Flush()
+			options = subCommand.Options
+		}
 
-		case interaction.InteractionTypeModalSubmit:
-			var interactionData interaction.ModalSubmitInteraction
-			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
-				return
+		focused := findFocusedOption(interactionData.Data.Options)
+		if focused == nil {
+			logrus.Warnf("focused option not found")
+			return
+		}
+
+		var handler command.AutoCompleteHandler
+		for _, arg := range cmd.Properties().Arguments {
+			if strings.ToLower(arg.Name) == strings.ToLower(focused.Name) {
+				handler = arg.AutoCompleteHandler
 			}
+		}
 
-			ctx.JSON(200, interaction.NewResponseDeferredMessageUpdate())
-			ctx.Writer.Flush()
+		if handler == nil {
+			logrus.Warnf("autocomplete for argument without handler: %s", focused.Name)
+			return
+		}
 
-			responseCh := make(chan button.Response, 1)
-			btn_manager.HandleModalInteraction(ctx, buttonManager, worker, interactionData, responseCh)
+		choices := handler(interactionData, fmt.Sprintf("%v", focused.Value))
+		res := interaction.NewApplicationCommandAutoCompleteResultResponse(choices)
+		ctx.JSON(200, res)
+		ctx.Writer.Flush()
 
-			go handleButtonResponseAfterDefer(interactionData.InteractionMetadata, worker, time.Now(), responseCh)
+	case interaction.InteractionTypeModalSubmit:
+		var interactionData interaction.ModalSubmitInteraction
+		if err := json.Unmarshal(event, &interactionData); err != nil {
+			logrus.Warnf("error parsing application payload data: %v", err)
+			return
 		}
+
+		ctx.JSON(200, interaction.NewResponseDeferredMessageUpdate())
+		ctx.Writer.Flush()
+
+		responseCh := make(chan button.Response, 1)
+		btn_manager.HandleModalInteraction(ctx, buttonManager, worker, interactionData, responseCh)
+
+		shutdown.Go(func() {
+			handleButtonResponseAfterDefer(interactionData.InteractionMetadata, worker, time.Now(), responseCh)
+		})
 	}
 }
 
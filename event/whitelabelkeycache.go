@@ -0,0 +1,82 @@
+package event
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+
+	"github.com/TicketsBot/worker/bot/dbclient"
+)
+
+const (
+	// whitelabelBotCacheExpiry also bounds how long a rotated token or public key is used for
+	whitelabelBotCacheExpiry = time.Minute * 5
+	// The interactions endpoint is public, so the cache is bounded in case it is sent many made-up application IDs
+	maxCachedWhitelabelBots = 10000
+)
+
+// cachedWhitelabelBot is the token and public key of a whitelabel bot. If the bot does not exist, the zero value is
+// cached, so that repeated requests for it do not reach the database either.
+type cachedWhitelabelBot struct {
+	botId     uint64
+	token     string
+	publicKey ed25519.PublicKey
+	expiresAt time.Time
+}
+
+var (
+	whitelabelBots   = make(map[uint64]cachedWhitelabelBot)
+	whitelabelBotsMu sync.RWMutex
+)
+
+// getWhitelabelBot returns false if there is no whitelabel bot with the ID
+func getWhitelabelBot(ctx context.Context, botId uint64) (cachedWhitelabelBot, bool, error) {
+	whitelabelBotsMu.RLock()
+	cached, ok := whitelabelBots[botId]
+	whitelabelBotsMu.RUnlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached, cached.botId != 0, nil
+	}
+
+	bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, botId)
+	if err != nil {
+		return cachedWhitelabelBot{}, false, err
+	}
+
+	cached = cachedWhitelabelBot{
+		expiresAt: time.Now().Add(whitelabelBotCacheExpiry),
+	}
+
+	if bot.BotId != 0 {
+		key, err := decodePublicKey(bot.PublicKey)
+		if err != nil {
+			return cachedWhitelabelBot{}, false, err
+		}
+
+		cached.botId = bot.BotId
+		cached.token = bot.Token
+		cached.publicKey = key
+	}
+
+	whitelabelBotsMu.Lock()
+	defer whitelabelBotsMu.Unlock()
+
+	if len(whitelabelBots) >= maxCachedWhitelabelBots {
+		now := time.Now()
+		for id, bot := range whitelabelBots {
+			if now.After(bot.expiresAt) {
+				delete(whitelabelBots, id)
+			}
+		}
+
+		// Every entry is still valid, so start again rather than growing without limit
+		if len(whitelabelBots) >= maxCachedWhitelabelBots {
+			whitelabelBots = make(map[uint64]cachedWhitelabelBot)
+		}
+	}
+
+	whitelabelBots[botId] = cached
+	return cached, cached.botId != 0, nil
+}
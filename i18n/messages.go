@@ -19,6 +19,7 @@ var (
 	ClickHere MessageId = "generic.click_here"
 	Confirm   MessageId = "generic.confirm"
 	Website   MessageId = "generic.website"
+	Cancel    MessageId = "generic.cancel"
 
 	TitlePremiumOnly       MessageId = "generic.title.premium_only"
 	TitleAbout             MessageId = "generic.title.about"
@@ -51,6 +52,24 @@ var (
 	TitlePanelSwitched     MessageId = "generic.title.panel_switched"
 	TitleJumpToTop         MessageId = "generic.title.jump_to_top"
 	TitleReopened          MessageId = "generic.title.reopened"
+	TitleSettings          MessageId = "generic.title.settings"
+	TitleAdopted           MessageId = "generic.title.adopted"
+	TitlePriority          MessageId = "generic.title.priority"
+	TitleEscalation        MessageId = "generic.title.escalation"
+	TitleSla               MessageId = "generic.title.sla"
+	TitleScheduledClose    MessageId = "generic.title.scheduled_close"
+	TitleMerge             MessageId = "generic.title.merge"
+	TitleSplit             MessageId = "generic.title.split"
+	TitleAutoAssign        MessageId = "generic.title.auto_assign"
+	TitleOnCall            MessageId = "generic.title.on_call"
+	TitleAway              MessageId = "generic.title.away"
+	TitleWatch             MessageId = "generic.title.watch"
+	TitleBulk              MessageId = "generic.title.bulk"
+	TitleSearch            MessageId = "generic.title.search"
+	TitleHistory           MessageId = "generic.title.history"
+	TitleLabel             MessageId = "generic.title.label"
+	TitleNotes             MessageId = "generic.title.notes"
+	TitleModmail           MessageId = "generic.title.modmail"
 
 	MessageAbout   MessageId = "commands.about"
 	MessagePremium MessageId = "commands.premium"
@@ -91,6 +110,7 @@ var (
 
 	MessageOpenThreadAnnouncementChannel MessageId = "open.thread_in_announcement_channel"
 	MessageOpenRatelimited               MessageId = "open.ratelimited"
+	MessageOpenRatelimitedRetry          MessageId = "open.ratelimited_retry"
 	MessageOpenPanelForceDisabled        MessageId = "open.panel_force_disabled"
 	MessageOpenPanelDisabled             MessageId = "open.panel_disabled"
 	MessageTicketOpened                  MessageId = "open.success"
@@ -108,9 +128,12 @@ var (
 	MessageAddSupportSuccess   MessageId = "commands.addsupport.success"
 	MessageAddSupportEveryone  MessageId = "commands.addsupport.everyone"
 
-	MessageAddNoMembers    MessageId = "commands.add.no_members"
-	MessageAddNoPermission MessageId = "commands.add.no_permission"
-	MessageAddSuccess      MessageId = "commands.add.success"
+	MessageAddNoMembers        MessageId = "commands.add.no_members"
+	MessageAddNoPermission     MessageId = "commands.add.no_permission"
+	MessageAddSuccess          MessageId = "commands.add.success"
+	MessageAddSuccessTemporary MessageId = "commands.add.success_temporary"
+	MessageAddInvalidDuration  MessageId = "commands.add.invalid_duration"
+	MessageAddAccessExpired    MessageId = "commands.add.access_expired"
 
 	MessageBlacklisted         MessageId = "generic.error.blacklisted"
 	MessageBlacklistNoMembers  MessageId = "commands.blacklist.no_members"
@@ -231,6 +254,208 @@ var (
 	MessageNotesAddedToExisting MessageId = "commands.notes.added_to_existing"
 	MessageNotesCreated         MessageId = "commands.notes.created"
 
+	MessageNoteInvalid         MessageId = "commands.note.invalid"
+	MessageNoteInvalidContent  MessageId = "commands.note.invalid_content"
+	MessageNoteLimit           MessageId = "commands.note.limit"
+	MessageNoteNotAuthor       MessageId = "commands.note.not_author"
+	MessageNoteAdded           MessageId = "commands.note.added"
+	MessageNoteEdited          MessageId = "commands.note.edited"
+	MessageNoteDeleted         MessageId = "commands.note.deleted"
+	MessageNoteListEmpty       MessageId = "commands.note.list_empty"
+	MessageNoteListEntry       MessageId = "commands.note.list_entry"
+	MessageNoteListEntryEdited MessageId = "commands.note.list_entry_edited"
+
+	MessageModmailPrompt            MessageId = "commands.modmail.prompt"
+	MessageModmailPromptPlaceholder MessageId = "commands.modmail.prompt_placeholder"
+	MessageModmailUnavailable       MessageId = "commands.modmail.unavailable"
+	MessageModmailAlreadyOpen       MessageId = "commands.modmail.already_open"
+	MessageModmailOpened            MessageId = "commands.modmail.opened"
+	MessageModmailRelayFailed       MessageId = "commands.modmail.relay_failed"
+	MessageModmailNotModmailTicket  MessageId = "commands.reply.not_modmail"
+	MessageModmailDMsDisabled       MessageId = "commands.reply.dms_disabled"
+	MessageModmailAnonymousAuthor   MessageId = "commands.reply.anonymous_author"
+	MessageModmailSentAnonymously   MessageId = "commands.reply.sent_anonymously"
+	MessageModmailAttachment        MessageId = "commands.reply.attachment"
+
+	MessageAdoptCategoryNotCategory  MessageId = "commands.settings.adopt_category.not_category"
+	MessageAdoptCategoryLimit        MessageId = "commands.settings.adopt_category.add.limit"
+	MessageAdoptCategoryAdded        MessageId = "commands.settings.adopt_category.add.success"
+	MessageAdoptCategoryNotAdoptable MessageId = "commands.settings.adopt_category.remove.not_adoptable"
+	MessageAdoptCategoryRemoved      MessageId = "commands.settings.adopt_category.remove.success"
+	MessageAdoptCategoryList         MessageId = "commands.settings.adopt_category.list"
+	MessageAdoptCategoryListEmpty    MessageId = "commands.settings.adopt_category.list.empty"
+	MessageAdoptInvalidChannel       MessageId = "commands.adopt.invalid_channel"
+	MessageAdoptAlreadyTicket        MessageId = "commands.adopt.already_ticket"
+	MessageAdoptSuccess              MessageId = "commands.adopt.success"
+
+	MessageTranscriptSettingsInvalidFormat   MessageId = "commands.settings.transcript.invalid_format"
+	MessageTranscriptSettingsUpdated         MessageId = "commands.settings.transcript.updated"
+	MessageTranscriptSettingsUpdatedAttached MessageId = "commands.settings.transcript.updated_attached"
+
+	MessageOpenRateLimitInvalidScope  MessageId = "commands.settings.open_ratelimit.invalid_scope"
+	MessageOpenRateLimitInvalidWindow MessageId = "commands.settings.open_ratelimit.invalid_window"
+	MessageOpenRateLimitGuildTooHigh  MessageId = "commands.settings.open_ratelimit.guild_too_high"
+	MessageOpenRateLimitUpdated       MessageId = "commands.settings.open_ratelimit.updated"
+	MessageOpenRateLimitDisabled      MessageId = "commands.settings.open_ratelimit.disabled"
+	MessageOpenRateLimitReset         MessageId = "commands.settings.open_ratelimit.reset"
+	MessageCloseRequestRatelimited    MessageId = "commands.close_request.ratelimited"
+	MessageTagRatelimited             MessageId = "commands.tag.ratelimited"
+
+	MessagePriorityInvalid                MessageId = "commands.priority.invalid"
+	MessagePrioritySet                    MessageId = "commands.priority.set.success"
+	MessagePrioritySetRenameRatelimited   MessageId = "commands.priority.set.rename_ratelimited"
+	MessagePriorityPanelSet               MessageId = "commands.priority.panel.success"
+	MessagePriorityPanelSetSelectable     MessageId = "commands.priority.panel.success_selectable"
+	MessagePrioritySelectPrompt           MessageId = "priority.select.prompt"
+	MessagePrioritySelectPlaceholder      MessageId = "priority.select.placeholder"
+	MessagePrioritySelectNoPermission     MessageId = "priority.select.no_permission"
+	MessagePrioritySelectLocked           MessageId = "priority.select.locked"
+	MessagePrioritySettingsInvalidMinutes MessageId = "commands.settings.priority.invalid_minutes"
+	MessagePrioritySettingsUpdated        MessageId = "commands.settings.priority.updated"
+	MessagePrioritySettingsEscalationOff  MessageId = "commands.settings.priority.updated_escalation_disabled"
+	MessagePriorityEscalation             MessageId = "priority.escalation"
+
+	MessageSlaKindFirstResponse         MessageId = "sla.kind.first_response"
+	MessageSlaKindNextResponse          MessageId = "sla.kind.next_response"
+	MessageSlaKindResolution            MessageId = "sla.kind.resolution"
+	MessageSlaWarning                   MessageId = "sla.warning"
+	MessageSlaWarningStaff              MessageId = "sla.warning.staff"
+	MessageSlaBreachStaff               MessageId = "sla.breach.staff"
+	MessageSlaSettingsInvalidTarget     MessageId = "commands.settings.sla.invalid_target"
+	MessageSlaSettingsInvalidWarning    MessageId = "commands.settings.sla.invalid_warning_percent"
+	MessageSlaSettingsUpdated           MessageId = "commands.settings.sla.updated"
+	MessageSlaSettingsRemoved           MessageId = "commands.settings.sla.removed"
+	MessageBusinessHoursInvalidTimezone MessageId = "commands.settings.business_hours.invalid_timezone"
+	MessageBusinessHoursInvalidDays     MessageId = "commands.settings.business_hours.invalid_days"
+	MessageBusinessHoursInvalidTime     MessageId = "commands.settings.business_hours.invalid_time"
+	MessageBusinessHoursUpdated         MessageId = "commands.settings.business_hours.updated"
+	MessageBusinessHoursRemoved         MessageId = "commands.settings.business_hours.removed"
+
+	MessageScheduledCloseInvalidDelay   MessageId = "commands.close.scheduled.invalid_delay"
+	MessageScheduledCloseScheduled      MessageId = "commands.close.scheduled.success"
+	MessageScheduledCloseNoReason       MessageId = "commands.close.scheduled.countdown"
+	MessageScheduledCloseWithReason     MessageId = "commands.close.scheduled.countdown_with_reason"
+	MessageScheduledCloseCancel         MessageId = "commands.close.scheduled.cancel"
+	MessageScheduledCloseCancelled      MessageId = "commands.close.scheduled.cancelled"
+	MessageScheduledCloseCancelledReply MessageId = "commands.close.scheduled.cancelled_reply"
+	MessageScheduledCloseNotScheduled   MessageId = "commands.close.scheduled.not_scheduled"
+
+	MessageMergeSameTicket     MessageId = "commands.merge.same_ticket"
+	MessageMergeTargetInvalid  MessageId = "commands.merge.target_invalid"
+	MessageMergeNoPermission   MessageId = "commands.merge.no_permission"
+	MessageMergeSuccess        MessageId = "commands.merge.success"
+	MessageMergeSummary        MessageId = "commands.merge.summary"
+	MessageMergeRecentMessages MessageId = "commands.merge.recent_messages"
+
+	MessageSplitChoosePanel  MessageId = "commands.split.choose_panel"
+	MessageSplitSelectPanel  MessageId = "commands.split.select_panel"
+	MessageSplitChooseOpener MessageId = "commands.split.choose_opener"
+	MessageSplitSelectOpener MessageId = "commands.split.select_opener"
+	MessageSplitConfirm      MessageId = "commands.split.confirm"
+	MessageSplitSource       MessageId = "commands.split.source"
+	MessageSplitTarget       MessageId = "commands.split.target"
+
+	MessageAutoAssigned              MessageId = "auto_assign.assigned"
+	MessageAutoAssignUnassigned      MessageId = "auto_assign.unassigned"
+	MessageAutoAssignInvalidStrategy MessageId = "commands.settings.auto_assign.invalid_strategy"
+	MessageAutoAssignDisabled        MessageId = "commands.settings.auto_assign.disabled"
+	MessageAutoAssignUpdated         MessageId = "commands.settings.auto_assign.updated"
+
+	MessageOnCallDefaultTeam             MessageId = "commands.on_call.default_team"
+	MessageOnCallWho                     MessageId = "commands.on_call.who"
+	MessageOnCallWhoEmpty                MessageId = "commands.on_call.who.empty"
+	MessageOnCallScheduleInvalidDays     MessageId = "commands.on_call.schedule.invalid_days"
+	MessageOnCallScheduleInvalidTime     MessageId = "commands.on_call.schedule.invalid_time"
+	MessageOnCallScheduleInvalidTimezone MessageId = "commands.on_call.schedule.invalid_timezone"
+	MessageOnCallScheduleInvalidTeam     MessageId = "commands.on_call.schedule.invalid_team"
+	MessageOnCallScheduleLimit           MessageId = "commands.on_call.schedule.limit"
+	MessageOnCallScheduleAdded           MessageId = "commands.on_call.schedule.added"
+	MessageOnCallScheduleRemoved         MessageId = "commands.on_call.schedule.removed"
+	MessageOnCallScheduleNotFound        MessageId = "commands.on_call.schedule.not_found"
+	MessageOnCallScheduleList            MessageId = "commands.on_call.schedule.list"
+	MessageOnCallScheduleListEmpty       MessageId = "commands.on_call.schedule.list_empty"
+	MessageOnCallHandover                MessageId = "on_call.handover"
+	MessageOnCallHandoverEmpty           MessageId = "on_call.handover.empty"
+	MessageOnCallHandoverMore            MessageId = "on_call.handover.more"
+
+	MessageAwayInvalidUntil   MessageId = "commands.away.invalid_until"
+	MessageAwaySet            MessageId = "commands.away.set"
+	MessageAwayCleared        MessageId = "commands.away.cleared"
+	MessageAwayNotAway        MessageId = "commands.away.not_away"
+	MessageAwayOnCall         MessageId = "commands.away.on_call"
+	MessageAwayTransferTarget MessageId = "commands.away.transfer_target"
+	MessageAwayNotice         MessageId = "away.notice"
+	MessageAwayNoticeWithNote MessageId = "away.notice_with_note"
+
+	MessageWatchSuccess         MessageId = "commands.watch.success"
+	MessageWatchAlreadyWatching MessageId = "commands.watch.already_watching"
+	MessageWatchNotWatching     MessageId = "commands.watch.not_watching"
+	MessageWatchNoPermission    MessageId = "commands.watch.no_permission"
+	MessageUnwatchSuccess       MessageId = "commands.unwatch.success"
+	MessageWatchDigest          MessageId = "watch.digest"
+	MessageWatchDigestTicket    MessageId = "watch.digest.ticket"
+	MessageWatchEventMessages   MessageId = "watch.event.messages"
+	MessageWatchEventStatus     MessageId = "watch.event.status"
+	MessageWatchEventClaim      MessageId = "watch.event.claim"
+	MessageWatchEventTransfer   MessageId = "watch.event.transfer"
+	MessageWatchEventClose      MessageId = "watch.event.close"
+
+	MessageBulkInvalidDuration MessageId = "commands.tickets.bulk.invalid_duration"
+	MessageBulkInvalidStatus   MessageId = "commands.tickets.bulk.invalid_status"
+	MessageBulkNoMatches       MessageId = "commands.tickets.bulk.no_matches"
+	MessageBulkPreview         MessageId = "commands.tickets.bulk.preview"
+	MessageBulkPreviewClose    MessageId = "commands.tickets.bulk.preview.close"
+	MessageBulkPreviewClaim    MessageId = "commands.tickets.bulk.preview.claim"
+	MessageBulkPreviewMove     MessageId = "commands.tickets.bulk.preview.move"
+	MessageBulkPreviewTag      MessageId = "commands.tickets.bulk.preview.tag"
+	MessageBulkPreviewMore     MessageId = "commands.tickets.bulk.preview.more"
+	MessageBulkLimited         MessageId = "commands.tickets.bulk.limited"
+	MessageBulkExpired         MessageId = "commands.tickets.bulk.expired"
+	MessageBulkCancelled       MessageId = "commands.tickets.bulk.cancelled"
+	MessageBulkProgress        MessageId = "commands.tickets.bulk.progress"
+	MessageBulkComplete        MessageId = "commands.tickets.bulk.complete"
+
+	MessageSearchInvalidDate        MessageId = "commands.tickets.search.invalid_date"
+	MessageSearchInvalidRating      MessageId = "commands.tickets.search.invalid_rating"
+	MessageSearchNoResults          MessageId = "commands.tickets.search.no_results"
+	MessageSearchExpired            MessageId = "commands.tickets.search.expired"
+	MessageSearchResultOpen         MessageId = "commands.tickets.search.result.open"
+	MessageSearchResultClosed       MessageId = "commands.tickets.search.result.closed"
+	MessageSearchResultNoTranscript MessageId = "commands.tickets.search.result.no_transcript"
+	MessageSearchClaimedBy          MessageId = "commands.tickets.search.claimed_by"
+	MessageSearchClosedAt           MessageId = "commands.tickets.search.closed_at"
+	MessageSearchRating             MessageId = "commands.tickets.search.rating"
+	MessageSearchCloseReason        MessageId = "commands.tickets.search.close_reason"
+	MessageSearchPanel              MessageId = "commands.tickets.search.panel"
+
+	MessageHistoryStats            MessageId = "commands.history.stats"
+	MessageHistoryAverageRating    MessageId = "commands.history.average_rating"
+	MessageHistoryNoRatings        MessageId = "commands.history.no_ratings"
+	MessageHistoryBlacklisted      MessageId = "commands.history.blacklisted"
+	MessageHistoryNotBlacklisted   MessageId = "commands.history.not_blacklisted"
+	MessageHistoryNoTickets        MessageId = "commands.history.no_tickets"
+	MessageHistoryMore             MessageId = "commands.history.more"
+	MessageHistoryReturning        MessageId = "history.returning"
+	MessageHistorySettingsEnabled  MessageId = "commands.settings.history.enabled"
+	MessageHistorySettingsDisabled MessageId = "commands.settings.history.disabled"
+	MessageModmailSettingsEnabled  MessageId = "commands.settings.modmail.enabled"
+	MessageModmailSettingsDisabled MessageId = "commands.settings.modmail.disabled"
+
+	MessageLabelInvalid       MessageId = "commands.label.invalid"
+	MessageLabelAdded         MessageId = "commands.label.added"
+	MessageLabelAlreadyAdded  MessageId = "commands.label.already_added"
+	MessageLabelRemoved       MessageId = "commands.label.removed"
+	MessageLabelNotAdded      MessageId = "commands.label.not_added"
+	MessageLabelInvalidName   MessageId = "commands.settings.labels.invalid_name"
+	MessageLabelInvalidColour MessageId = "commands.settings.labels.invalid_colour"
+	MessageLabelInvalidEmoji  MessageId = "commands.settings.labels.invalid_emoji"
+	MessageLabelLimit         MessageId = "commands.settings.labels.limit"
+	MessageLabelExists        MessageId = "commands.settings.labels.exists"
+	MessageLabelCreated       MessageId = "commands.settings.labels.created"
+	MessageLabelDeleted       MessageId = "commands.settings.labels.deleted"
+	MessageLabelList          MessageId = "commands.settings.labels.list"
+	MessageLabelListEmpty     MessageId = "commands.settings.labels.list_empty"
+
 	MessageJoinClosedTicket       MessageId = "button.join_thread.closed_ticket"
 	MessageJoinThreadNoPermission MessageId = "button.join_thread.no_permission"
 	MessageAlreadyJoinedThread    MessageId = "button.join_thread.already_joined"
@@ -270,44 +495,96 @@ var (
 	MessageButtonGuildOnly MessageId = "button.guild_only"
 	MessageButtonDMOnly    MessageId = "button.dms_only"
 
-	HelpAdmin              MessageId = "help.admin"
-	HelpAdminGenPremium    MessageId = "help.admin.generate_premium"
-	HelpAdminGetOwner      MessageId = "help.admin.get_owner"
-	HelpAbout              MessageId = "help.about"
-	HelpAutoClose          MessageId = "help.autoclose"
-	HelpAutoCloseExclude   MessageId = "help.autoclose.exclude"
-	HelpAutoCloseConfigure MessageId = "help.autoclose.configure"
-	HelpVote               MessageId = "help.vote"
-	HelpAddAdmin           MessageId = "help.addadmin"
-	HelpAddSupport         MessageId = "help.addsupport"
-	HelpBlacklist          MessageId = "help.blacklist"
-	HelpPanel              MessageId = "help.panel"
-	HelpPremium            MessageId = "help.premium"
-	HelpRemoveSupport      MessageId = "help.removesupport"
-	HelpSetup              MessageId = "help.setup"
-	HelpViewStaff          MessageId = "help.viewstaff"
-	HelpStats              MessageId = "help.stats"
-	HelpStatsServer        MessageId = "help.statsserver"
-	HelpManageTags         MessageId = "help.managetags"
-	HelpTagAdd             MessageId = "help.taggadd"
-	HelpTagDelete          MessageId = "help.tagdelete"
-	HelpTagList            MessageId = "help.taglist"
-	HelpTag                MessageId = "help.tag"
-	HelpAdd                MessageId = "help.add"
-	HelpClaim              MessageId = "help.claim"
-	HelpClose              MessageId = "help.close"
-	HelpCloseRequest       MessageId = "help.close_request"
-	HelpNotes              MessageId = "help.notes"
-	HelpOpen               MessageId = "help.open"
-	HelpRemove             MessageId = "help.remove"
-	HelpRename             MessageId = "help.rename"
-	HelpReopen             MessageId = "help.reopen"
-	HelpTransfer           MessageId = "help.transfer"
-	HelpUnclaim            MessageId = "help.unclaim"
-	HelpHelp               MessageId = "help.help"
-	HelpRemoveAdmin        MessageId = "help.removeadmin"
-	HelpLanguage           MessageId = "help.language"
-	HelpSwitchPanel        MessageId = "help.switch_panel"
-	HelpJumpToTop          MessageId = "help.jump_to_top"
-	HelpOnCall             MessageId = "help.on_call"
+	HelpAdmin                MessageId = "help.admin"
+	HelpAdminGenPremium      MessageId = "help.admin.generate_premium"
+	HelpAdminGetOwner        MessageId = "help.admin.get_owner"
+	HelpAbout                MessageId = "help.about"
+	HelpAutoClose            MessageId = "help.autoclose"
+	HelpAutoCloseExclude     MessageId = "help.autoclose.exclude"
+	HelpAutoCloseConfigure   MessageId = "help.autoclose.configure"
+	HelpVote                 MessageId = "help.vote"
+	HelpAddAdmin             MessageId = "help.addadmin"
+	HelpAddSupport           MessageId = "help.addsupport"
+	HelpBlacklist            MessageId = "help.blacklist"
+	HelpPanel                MessageId = "help.panel"
+	HelpPremium              MessageId = "help.premium"
+	HelpRemoveSupport        MessageId = "help.removesupport"
+	HelpSetup                MessageId = "help.setup"
+	HelpViewStaff            MessageId = "help.viewstaff"
+	HelpStats                MessageId = "help.stats"
+	HelpStatsServer          MessageId = "help.statsserver"
+	HelpManageTags           MessageId = "help.managetags"
+	HelpTagAdd               MessageId = "help.taggadd"
+	HelpTagDelete            MessageId = "help.tagdelete"
+	HelpTagList              MessageId = "help.taglist"
+	HelpTag                  MessageId = "help.tag"
+	HelpAdd                  MessageId = "help.add"
+	HelpClaim                MessageId = "help.claim"
+	HelpClose                MessageId = "help.close"
+	HelpCloseRequest         MessageId = "help.close_request"
+	HelpNotes                MessageId = "help.notes"
+	HelpNote                 MessageId = "help.note"
+	HelpNoteAdd              MessageId = "help.note.add"
+	HelpNoteList             MessageId = "help.note.list"
+	HelpNoteEdit             MessageId = "help.note.edit"
+	HelpNoteDelete           MessageId = "help.note.delete"
+	HelpReply                MessageId = "help.reply"
+	HelpAReply               MessageId = "help.areply"
+	HelpOpen                 MessageId = "help.open"
+	HelpRemove               MessageId = "help.remove"
+	HelpRename               MessageId = "help.rename"
+	HelpReopen               MessageId = "help.reopen"
+	HelpTransfer             MessageId = "help.transfer"
+	HelpUnclaim              MessageId = "help.unclaim"
+	HelpHelp                 MessageId = "help.help"
+	HelpRemoveAdmin          MessageId = "help.removeadmin"
+	HelpLanguage             MessageId = "help.language"
+	HelpSwitchPanel          MessageId = "help.switch_panel"
+	HelpJumpToTop            MessageId = "help.jump_to_top"
+	HelpOnCall               MessageId = "help.on_call"
+	HelpOnCallToggle         MessageId = "help.on_call.toggle"
+	HelpOnCallWho            MessageId = "help.on_call.who"
+	HelpOnCallSchedule       MessageId = "help.on_call.schedule"
+	HelpOnCallScheduleAdd    MessageId = "help.on_call.schedule.add"
+	HelpOnCallScheduleRemove MessageId = "help.on_call.schedule.remove"
+	HelpOnCallScheduleList   MessageId = "help.on_call.schedule.list"
+	HelpAway                 MessageId = "help.away"
+	HelpAwaySet              MessageId = "help.away.set"
+	HelpAwayClear            MessageId = "help.away.clear"
+	HelpWatch                MessageId = "help.watch"
+	HelpUnwatch              MessageId = "help.unwatch"
+	HelpTickets              MessageId = "help.tickets"
+	HelpTicketsBulk          MessageId = "help.tickets.bulk"
+	HelpTicketsBulkClose     MessageId = "help.tickets.bulk.close"
+	HelpTicketsBulkClaim     MessageId = "help.tickets.bulk.claim"
+	HelpTicketsBulkMove      MessageId = "help.tickets.bulk.move"
+	HelpTicketsBulkTag       MessageId = "help.tickets.bulk.tag"
+	HelpTicketsSearch        MessageId = "help.tickets.search"
+	HelpHistory              MessageId = "help.history"
+	HelpLabel                MessageId = "help.label"
+	HelpLabelAdd             MessageId = "help.label.add"
+	HelpLabelRemove          MessageId = "help.label.remove"
+
+	HelpSettings            MessageId = "help.settings"
+	HelpAdoptCategory       MessageId = "help.settings.adopt_category"
+	HelpAdoptCategoryAdd    MessageId = "help.settings.adopt_category.add"
+	HelpAdoptCategoryRemove MessageId = "help.settings.adopt_category.remove"
+	HelpAdoptCategoryList   MessageId = "help.settings.adopt_category.list"
+	HelpAdopt               MessageId = "help.adopt"
+	HelpTranscriptSettings  MessageId = "help.settings.transcript"
+	HelpOpenRateLimit       MessageId = "help.settings.open_ratelimit"
+	HelpPriority            MessageId = "help.priority"
+	HelpPrioritySet         MessageId = "help.priority.set"
+	HelpPriorityPanel       MessageId = "help.priority.panel"
+	HelpPrioritySettings    MessageId = "help.settings.priority"
+	HelpSlaSettings         MessageId = "help.settings.sla"
+	HelpBusinessHours       MessageId = "help.settings.business_hours"
+	HelpMerge               MessageId = "help.merge"
+	HelpAutoAssignSettings  MessageId = "help.settings.auto_assign"
+	HelpHistorySettings     MessageId = "help.settings.history"
+	HelpModmailSettings     MessageId = "help.settings.modmail"
+	HelpLabelsSettings      MessageId = "help.settings.labels"
+	HelpLabelsCreate        MessageId = "help.settings.labels.create"
+	HelpLabelsDelete        MessageId = "help.settings.labels.delete"
+	HelpLabelsList          MessageId = "help.settings.labels.list"
 )
@@ -43,10 +43,7 @@ func main() {
 
 	allCmds := make([]registry.Command, 0, len(cm.GetCommands()))
 	for _, cmd := range cm.GetCommands() {
-		allCmds = append(allCmds, cmd)
-		for _, sub := range cmd.Properties().Children {
-			allCmds = append(allCmds, sub)
-		}
+		allCmds = appendWithChildren(allCmds, cmd)
 	}
 
 	var packagePaths []string
@@ -97,3 +94,13 @@ func main() {
 		panic(err)
 	}
 }
+
+// Subcommand groups may be nested, so walk the whole tree
+func appendWithChildren(cmds []registry.Command, cmd registry.Command) []registry.Command {
+	cmds = append(cmds, cmd)
+	for _, sub := range cmd.Properties().Children {
+		cmds = appendWithChildren(cmds, sub)
+	}
+
+	return cmds
+}